@@ -0,0 +1,23 @@
+// Web Push用のVAPIDの鍵を作成し、.envに設定する形式で出力する
+//
+//	go run ./cmd/generate-vapid-keys
+package main
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log"
+)
+
+func main() {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		log.Fatal("Could not generate VAPID key:", err)
+	}
+
+	fmt.Printf("VAPID_PRIVATE_KEY=%s\n", base64.RawURLEncoding.EncodeToString(key.Bytes()))
+	fmt.Println("VAPID_SUBJECT=mailto:admin@example.com")
+	fmt.Printf("# 公開鍵（GET /push/vapid_public_key でも取得できます）: %s\n", base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()))
+}
@@ -5,7 +5,11 @@ import (
 	"log"
 	"os"
 	"time"
+	_ "time/tzdata" // ユーザーのタイムゾーン解決用（alpineイメージにはtzdataが含まれない）
 
+	"backend/internal/config"
+	"backend/internal/domain/model/notification"
+	"backend/internal/domain/model/point"
 	"backend/internal/handler"
 	"backend/internal/infrastructure/database"
 	"backend/internal/infrastructure/repositoryImpl"
@@ -42,30 +46,123 @@ func main() {
 	defer dbClient.Disconnect(ctx)
 	log.Println("Connected to DB!")
 
+	// インデックスの作成
+	db := dbClient.Client().Database(dbName)
+	if err := database.EnsureIndexes(context.Background(), db); err != nil {
+		log.Fatal("Could not create indexes:", err)
+	}
+	// 台帳導入前のポイントの繰越
+	if err := database.MigrateOpeningBalances(context.Background(), db); err != nil {
+		log.Fatal("Could not migrate opening balances:", err)
+	}
+
 	// --- 依存性の解決とインスタンス化 ---
 	// 1. 各リポジトリを生成し、DBクライアントを注入
-	db := dbClient.Client().Database(dbName)
 	userRepo := repositoryImpl.NewUserRepository(db.Collection("user"))
 	habitRepo := repositoryImpl.NewHabitRepository(db.Collection("habits"))
 	dailyTrackRepo := repositoryImpl.NewDailyTrackRepository(db.Collection("daily_track"))
+	pointLedgerRepo := repositoryImpl.NewPointLedgerRepository(db.Collection("points_ledger"))
+	streakFreezeRepo := repositoryImpl.NewStreakFreezeRepository(db.Collection("streak_freezes"))
+	statsRepo := repositoryImpl.NewStatsRepository(db.Collection("daily_track"))
+	sessionRepo := repositoryImpl.NewSessionRepository(db.Collection("sessions"))
+	rewardRepo := repositoryImpl.NewRewardRepository(db.Collection("rewards"))
+	redemptionRepo := repositoryImpl.NewRewardRedemptionRepository(db.Collection("reward_redemptions"))
+	achievementRepo := repositoryImpl.NewAchievementRepository(db.Collection("achievements"))
+	rankingRepo := repositoryImpl.NewRankingRepository(db.Collection("rankings"))
+	friendshipRepo := repositoryImpl.NewFriendshipRepository(db.Collection("friendships"))
+	habitShareRepo := repositoryImpl.NewHabitShareRepository(db.Collection("habit_shares"))
+	challengeRepo := repositoryImpl.NewChallengeRepository(db.Collection("challenges"))
+	feedItemRepo := repositoryImpl.NewFeedItemRepository(db.Collection("feed_items"))
+	cheerRepo := repositoryImpl.NewCheerRepository(db.Collection("cheers"))
+	cheerRateRepo := repositoryImpl.NewCheerRateLimitRepository(db.Collection("cheer_rate_limits"))
+	reminderRepo := repositoryImpl.NewReminderRepository(db.Collection("reminders"))
+	reminderDeliveryRepo := repositoryImpl.NewReminderDeliveryRepository(db.Collection("reminder_deliveries"))
+	pushSubscriptionRepo := repositoryImpl.NewPushSubscriptionRepository(db.Collection("push_subscriptions"))
+
+	// Web Push（VAPID）の鍵の読み込み（未設定の場合はブラウザ通知を使用しない）
+	vapid, err := config.LoadVAPID()
+	if err != nil {
+		log.Fatal("Could not load VAPID keys:", err)
+	}
+	notifiers := newNotifiers(vapid, pushSubscriptionRepo)
 
 	// 2. 各サービスを生成し、使用するリポジトリを注入
-	userService := serviceImpl.NewUserService(dbClient.Client(), userRepo)
-	habitService := serviceImpl.NewHabitService(dbClient.Client(), habitRepo, dailyTrackRepo)
-	dailyTrackService := serviceImpl.NewDailyTrackService(dbClient.Client(), userRepo, habitRepo, dailyTrackRepo)
+	userService := serviceImpl.NewUserService(dbClient.Client(), serviceImpl.UserServiceDeps{
+		UserRepo:             userRepo,
+		HabitRepo:            habitRepo,
+		DailyTrackRepo:       dailyTrackRepo,
+		PointLedgerRepo:      pointLedgerRepo,
+		StreakFreezeRepo:     streakFreezeRepo,
+		SessionRepo:          sessionRepo,
+		RewardRepo:           rewardRepo,
+		RedemptionRepo:       redemptionRepo,
+		AchievementRepo:      achievementRepo,
+		RankingRepo:          rankingRepo,
+		FriendshipRepo:       friendshipRepo,
+		HabitShareRepo:       habitShareRepo,
+		ChallengeRepo:        challengeRepo,
+		FeedItemRepo:         feedItemRepo,
+		CheerRepo:            cheerRepo,
+		ReminderRepo:         reminderRepo,
+		ReminderDeliveryRepo: reminderDeliveryRepo,
+		PushSubscriptionRepo: pushSubscriptionRepo,
+	})
+	habitService := serviceImpl.NewHabitService(dbClient.Client(), userRepo, habitRepo, dailyTrackRepo, streakFreezeRepo, feedItemRepo, cheerRepo, reminderRepo, habitShareRepo, challengeRepo)
+	dailyTrackService := serviceImpl.NewDailyTrackService(dbClient.Client(), userRepo, habitRepo, dailyTrackRepo, pointLedgerRepo, streakFreezeRepo, achievementRepo, rankingRepo, challengeRepo, feedItemRepo, cheerRepo, point.NewDefaultScoringPolicy(), notifiers[notification.ChannelWebPush])
+	pointService := serviceImpl.NewPointService(dbClient.Client(), pointLedgerRepo)
+	streakService := serviceImpl.NewStreakService(dbClient.Client(), userRepo, habitRepo, dailyTrackRepo, streakFreezeRepo, pointLedgerRepo)
+	statsService := serviceImpl.NewStatsService(dbClient.Client(), userRepo, statsRepo)
+	transferService := serviceImpl.NewTransferService(dbClient.Client(), userRepo, habitRepo, dailyTrackRepo, pointLedgerRepo, streakFreezeRepo)
+	rewardService := serviceImpl.NewRewardService(dbClient.Client(), userRepo, rewardRepo, redemptionRepo, pointLedgerRepo)
+	achievementService := serviceImpl.NewAchievementService(dbClient.Client(), achievementRepo)
+	leaderboardService := serviceImpl.NewLeaderboardService(dbClient.Client(), userRepo, friendshipRepo, rankingRepo)
+	friendService := serviceImpl.NewFriendService(dbClient.Client(), userRepo, habitRepo, dailyTrackRepo, friendshipRepo, habitShareRepo)
+	challengeService := serviceImpl.NewChallengeService(dbClient.Client(), userRepo, habitRepo, dailyTrackRepo, pointLedgerRepo, friendshipRepo, challengeRepo)
+	feedService := serviceImpl.NewFeedService(dbClient.Client(), userRepo, friendshipRepo, habitShareRepo, feedItemRepo, cheerRepo, cheerRateRepo)
+	reminderService := serviceImpl.NewReminderService(dbClient.Client(), userRepo, habitRepo, dailyTrackRepo, reminderRepo, reminderDeliveryRepo, notifiers)
+	pushService := serviceImpl.NewPushService(dbClient.Client(), pushSubscriptionRepo, vapid)
 
 	// 3. 各ハンドラーを生成し、対応するサービスを注入
 	userHandler := handler.NewUserHandler(userService)
-	habitHandler := handler.NewHabitHandler(habitService)
+	habitHandler := handler.NewHabitHandler(habitService, streakService)
 	dailyTrackHandler := handler.NewDailyTrackHandler(dailyTrackService)
+	pointHandler := handler.NewPointHandler(pointService)
+	streakHandler := handler.NewStreakHandler(streakService)
+	statsHandler := handler.NewStatsHandler(statsService)
+	transferHandler := handler.NewTransferHandler(transferService)
+	rewardHandler := handler.NewRewardHandler(rewardService)
+	achievementHandler := handler.NewAchievementHandler(achievementService)
+	leaderboardHandler := handler.NewLeaderboardHandler(leaderboardService)
+	friendHandler := handler.NewFriendHandler(friendService)
+	challengeHandler := handler.NewChallengeHandler(challengeService)
+	feedHandler := handler.NewFeedHandler(feedService)
+	reminderHandler := handler.NewReminderHandler(reminderService)
+	pushHandler := handler.NewPushHandler(pushService)
 
 	// 4. ルーター設定のコンフィグを作成
 	routerConfig := &router.RouterConfig{
-		UserHandler:       userHandler,
-		HabitHandler:      habitHandler,
-		DailyTrackHandler: dailyTrackHandler,
+		UserHandler:        userHandler,
+		HabitHandler:       habitHandler,
+		DailyTrackHandler:  dailyTrackHandler,
+		PointHandler:       pointHandler,
+		StreakHandler:      streakHandler,
+		StatsHandler:       statsHandler,
+		TransferHandler:    transferHandler,
+		RewardHandler:      rewardHandler,
+		AchievementHandler: achievementHandler,
+		LeaderboardHandler: leaderboardHandler,
+		FriendHandler:      friendHandler,
+		ChallengeHandler:   challengeHandler,
+		FeedHandler:        feedHandler,
+		ReminderHandler:    reminderHandler,
+		PushHandler:        pushHandler,
+
+		UserService: userService,
 	}
 
+	// リマインダーの送信（バックグラウンドで実行）
+	go runReminderScheduler(context.Background(), reminderService, config.ReminderCheckIntervalSecond*time.Second)
+
 	// Route
 	r := router.NewRouter(routerConfig)
 	r.Run(":8080")
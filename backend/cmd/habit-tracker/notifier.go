@@ -0,0 +1,58 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/domain/model/notification"
+	"backend/internal/domain/repository"
+	"backend/internal/domain/service"
+	"backend/internal/infrastructure/notifier"
+)
+
+// 通知の送信にかかる時間の上限
+const notifyTimeout = 10 * time.Second
+
+// 環境変数の設定から、送信方法ごとのNotifierを作成する
+// NOTE: メールはSMTP_HOSTが設定されている場合、ブラウザ通知はVAPIDの鍵が設定されている場合のみ使用できる
+func newNotifiers(vapid *config.VAPID, pushSubscriptionRepo repository.PushSubscriptionRepository) map[notification.Channel]service.Notifier {
+	notifiers := map[notification.Channel]service.Notifier{
+		notification.ChannelWebhook: notifier.NewWebhookNotifier(notifyTimeout),
+	}
+
+	ntfyBaseURL := os.Getenv("NTFY_BASE_URL")
+	if ntfyBaseURL == "" {
+		ntfyBaseURL = "https://ntfy.sh"
+	}
+	notifiers[notification.ChannelNtfy] = notifier.NewNtfyNotifier(notifier.NtfyConfig{
+		BaseURL: ntfyBaseURL,
+		Token:   os.Getenv("NTFY_TOKEN"),
+	}, notifyTimeout)
+
+	if smtpHost := os.Getenv("SMTP_HOST"); smtpHost != "" {
+		smtpPort, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+		if err != nil {
+			smtpPort = 587
+		}
+		notifiers[notification.ChannelEmail] = notifier.NewSMTPNotifier(notifier.SMTPConfig{
+			Host:     smtpHost,
+			Port:     smtpPort,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+		}, notifyTimeout)
+	} else {
+		log.Println("SMTP_HOST is not set, email notifications are disabled.")
+	}
+
+	if vapid != nil {
+		notifiers[notification.ChannelWebPush] = notifier.NewWebPushNotifier(vapid, pushSubscriptionRepo, notifyTimeout)
+	} else {
+		log.Println("VAPID_PRIVATE_KEY is not set, web push notifications are disabled.")
+	}
+
+	return notifiers
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"backend/internal/domain/service"
+)
+
+// リマインダーの送信を一定間隔で実行する（ctxがキャンセルされるまで）
+// NOTE: 複数台で実行しても、同じリマインダーは1台のみが送信する
+func runReminderScheduler(ctx context.Context, reminderService service.ReminderService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := reminderService.DispatchDueReminders(ctx, now); err != nil {
+				log.Printf("[ERROR] %v", err)
+			}
+		}
+	}
+}
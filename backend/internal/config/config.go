@@ -1,9 +1,82 @@
 package config
 
+import "time"
+
 const (
-	// ログイントークンの有効期限（時間）
-	JwtExpirationHour = 24
+	// アクセストークンの有効期限（分）
+	AccessTokenExpirationMinute = 15
+
+	// リフレッシュトークン（セッション）の有効期限（日）
+	RefreshTokenExpirationDay = 30
 
-	// 一つの習慣を完了した時に付与されるポイント
+	// 一つの習慣を完了した時に付与されるポイント（難易度: 普通）
 	PointsForHabitDone = 3
+
+	// 難易度が簡単・難しい習慣を完了した時に付与されるポイント
+	PointsForEasyHabitDone = 1
+	PointsForHardHabitDone = 5
+
+	// その日の全ての習慣を完了した時のボーナスポイント
+	PointsForPerfectDay = 5
+
+	// グループチャレンジの成功条件を満たした時のボーナスポイント
+	PointsForChallengeComplete = 20
+
+	// レベル1から2に必要な経験値（獲得ポイントの累計）と、レベルごとの増加率
+	LevelBaseExperience = 30
+	LevelGrowthRate     = 1.2
+
+	// ストリークフリーズ1つと交換するのに必要なポイント
+	PointsForStreakFreeze = 30
+
+	// daily_trackの期間指定で取得できる最大日数
+	MaxDailyTrackRangeDays = 366
+
+	// daily_trackを参照・作成できる過去の日数
+	DailyTrackPastDays = 365
+
+	// daily_trackを参照・作成できる未来の日数（完了の記録は今日まで）
+	DailyTrackFutureDays = 7
+
+	// 連続達成数の算出時に、daily_trackを一度に遡って取得する日数（連続が途切れるまで繰り返す）
+	StreakHistoryWindowDays = 90
+
+	// グループチャレンジの最長日数
+	MaxChallengeDays = 366
+
+	// 応援コメントの最大文字数
+	MaxCheerCommentLength = 140
+
+	// 応援（リアクション・コメント）の連投制限（期間内に送れる件数と期間（分））
+	CheerRateLimitCount  = 20
+	CheerRateLimitMinute = 1
+
+	// 1つの習慣に設定できるリマインダーの数
+	MaxRemindersPerHabit = 5
+
+	// リマインダーの送信を確認する間隔（秒）と、1回に処理する件数
+	ReminderCheckIntervalSecond = 30
+	ReminderBatchSize           = 100
+
+	// 送信予定から遅れた場合に送信を諦める時間（分）（サーバー停止中の分をまとめて送らない）
+	ReminderMaxDelayMinute = 60
+
+	// リマインダーの送信済みの記録を保持する日数
+	ReminderDeliveryRetentionDay = 7
+
+	// Web Pushの通知をプッシュサービスで保持する時間（秒）（端末がオフラインの場合）
+	WebPushTTLSecond = 24 * 60 * 60
+
+	// 他の習慣管理アプリから取り込むファイルの最大サイズ（バイト）
+	MaxImportFileBytes = 20 << 20
+
+	// 取り込むzip内のファイル1つあたりの展開後の最大サイズと、展開後の合計の最大サイズ（バイト）
+	MaxImportEntryBytes        = 20 << 20
+	MaxImportUncompressedBytes = 100 << 20
+
+	// 統計で月ごとの推移を表示する月数
+	StatsTrendMonths = 6
+
+	// 週の始まりの曜日（週あたりの回数目標の集計に使用）
+	WeekStartDay = time.Monday
 )
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// Web Pushの送信者の鍵（VAPID, RFC 8292）
+type VAPID struct {
+	PrivateKey *ecdsa.PrivateKey
+	PublicKey  string // 非圧縮形式の公開鍵（base64url）。ブラウザのapplicationServerKeyに使用
+	Subject    string // 連絡先（mailto:またはhttps:）
+}
+
+// 環境変数からVAPIDの鍵を読み込む
+// NOTE: main()で.envファイルを読み込んだ後に実行。VAPID_PRIVATE_KEYが未設定の場合はnil（Web Pushを使用しない）
+// 鍵はgo run ./cmd/generate-vapid-keysで作成する
+func LoadVAPID() (*VAPID, error) {
+	encoded := os.Getenv("VAPID_PRIVATE_KEY")
+	if encoded == "" {
+		return nil, nil
+	}
+
+	subject := os.Getenv("VAPID_SUBJECT")
+	if !strings.HasPrefix(subject, "mailto:") && !strings.HasPrefix(subject, "https:") {
+		return nil, errors.New("VAPID_SUBJECT must start with mailto: or https:")
+	}
+
+	scalar, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode VAPID_PRIVATE_KEY: %w", err)
+	}
+	privateKey, err := NewVAPIDPrivateKey(scalar)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VAPID{
+		PrivateKey: privateKey,
+		PublicKey:  base64.RawURLEncoding.EncodeToString(publicKeyBytes(privateKey)),
+		Subject:    subject,
+	}, nil
+}
+
+// P-256の秘密鍵（32バイトのスカラー）からECDSAの鍵を作成する
+func NewVAPIDPrivateKey(scalar []byte) (*ecdsa.PrivateKey, error) {
+	// NOTE: 鍵の検証と公開鍵の計算はcrypto/ecdhで行う
+	key, err := ecdh.P256().NewPrivateKey(scalar)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	public := key.PublicKey().Bytes() // 0x04 || X || Y
+
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(public[1:33]),
+			Y:     new(big.Int).SetBytes(public[33:]),
+		},
+		D: new(big.Int).SetBytes(scalar),
+	}, nil
+}
+
+// 非圧縮形式の公開鍵（65バイト）
+func publicKeyBytes(privateKey *ecdsa.PrivateKey) []byte {
+	public := make([]byte, 65)
+	public[0] = 0x04
+	privateKey.X.FillBytes(public[1:33])
+	privateKey.Y.FillBytes(public[33:])
+	return public
+}
@@ -0,0 +1,22 @@
+package common
+
+import "time"
+
+// 日付文字列のフォーマット（YYYY-MM-DD）
+const DateLayout = "2006-01-02"
+
+// YYYY-MM-DD形式の文字列をtime.Timeに変換する
+func ParseDate(date string) (time.Time, error) {
+	return time.Parse(DateLayout, date)
+}
+
+// time.TimeをYYYY-MM-DD形式の文字列に変換する
+func FormatDate(date time.Time) string {
+	return date.Format(DateLayout)
+}
+
+// 時刻を切り捨て、ParseDateの結果と比較できるようUTCの0時に揃える
+func TruncateToDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
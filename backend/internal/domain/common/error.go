@@ -7,3 +7,67 @@ var ErrNotFound = errors.New("resource not found")
 var ErrAlreadyExists = errors.New("resource already exists")
 
 var ErrPasswordMismatch = errors.New("password mismatch")
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+var ErrInvalidDate = errors.New("invalid date")
+
+var ErrInvalidMeasure = errors.New("invalid measure")
+
+var ErrNotMeasurable = errors.New("habit is not measurable")
+
+var ErrAmountRequired = errors.New("habit is completed by logging an amount")
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+var ErrInsufficientPoints = errors.New("insufficient points")
+
+var ErrNoFreezeTokens = errors.New("no streak freeze tokens")
+
+var ErrFreezeNotNeeded = errors.New("streak freeze is not needed")
+
+var ErrInvalidDateRange = errors.New("invalid date range")
+
+var ErrInvalidHabitStatus = errors.New("invalid habit status")
+
+var ErrInvalidDaySettings = errors.New("invalid timezone or day start hour")
+
+var ErrDateOutOfRange = errors.New("date out of range")
+
+var ErrForbidden = errors.New("resource belongs to another user")
+
+var ErrInvalidToken = errors.New("invalid token")
+
+var ErrSessionRevoked = errors.New("session revoked")
+
+var ErrRefreshTokenReused = errors.New("refresh token reused")
+
+var ErrInvalidImport = errors.New("invalid import document")
+
+var ErrInvalidReward = errors.New("invalid reward")
+
+var ErrRewardOutOfStock = errors.New("reward out of stock")
+
+var ErrRewardCoolingDown = errors.New("reward is cooling down")
+
+var ErrInvalidScoring = errors.New("invalid scoring")
+
+var ErrInvalidLeaderboard = errors.New("invalid leaderboard")
+
+var ErrInvalidFriendRequest = errors.New("invalid friend request")
+
+var ErrNotFriends = errors.New("users are not friends")
+
+var ErrInvalidChallenge = errors.New("invalid challenge")
+
+var ErrInvalidCheer = errors.New("invalid cheer")
+
+var ErrTooManyCheers = errors.New("too many cheers")
+
+var ErrInvalidReminder = errors.New("invalid reminder")
+
+var ErrInvalidNotification = errors.New("invalid notification settings")
+
+var ErrInvalidPushSubscription = errors.New("invalid push subscription")
+
+var ErrPushNotConfigured = errors.New("web push is not configured")
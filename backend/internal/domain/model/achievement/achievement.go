@@ -0,0 +1,65 @@
+package achievement
+
+import "time"
+
+// ユーザーが獲得したバッジ
+// NOTE: ルールの名前・説明が変わっても獲得時の内容が分かるよう、獲得時の名前と説明を保存する
+type Badge struct {
+	Id          string    `json:"id"`
+	UserId      string    `json:"user_id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	UnlockedAt  time.Time `json:"unlocked_at"`
+}
+
+// 実績の判定に使う情報
+// NOTE: ルールが必要とする情報だけを取得できるよう、メソッドで遅延して提供する
+type Facts interface {
+	// これまでに習慣を完了した回数（全習慣の合計）
+	TotalDone() (int, error)
+	// 現在のポイント
+	Points() (int, error)
+	// 完了した習慣の連続達成日数（週単位の習慣は0）
+	DailyStreak() (int, error)
+	// 完了した日まで、その日の全ての習慣を完了した日が何日続いているか
+	PerfectDays() (int, error)
+}
+
+// 実績のルール
+type Rule struct {
+	Code        string // バッジの識別子（変更しない）
+	Name        string
+	Description string
+	IsUnlocked  func(facts Facts) (bool, error)
+}
+
+// 獲得済みでないルールのうち、条件を満たしたものを返す
+func Evaluate(rules []*Rule, unlocked map[string]bool, facts Facts) ([]*Rule, error) {
+	var unlockedRules []*Rule
+	for _, rule := range rules {
+		if unlocked[rule.Code] {
+			continue
+		}
+
+		ok, err := rule.IsUnlocked(facts)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			unlockedRules = append(unlockedRules, rule)
+		}
+	}
+	return unlockedRules, nil
+}
+
+// ルールからバッジを作成する
+func (r *Rule) NewBadge(userId string, unlockedAt time.Time) *Badge {
+	return &Badge{
+		UserId:      userId,
+		Code:        r.Code,
+		Name:        r.Name,
+		Description: r.Description,
+		UnlockedAt:  unlockedAt,
+	}
+}
@@ -0,0 +1,56 @@
+package achievement
+
+import "fmt"
+
+// 実績のルール一覧
+// NOTE: 新しい実績はここにルールを追加するだけでよい（習慣の完了時に全ルールを判定する）
+var Rules = []*Rule{
+	{
+		Code:        "first_done",
+		Name:        "はじめの一歩",
+		Description: "はじめて習慣を完了した",
+		IsUnlocked: func(facts Facts) (bool, error) {
+			totalDone, err := facts.TotalDone()
+			return totalDone >= 1, err
+		},
+	},
+	streakRule(7, "1週間継続"),
+	streakRule(30, "1ヶ月継続"),
+	streakRule(100, "100日継続"),
+	pointsRule(1000),
+	{
+		Code:        "perfect_week",
+		Name:        "パーフェクトウィーク",
+		Description: "7日間連続で全ての習慣を完了した",
+		IsUnlocked: func(facts Facts) (bool, error) {
+			perfectDays, err := facts.PerfectDays()
+			return perfectDays >= 7, err
+		},
+	},
+}
+
+// 習慣を指定した日数連続で達成する実績
+func streakRule(days int, name string) *Rule {
+	return &Rule{
+		Code:        fmt.Sprintf("streak_%d", days),
+		Name:        name,
+		Description: fmt.Sprintf("習慣を%d日連続で達成した", days),
+		IsUnlocked: func(facts Facts) (bool, error) {
+			streakDays, err := facts.DailyStreak()
+			return streakDays >= days, err
+		},
+	}
+}
+
+// ポイントを指定した数まで貯める実績
+func pointsRule(points int) *Rule {
+	return &Rule{
+		Code:        fmt.Sprintf("points_%d", points),
+		Name:        fmt.Sprintf("%dポイント達成", points),
+		Description: fmt.Sprintf("ポイントを%d貯めた", points),
+		IsUnlocked: func(facts Facts) (bool, error) {
+			current, err := facts.Points()
+			return current >= points, err
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package challenge
+
+import "sort"
+
+// 参加者ごとの進捗
+type Progress struct {
+	UserId     string  `json:"user_id"`
+	Username   string  `json:"username"`
+	HabitId    string  `json:"habit_id"`
+	DoneDays   int     `json:"done_days"`   // 期間中に習慣を達成した日数
+	TargetDays int     `json:"target_days"` // 成功条件
+	Rate       float64 `json:"rate"`        // 成功条件に対する進捗（0〜1）
+	Completed  bool    `json:"completed"`
+}
+
+// チャレンジの進捗ボード
+type Board struct {
+	Challenge *Challenge  `json:"challenge"`
+	Progress  []*Progress `json:"progress"` // 達成日数の多い順
+}
+
+func NewProgress(challenge *Challenge, participant *Participant, doneDays int) *Progress {
+	return &Progress{
+		UserId:     participant.UserId,
+		HabitId:    participant.HabitId,
+		DoneDays:   doneDays,
+		TargetDays: challenge.TargetDays,
+		Rate:       min(float64(doneDays)/float64(challenge.TargetDays), 1),
+		Completed:  doneDays >= challenge.TargetDays,
+	}
+}
+
+// 達成日数の多い順に並べる
+func SortProgress(progress []*Progress) {
+	sort.SliceStable(progress, func(i, j int) bool {
+		return progress[i].DoneDays > progress[j].DoneDays
+	})
+}
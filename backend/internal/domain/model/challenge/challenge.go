@@ -0,0 +1,109 @@
+package challenge
+
+import (
+	"strings"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/domain/common"
+)
+
+type ParticipantStatus string
+
+const (
+	// 招待中（参加する習慣が未設定）
+	StatusInvited ParticipantStatus = "invited"
+	// 参加中
+	StatusJoined ParticipantStatus = "joined"
+)
+
+// グループチャレンジ（例: 30日間のうち20日瞑想する）
+type Challenge struct {
+	Id           string         `json:"id"`
+	OwnerId      string         `json:"owner_id"`
+	Name         string         `json:"name"`
+	StartDate    string         `json:"start_date"`   // YYYY-MM-DD
+	EndDate      string         `json:"end_date"`     // YYYY-MM-DD（この日を含む）
+	TargetDays   int            `json:"target_days"`  // 成功条件: 期間中に習慣を達成する日数
+	BonusPoints  int            `json:"bonus_points"` // 成功した参加者に付与するポイント（作成時に決定）
+	Participants []*Participant `json:"participants"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// チャレンジの参加者（参加者ごとに自分の習慣を紐づける）
+type Participant struct {
+	UserId      string            `json:"user_id"`
+	HabitId     string            `json:"habit_id,omitempty"`
+	Status      ParticipantStatus `json:"status"`
+	InvitedBy   string            `json:"invited_by,omitempty"`
+	JoinedAt    *time.Time        `json:"joined_at,omitempty"`
+	CompletedAt *time.Time        `json:"completed_at,omitempty"` // 成功条件を満たしボーナスを付与した日時
+}
+
+// チャレンジを作成する（作成したユーザーは指定した習慣で参加する）
+func New(ownerId string, name string, startDate string, endDate string, targetDays int, habitId string, now time.Time) (*Challenge, error) {
+	challenge := &Challenge{
+		OwnerId:     ownerId,
+		Name:        strings.TrimSpace(name),
+		StartDate:   startDate,
+		EndDate:     endDate,
+		TargetDays:  targetDays,
+		BonusPoints: config.PointsForChallengeComplete,
+		CreatedAt:   now,
+	}
+	if err := challenge.Validate(); err != nil {
+		return nil, err
+	}
+
+	challenge.Participants = []*Participant{{UserId: ownerId, HabitId: habitId, Status: StatusJoined, JoinedAt: &now}}
+
+	return challenge, nil
+}
+
+func (c *Challenge) Validate() error {
+	if c.Name == "" {
+		return common.ErrInvalidChallenge
+	}
+
+	start, err := common.ParseDate(c.StartDate)
+	if err != nil {
+		return common.ErrInvalidChallenge
+	}
+	end, err := common.ParseDate(c.EndDate)
+	if err != nil || end.Before(start) {
+		return common.ErrInvalidChallenge
+	}
+
+	days := c.Days()
+	if days > config.MaxChallengeDays || c.TargetDays < 1 || c.TargetDays > days {
+		return common.ErrInvalidChallenge
+	}
+
+	return nil
+}
+
+// 期間の日数（開始日・終了日を含む）
+func (c *Challenge) Days() int {
+	start, _ := common.ParseDate(c.StartDate)
+	end, _ := common.ParseDate(c.EndDate)
+	return int(end.Sub(start).Hours()/24) + 1
+}
+
+// 指定日が期間内かどうか
+func (c *Challenge) IsActiveOn(date string) bool {
+	return c.StartDate <= date && date <= c.EndDate
+}
+
+// 期間が終了したかどうか
+func (c *Challenge) IsEnded(today time.Time) bool {
+	return common.FormatDate(today) > c.EndDate
+}
+
+func (c *Challenge) FindParticipant(userId string) *Participant {
+	for _, participant := range c.Participants {
+		if participant.UserId == userId {
+			return participant
+		}
+	}
+	return nil
+}
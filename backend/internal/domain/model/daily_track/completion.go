@@ -0,0 +1,17 @@
+package daily_track
+
+import (
+	"backend/internal/domain/model/achievement"
+	"backend/internal/domain/model/challenge"
+	"backend/internal/domain/model/level"
+)
+
+// 習慣の完了状態を更新した結果
+type CompletionResult struct {
+	DailyTrack   *DailyTrack            `json:"daily_track"`
+	Points       int                    `json:"points"`       // 更新後のポイント
+	Achievements []*achievement.Badge   `json:"achievements"` // 今回獲得したバッジ
+	Challenges   []*challenge.Challenge `json:"challenges"`   // 今回成功したグループチャレンジ
+	Level        *level.Level           `json:"level"`        // 更新後のレベル
+	LevelUp      *level.LevelUp         `json:"level_up"`     // レベルが上がった場合のみ
+}
@@ -6,3 +6,32 @@ type DailyTrack struct {
 	Date          string         `json:"date"`
 	HabitStatuses []*HabitStatus `json:"habit_statuses"`
 }
+
+// 習慣ごとの達成回数を集計する
+func CountDoneByHabit(dailyTracks []*DailyTrack) map[string]int {
+	counts := make(map[string]int)
+	for _, dailyTrack := range dailyTracks {
+		for _, habitStatus := range dailyTrack.HabitStatuses {
+			if habitStatus.IsDone {
+				counts[habitStatus.HabitId]++
+			}
+		}
+	}
+	return counts
+}
+
+// その日の全ての習慣を完了したかどうか（習慣が無い日はfalse）
+// NOTE: 週あたりの回数目標の習慣は、その日に実施しなくてもよいため判定に含めない
+func (d *DailyTrack) IsAllDone() bool {
+	counted := 0
+	for _, habitStatus := range d.HabitStatuses {
+		if habitStatus.WeeklyTarget > 0 && !habitStatus.IsDone {
+			continue
+		}
+		if !habitStatus.IsDone {
+			return false
+		}
+		counted++
+	}
+	return counted > 0
+}
@@ -1,7 +1,18 @@
 package daily_track
 
+import "backend/internal/domain/model/habit"
+
 type HabitStatus struct {
-    HabitId   string `json:"habit_id"`
-    HabitName string `json:"habit_name"`
-    IsDone    bool   `json:"is_done"`
+    HabitId      string         `json:"habit_id"`
+    HabitName    string         `json:"habit_name"`
+    IsDone       bool           `json:"is_done"`
+    WeeklyTarget int            `json:"weekly_target,omitempty"` // 週あたりの目標回数（weekly_quotaの習慣のみ）
+    WeeklyDone   int            `json:"weekly_done,omitempty"`   // 今週の達成回数（weekly_quotaの習慣のみ）
+    Measure      *habit.Measure `json:"measure,omitempty"`       // 量の目標（量を記録する習慣のみ）
+    Value        float64        `json:"value,omitempty"`         // 記録量（量を記録する習慣のみ）
+}
+
+// 量を記録する習慣かどうか
+func (h *HabitStatus) IsMeasurable() bool {
+    return h.Measure != nil
 }
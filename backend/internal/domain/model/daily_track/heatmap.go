@@ -0,0 +1,89 @@
+package daily_track
+
+import (
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/habit"
+)
+
+type HeatmapStatus string
+
+const (
+	HeatmapDone         HeatmapStatus = "done"
+	HeatmapNotDone      HeatmapStatus = "not_done"
+	HeatmapNotScheduled HeatmapStatus = "not_scheduled"
+)
+
+// 習慣ごとの日別の達成状況（カレンダー・ヒートマップ表示用）
+type HabitHeatmap struct {
+	HabitId   string                   `json:"habit_id"`
+	HabitName string                   `json:"habit_name"`
+	Days      map[string]HeatmapStatus `json:"days"` // 日付（YYYY-MM-DD） -> 達成状況
+}
+
+// 期間内のdaily_trackから習慣ごとのヒートマップを作成する
+// ・daily_trackに習慣が含まれている日はその達成状況
+// ・daily_trackはあるが習慣が含まれていない日は予定外
+// ・daily_trackが無い日は、今日までの実施予定日なら未達成、それ以外は予定外
+// ・daily_trackが無い未来の日は含めない
+// NOTE: 削除済みなどhabitsに含まれない習慣も、daily_trackに含まれていれば対象にする
+func BuildHeatmaps(habits []*habit.Habit, dailyTracks []*DailyTrack, from time.Time, to time.Time, today time.Time) []*HabitHeatmap {
+	tracksByDate := make(map[string]*DailyTrack)
+	for _, dailyTrack := range dailyTracks {
+		tracksByDate[dailyTrack.Date] = dailyTrack
+	}
+
+	// 対象の習慣（習慣ID -> 習慣）。daily_trackにしか無い習慣はスケジュール無しとして扱う
+	targetHabits := make(map[string]*habit.Habit)
+	var heatmaps []*HabitHeatmap
+	addHeatmap := func(habitId string, habitName string, targetHabit *habit.Habit) {
+		if _, ok := targetHabits[habitId]; ok {
+			return
+		}
+		targetHabits[habitId] = targetHabit
+		heatmaps = append(heatmaps, &HabitHeatmap{HabitId: habitId, HabitName: habitName, Days: make(map[string]HeatmapStatus)})
+	}
+	for _, targetHabit := range habits {
+		addHeatmap(targetHabit.Id, targetHabit.Name, targetHabit)
+	}
+	for _, dailyTrack := range dailyTracks {
+		for _, habitStatus := range dailyTrack.HabitStatuses {
+			addHeatmap(habitStatus.HabitId, habitStatus.HabitName, nil)
+		}
+	}
+
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		dateString := common.FormatDate(date)
+		dailyTrack, hasTrack := tracksByDate[dateString]
+		if !hasTrack && date.After(today) {
+			continue
+		}
+
+		for _, heatmap := range heatmaps {
+			heatmap.Days[dateString] = heatmapStatusOn(targetHabits[heatmap.HabitId], heatmap.HabitId, dailyTrack, date)
+		}
+	}
+
+	return heatmaps
+}
+
+func heatmapStatusOn(targetHabit *habit.Habit, habitId string, dailyTrack *DailyTrack, date time.Time) HeatmapStatus {
+	if dailyTrack == nil {
+		if targetHabit != nil && targetHabit.IsScheduledOn(date) {
+			return HeatmapNotDone
+		}
+		return HeatmapNotScheduled
+	}
+
+	for _, habitStatus := range dailyTrack.HabitStatuses {
+		if habitStatus.HabitId != habitId {
+			continue
+		}
+		if habitStatus.IsDone {
+			return HeatmapDone
+		}
+		return HeatmapNotDone
+	}
+	return HeatmapNotScheduled
+}
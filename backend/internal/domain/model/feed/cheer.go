@@ -0,0 +1,92 @@
+package feed
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"backend/internal/config"
+	"backend/internal/domain/common"
+)
+
+type Kind string
+
+const (
+	// 絵文字のリアクション（1人につき絵文字ごとに1件）
+	KindReaction Kind = "reaction"
+	// 短いコメント
+	KindComment Kind = "comment"
+)
+
+// リアクションに使える絵文字（表示する絵文字はフロントで対応付ける）
+type Emoji string
+
+const (
+	EmojiThumbsUp Emoji = "thumbs_up"
+	EmojiClap     Emoji = "clap"
+	EmojiFire     Emoji = "fire"
+	EmojiMuscle   Emoji = "muscle"
+	EmojiParty    Emoji = "party"
+)
+
+// 表示順
+var Emojis = []Emoji{EmojiThumbsUp, EmojiClap, EmojiFire, EmojiMuscle, EmojiParty}
+
+func ParseEmoji(value string) (Emoji, error) {
+	for _, emoji := range Emojis {
+		if Emoji(value) == emoji {
+			return emoji, nil
+		}
+	}
+	return "", common.ErrInvalidCheer
+}
+
+// フィードの1件への応援
+// NOTE: 削除をまとめて行えるよう、フィードの持ち主・習慣・日付も保存する
+type Cheer struct {
+	Id          string
+	ItemId      string
+	ItemOwnerId string
+	HabitId     string
+	Date        string
+	UserId      string // 応援したユーザー
+	Kind        Kind
+	Emoji       Emoji  // リアクションのみ
+	Body        string // コメントのみ
+	CreatedAt   time.Time
+}
+
+func NewReaction(item *Item, userId string, emoji Emoji) *Cheer {
+	cheer := newCheer(item, userId, KindReaction)
+	cheer.Emoji = emoji
+	return cheer
+}
+
+// コメントを作成する（前後の空白は除く）
+// 空、またはconfig.MaxCheerCommentLength文字を超える場合はErrInvalidCheer
+func NewComment(item *Item, userId string, body string) (*Cheer, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > config.MaxCheerCommentLength {
+		return nil, common.ErrInvalidCheer
+	}
+
+	cheer := newCheer(item, userId, KindComment)
+	cheer.Body = body
+	return cheer, nil
+}
+
+// コメントを削除できるか（コメントしたユーザーと、フィードの持ち主のみ）
+func (c *Cheer) CanDelete(userId string) bool {
+	return c.Kind == KindComment && (c.UserId == userId || c.ItemOwnerId == userId)
+}
+
+func newCheer(item *Item, userId string, kind Kind) *Cheer {
+	return &Cheer{
+		ItemId:      item.Id,
+		ItemOwnerId: item.UserId,
+		HabitId:     item.HabitId,
+		Date:        item.Date,
+		UserId:      userId,
+		Kind:        kind,
+	}
+}
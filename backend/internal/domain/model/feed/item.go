@@ -0,0 +1,80 @@
+package feed
+
+import "time"
+
+// フィードの1件（習慣の完了）
+// NOTE: 完了の取り消し・習慣の完全削除で応援とあわせて削除する
+type Item struct {
+	Id        string           `json:"id"`
+	UserId    string           `json:"user_id"`
+	Username  string           `json:"username"`
+	HabitId   string           `json:"habit_id"`
+	HabitName string           `json:"habit_name"` // 完了した時点の習慣名
+	Date      string           `json:"date"`
+	Reactions []*ReactionCount `json:"reactions"`
+	Comments  []*Comment       `json:"comments"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// 絵文字ごとのリアクション数
+type ReactionCount struct {
+	Emoji   Emoji `json:"emoji"`
+	Count   int   `json:"count"`
+	Reacted bool  `json:"reacted"` // 自分がリアクションしたか
+}
+
+// フィードに表示するコメント
+type Comment struct {
+	Id        string    `json:"id"`
+	UserId    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// フィードのページ
+type Feed struct {
+	Items []*Item `json:"items"`
+	Page  int     `json:"page"`
+	Limit int     `json:"limit"`
+	Total int64   `json:"total"`
+}
+
+// 応援（リアクション・コメント）を集計して設定する
+// リアクションはEmojisの順、コメントは古い順（cheersの順）
+func (i *Item) AttachCheers(cheers []*Cheer, viewerId string, usernames map[string]string) {
+	i.Username = usernames[i.UserId]
+
+	countsByEmoji := make(map[Emoji]*ReactionCount)
+	i.Comments = make([]*Comment, 0)
+	for _, cheer := range cheers {
+		if cheer.ItemId != i.Id {
+			continue
+		}
+		switch cheer.Kind {
+		case KindReaction:
+			count, ok := countsByEmoji[cheer.Emoji]
+			if !ok {
+				count = &ReactionCount{Emoji: cheer.Emoji}
+				countsByEmoji[cheer.Emoji] = count
+			}
+			count.Count++
+			count.Reacted = count.Reacted || cheer.UserId == viewerId
+		case KindComment:
+			i.Comments = append(i.Comments, &Comment{
+				Id:        cheer.Id,
+				UserId:    cheer.UserId,
+				Username:  usernames[cheer.UserId],
+				Body:      cheer.Body,
+				CreatedAt: cheer.CreatedAt,
+			})
+		}
+	}
+
+	i.Reactions = make([]*ReactionCount, 0, len(countsByEmoji))
+	for _, emoji := range Emojis {
+		if count, ok := countsByEmoji[emoji]; ok {
+			i.Reactions = append(i.Reactions, count)
+		}
+	}
+}
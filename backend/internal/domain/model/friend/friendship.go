@@ -0,0 +1,68 @@
+package friend
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+type Status string
+
+const (
+	// 申請中（承認待ち）
+	StatusPending Status = "pending"
+	// フレンド
+	StatusAccepted Status = "accepted"
+	// ブロック（ブロックしたユーザーのみ解除できる）
+	StatusBlocked Status = "blocked"
+)
+
+// 2人のユーザーの関係（1組につき1件）
+type Friendship struct {
+	Id          string    `json:"id"`
+	RequesterId string    `json:"requester_id"` // 申請（ブロック）したユーザー
+	AddresseeId string    `json:"addressee_id"`
+	Status      Status    `json:"status"`
+	BlockedBy   string    `json:"blocked_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// 2人の組を表すキー（申請の向きによらず同じ値になる）
+func PairKey(userId string, otherUserId string) string {
+	ids := []string{userId, otherUserId}
+	sort.Strings(ids)
+	return strings.Join(ids, ":")
+}
+
+// 相手のユーザーID
+func (f *Friendship) OtherUserId(userId string) string {
+	if f.RequesterId == userId {
+		return f.AddresseeId
+	}
+	return f.RequesterId
+}
+
+func (f *Friendship) IsAccepted() bool {
+	return f.Status == StatusAccepted
+}
+
+// 指定したユーザーが承認できる申請かどうか（申請されたユーザーのみ承認できる）
+func (f *Friendship) CanAccept(userId string) bool {
+	return f.Status == StatusPending && f.AddresseeId == userId
+}
+
+// 指定したユーザーから見た関係が存在しないものとして扱うべきかどうか（相手にブロックされている）
+func (f *Friendship) IsBlockedBy(userId string) bool {
+	return f.Status == StatusBlocked && f.BlockedBy == userId
+}
+
+// フレンド一覧の1行（自分から見た関係）
+type Friend struct {
+	UserId         string    `json:"user_id"`
+	Username       string    `json:"username"`
+	Status         Status    `json:"status"`
+	Incoming       bool      `json:"incoming"`         // 相手からの申請
+	SharedHabitIds []string  `json:"shared_habit_ids"` // 自分が相手に公開している習慣
+	Since          time.Time `json:"since"`
+}
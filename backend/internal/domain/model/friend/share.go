@@ -0,0 +1,37 @@
+package friend
+
+import (
+	"time"
+
+	"backend/internal/domain/model/daily_track"
+)
+
+// 習慣の公開設定（ユーザーがフレンドに公開する習慣）
+type Share struct {
+	Id        string    `json:"id"`
+	UserId    string    `json:"user_id"`   // 公開するユーザー
+	FriendId  string    `json:"friend_id"` // 公開先のフレンド
+	HabitIds  []string  `json:"habit_ids"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (s *Share) IsShared(habitId string) bool {
+	for _, sharedHabitId := range s.HabitIds {
+		if sharedHabitId == habitId {
+			return true
+		}
+	}
+	return false
+}
+
+// 公開している習慣のみにしたdaily_trackを返す（元のdaily_trackは変更しない）
+func (s *Share) FilterDailyTrack(dailyTrack *daily_track.DailyTrack) *daily_track.DailyTrack {
+	filtered := *dailyTrack
+	filtered.HabitStatuses = make([]*daily_track.HabitStatus, 0, len(dailyTrack.HabitStatuses))
+	for _, habitStatus := range dailyTrack.HabitStatuses {
+		if s.IsShared(habitStatus.HabitId) {
+			filtered.HabitStatuses = append(filtered.HabitStatuses, habitStatus)
+		}
+	}
+	return &filtered
+}
@@ -1,7 +1,30 @@
 package habit
 
+import "time"
+
 type Habit struct {
-	Id     string `json:"id"`
-	UserId string `json:"user_id"`
-	Name   string `json:"name"`
+	Id       string   `json:"id"`
+	UserId   string   `json:"user_id"`
+	Name     string   `json:"name"`
+	Schedule Schedule `json:"schedule"`
+	Measure  *Measure `json:"measure,omitempty"` // nilの場合はチェックのみの習慣
+	Scoring  Scoring  `json:"scoring"`           // 完了時に付与するポイントの設定
+
+	SortOrder int `json:"sort_order"` // 表示順（昇順）
+
+	Status      Status     `json:"status"`
+	PausedUntil string     `json:"paused_until,omitempty"` // 一時停止中の再開日（YYYY-MM-DD）
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+
+	LongestStreak *int `json:"-"` // 最長の連続達成数の記録（未算出の場合はnil）
+}
+
+// 指定日が実施予定日かどうか（一時停止中・アーカイブ済みの場合は予定外）
+func (h *Habit) IsScheduledOn(date time.Time) bool {
+	return h.IsActiveOn(date) && h.Schedule.IsScheduledOn(date)
+}
+
+// 量を記録する習慣かどうか
+func (h *Habit) IsMeasurable() bool {
+	return h.Measure != nil
 }
@@ -0,0 +1,85 @@
+package habit
+
+import (
+	"time"
+
+	"backend/internal/domain/common"
+)
+
+type Status string
+
+const (
+	// 有効
+	StatusActive Status = "active"
+	// 一時停止中（再開日まで）
+	StatusPaused Status = "paused"
+	// アーカイブ済み（履歴は残るが、新しいdaily_trackには含まれない）
+	StatusArchived Status = "archived"
+)
+
+// 指定日に有効な習慣かどうか（一時停止中・アーカイブ済みは無効）
+func (h *Habit) IsActiveOn(date time.Time) bool {
+	switch h.Status {
+	case StatusArchived:
+		return false
+	case StatusPaused:
+		resumeDate, err := common.ParseDate(h.PausedUntil)
+		return err == nil && !date.Before(resumeDate)
+	default:
+		return true
+	}
+}
+
+// 再開日（YYYY-MM-DD）まで一時停止する
+func (h *Habit) Pause(pausedUntil string, today time.Time) error {
+	resumeDate, err := common.ParseDate(pausedUntil)
+	if err != nil {
+		return common.ErrInvalidDate
+	}
+	if h.Status == StatusArchived || !resumeDate.After(today) {
+		return common.ErrInvalidHabitStatus
+	}
+
+	h.Status = StatusPaused
+	h.PausedUntil = pausedUntil
+	return nil
+}
+
+// 一時停止を解除する
+func (h *Habit) Resume() error {
+	if h.Status != StatusPaused {
+		return common.ErrInvalidHabitStatus
+	}
+
+	h.Status = StatusActive
+	h.PausedUntil = ""
+	return nil
+}
+
+// アーカイブする
+func (h *Habit) Archive(now time.Time) error {
+	if h.Status == StatusArchived {
+		return common.ErrInvalidHabitStatus
+	}
+
+	h.Status = StatusArchived
+	h.PausedUntil = ""
+	h.ArchivedAt = &now
+	return nil
+}
+
+// アーカイブから復元する
+func (h *Habit) Restore() error {
+	if h.Status != StatusArchived {
+		return common.ErrInvalidHabitStatus
+	}
+
+	h.Status = StatusActive
+	h.ArchivedAt = nil
+	return nil
+}
+
+// アーカイブ済みかどうか
+func (h *Habit) IsArchived() bool {
+	return h.Status == StatusArchived
+}
@@ -0,0 +1,38 @@
+package habit
+
+import "backend/internal/domain/common"
+
+type AggregationType string
+
+const (
+	// 記録した量を合計する（例: 水を飲んだ杯数）
+	AggregationSum AggregationType = "sum"
+	// 記録した量の最大値を採用する（例: 一度に走った距離）
+	AggregationMax AggregationType = "max"
+)
+
+// 量を記録する習慣の目標
+type Measure struct {
+	Unit        string          `json:"unit"`        // 単位（杯、分、kmなど）
+	Target      float64         `json:"target"`      // 1日の目標量
+	Aggregation AggregationType `json:"aggregation"` // 記録の集計方法
+}
+
+// 目標の内容を検証する
+func (m *Measure) Validate() error {
+	if m.Unit == "" || m.Target <= 0 {
+		return common.ErrInvalidMeasure
+	}
+
+	switch m.Aggregation {
+	case AggregationSum, AggregationMax:
+		return nil
+	default:
+		return common.ErrInvalidMeasure
+	}
+}
+
+// 記録量が目標に達しているかどうか
+func (m *Measure) IsReached(value float64) bool {
+	return value >= m.Target
+}
@@ -0,0 +1,111 @@
+package habit
+
+import (
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/domain/common"
+)
+
+type ScheduleType string
+
+const (
+	// 毎日
+	ScheduleTypeDaily ScheduleType = "daily"
+	// 指定した曜日のみ
+	ScheduleTypeWeekdays ScheduleType = "weekdays"
+	// 開始日からN日ごと
+	ScheduleTypeInterval ScheduleType = "interval"
+	// 週にN回
+	ScheduleTypeWeeklyQuota ScheduleType = "weekly_quota"
+)
+
+type Schedule struct {
+	Type         ScheduleType   `json:"type"`
+	Weekdays     []time.Weekday `json:"weekdays,omitempty"`       // weekdays: 0(日)〜6(土)
+	IntervalDays int            `json:"interval_days,omitempty"`  // interval: 何日ごとか
+	TimesPerWeek int            `json:"times_per_week,omitempty"` // weekly_quota: 週あたりの目標回数
+	StartDate    string         `json:"start_date,omitempty"`     // YYYY-MM-DD（intervalでは必須）
+}
+
+// スケジュール未指定の習慣は毎日実施する
+func DefaultSchedule() Schedule {
+	return Schedule{Type: ScheduleTypeDaily}
+}
+
+// スケジュールの内容を検証する
+func (s *Schedule) Validate() error {
+	if s.StartDate != "" {
+		if _, err := common.ParseDate(s.StartDate); err != nil {
+			return common.ErrInvalidSchedule
+		}
+	}
+
+	switch s.Type {
+	case ScheduleTypeDaily:
+		return nil
+	case ScheduleTypeWeekdays:
+		if len(s.Weekdays) == 0 {
+			return common.ErrInvalidSchedule
+		}
+		for _, weekday := range s.Weekdays {
+			if weekday < time.Sunday || weekday > time.Saturday {
+				return common.ErrInvalidSchedule
+			}
+		}
+		return nil
+	case ScheduleTypeInterval:
+		if s.IntervalDays < 1 || s.StartDate == "" {
+			return common.ErrInvalidSchedule
+		}
+		return nil
+	case ScheduleTypeWeeklyQuota:
+		if s.TimesPerWeek < 1 || s.TimesPerWeek > 7 {
+			return common.ErrInvalidSchedule
+		}
+		return nil
+	default:
+		return common.ErrInvalidSchedule
+	}
+}
+
+// 指定日が実施予定日かどうかを判定する
+// NOTE: weekly_quotaは週内のどの日でも実施できるため常にtrue。目標達成済みかどうかは履歴を見て呼び出し側で判定する
+func (s *Schedule) IsScheduledOn(date time.Time) bool {
+	if s.StartDate != "" {
+		startDate, err := common.ParseDate(s.StartDate)
+		if err != nil || date.Before(startDate) {
+			return false
+		}
+	}
+
+	switch s.Type {
+	case ScheduleTypeWeekdays:
+		for _, weekday := range s.Weekdays {
+			if date.Weekday() == weekday {
+				return true
+			}
+		}
+		return false
+	case ScheduleTypeInterval:
+		startDate, err := common.ParseDate(s.StartDate)
+		if err != nil || s.IntervalDays < 1 {
+			return false
+		}
+		days := int(date.Sub(startDate).Hours() / 24)
+		return days%s.IntervalDays == 0
+	default:
+		return true
+	}
+}
+
+// 週あたりの回数目標を持つスケジュールかどうか
+func (s *Schedule) IsWeeklyQuota() bool {
+	return s.Type == ScheduleTypeWeeklyQuota
+}
+
+// 指定日が属する週の開始日を返す
+func WeekStart(date time.Time) time.Time {
+	offset := (int(date.Weekday()) - int(config.WeekStartDay) + 7) % 7
+	return date.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,45 @@
+package habit
+
+import "backend/internal/domain/common"
+
+type Difficulty string
+
+const (
+	// 簡単
+	DifficultyEasy Difficulty = "easy"
+	// 普通
+	DifficultyMedium Difficulty = "medium"
+	// 難しい
+	DifficultyHard Difficulty = "hard"
+	// ポイントを直接指定する
+	DifficultyCustom Difficulty = "custom"
+)
+
+// customで指定できるポイントの上限
+const MaxCustomPoints = 100
+
+// 完了時に付与するポイントの設定
+type Scoring struct {
+	Difficulty Difficulty `json:"difficulty"`
+	Points     int        `json:"points,omitempty"` // customの場合のポイント
+}
+
+// 難易度未指定の習慣は普通
+func DefaultScoring() Scoring {
+	return Scoring{Difficulty: DifficultyMedium}
+}
+
+// ポイントの設定を検証する
+func (s *Scoring) Validate() error {
+	switch s.Difficulty {
+	case DifficultyEasy, DifficultyMedium, DifficultyHard:
+		return nil
+	case DifficultyCustom:
+		if s.Points < 1 || s.Points > MaxCustomPoints {
+			return common.ErrInvalidScoring
+		}
+		return nil
+	default:
+		return common.ErrInvalidScoring
+	}
+}
@@ -0,0 +1,68 @@
+package level
+
+import (
+	"math"
+
+	"backend/internal/config"
+)
+
+// ユーザーのレベル（これまでに獲得したポイントを経験値とする）
+type Level struct {
+	Level                  int     `json:"level"`
+	Experience             int     `json:"experience"`               // 経験値（交換で使ったポイントは減らない）
+	CurrentLevelExperience int     `json:"current_level_experience"` // 現在のレベルに到達した経験値
+	NextLevelExperience    int     `json:"next_level_experience"`    // 次のレベルに必要な経験値
+	Progress               float64 `json:"progress"`                 // 次のレベルまでの進捗（0〜1）
+}
+
+// レベルアップ
+type LevelUp struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// 経験値の曲線（レベルnからn+1に必要な経験値 = BaseExperience × GrowthRate^(n-1)）
+type Curve struct {
+	BaseExperience int
+	GrowthRate     float64
+}
+
+func DefaultCurve() Curve {
+	return Curve{
+		BaseExperience: config.LevelBaseExperience,
+		GrowthRate:     config.LevelGrowthRate,
+	}
+}
+
+// レベルnから次のレベルに必要な経験値
+func (c Curve) required(level int) int {
+	return max(int(math.Round(float64(c.BaseExperience)*math.Pow(c.GrowthRate, float64(level-1)))), 1)
+}
+
+// 経験値からレベルを算出する（レベルは1から）
+func (c Curve) Calculate(experience int) *Level {
+	result := &Level{Level: 1, Experience: experience}
+
+	for {
+		next := result.CurrentLevelExperience + c.required(result.Level)
+		if experience < next {
+			result.NextLevelExperience = next
+			break
+		}
+		result.Level++
+		result.CurrentLevelExperience = next
+	}
+
+	span := result.NextLevelExperience - result.CurrentLevelExperience
+	result.Progress = float64(max(experience-result.CurrentLevelExperience, 0)) / float64(span)
+
+	return result
+}
+
+// レベルが上がった場合のみレベルアップを返す
+func NewLevelUp(before *Level, after *Level) *LevelUp {
+	if after.Level <= before.Level {
+		return nil
+	}
+	return &LevelUp{From: before.Level, To: after.Level}
+}
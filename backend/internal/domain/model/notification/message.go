@@ -0,0 +1,29 @@
+package notification
+
+import "fmt"
+
+// 通知の内容
+type Message struct {
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	HabitId string `json:"habit_id,omitempty"`
+	Date    string `json:"date,omitempty"`
+}
+
+// 未完了の習慣のリマインド
+func NewReminderMessage(habitId string, habitName string, date string) *Message {
+	return &Message{
+		Title:   "習慣のリマインド",
+		Body:    fmt.Sprintf("「%s」がまだ完了していません。", habitName),
+		HabitId: habitId,
+		Date:    date,
+	}
+}
+
+// 実績のバッジを獲得した時の通知
+func NewAchievementMessage(badgeName string, description string) *Message {
+	return &Message{
+		Title: fmt.Sprintf("バッジ「%s」を獲得しました！", badgeName),
+		Body:  description,
+	}
+}
@@ -0,0 +1,67 @@
+package notification
+
+import (
+	"net/mail"
+	"net/url"
+	"regexp"
+
+	"backend/internal/domain/common"
+)
+
+// 通知の送信方法
+type Channel string
+
+const (
+	// ユーザーが指定したURLにJSONをPOSTする
+	ChannelWebhook Channel = "webhook"
+	// メール（SMTP）
+	ChannelEmail Channel = "email"
+	// ntfy形式のHTTPプッシュ通知（宛先はトピック名）
+	ChannelNtfy Channel = "ntfy"
+	// ブラウザのWeb Push（宛先は登録済みの購読。addressは使用しない）
+	ChannelWebPush Channel = "webpush"
+)
+
+// ntfyのトピック名に使える文字
+var ntfyTopicPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// ユーザーの通知設定
+type Settings struct {
+	Channel Channel `json:"channel"`
+	Address string  `json:"address"` // webhook: URL、email: メールアドレス、ntfy: トピック名、webpush: 空
+}
+
+// 送信方法ごとに宛先を検証する
+func (s *Settings) Validate() error {
+	switch s.Channel {
+	case ChannelWebhook:
+		parsed, err := url.Parse(s.Address)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return common.ErrInvalidNotification
+		}
+		return nil
+	case ChannelEmail:
+		address, err := mail.ParseAddress(s.Address)
+		if err != nil || address.Address != s.Address {
+			return common.ErrInvalidNotification
+		}
+		return nil
+	case ChannelNtfy:
+		if !ntfyTopicPattern.MatchString(s.Address) {
+			return common.ErrInvalidNotification
+		}
+		return nil
+	case ChannelWebPush:
+		if s.Address != "" {
+			return common.ErrInvalidNotification
+		}
+		return nil
+	}
+	return common.ErrInvalidNotification
+}
+
+// 通知の宛先
+type Recipient struct {
+	UserId   string
+	Settings Settings
+}
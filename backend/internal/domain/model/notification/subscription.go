@@ -0,0 +1,45 @@
+package notification
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"time"
+
+	"backend/internal/domain/common"
+)
+
+// ブラウザのWeb Pushの購読（PushSubscription）
+// NOTE: 同じブラウザ（エンドポイント）は1件のみ。プッシュサービスが410を返した場合は削除する
+type PushSubscription struct {
+	Id        string    `json:"id"`
+	UserId    string    `json:"user_id"`
+	Endpoint  string    `json:"endpoint"`
+	P256dh    string    `json:"p256dh"` // ブラウザの公開鍵（base64url）
+	Auth      string    `json:"auth"`   // 認証用のシークレット（base64url）
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// エンドポイントと鍵の形式を検証する
+// p256dhは非圧縮形式のP-256公開鍵（65バイト）、authは16バイト
+func (s *PushSubscription) Validate() error {
+	endpoint, err := url.Parse(s.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return common.ErrInvalidPushSubscription
+	}
+
+	p256dh, err := DecodeBase64URL(s.P256dh)
+	if err != nil || len(p256dh) != 65 || p256dh[0] != 0x04 {
+		return common.ErrInvalidPushSubscription
+	}
+	auth, err := DecodeBase64URL(s.Auth)
+	if err != nil || len(auth) != 16 {
+		return common.ErrInvalidPushSubscription
+	}
+	return nil
+}
+
+// base64url（パディングの有無どちらも可）をデコードする
+func DecodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
@@ -0,0 +1,10 @@
+package point
+
+// ポイント履歴のページ
+type History struct {
+	Entries []*LedgerEntry `json:"entries"`
+	Page    int            `json:"page"`
+	Limit   int            `json:"limit"`
+	Total   int64          `json:"total"`
+	Balance int            `json:"balance"` // 台帳から算出した残高
+}
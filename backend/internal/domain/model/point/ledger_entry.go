@@ -0,0 +1,38 @@
+package point
+
+import "time"
+
+type Reason string
+
+const (
+	// 習慣の完了
+	ReasonHabitDone Reason = "habit_done"
+	// 習慣の完了取り消し
+	ReasonHabitUndone Reason = "habit_undone"
+	// ボーナス
+	ReasonBonus Reason = "bonus"
+	// ストリークフリーズとの交換
+	ReasonStreakFreeze Reason = "streak_freeze"
+	// ごほうびとの交換
+	ReasonReward Reason = "reward"
+	// 台帳導入前に貯まっていたポイントの繰越
+	ReasonOpeningBalance Reason = "opening_balance"
+	// グループチャレンジの成功ボーナス（成功条件を満たさなくなった場合は負の値で取り消す）
+	ReasonChallenge Reason = "challenge"
+)
+
+// ポイントを使用した理由（経験値には含めない）
+var SpendingReasons = []Reason{ReasonStreakFreeze, ReasonReward}
+
+// ポイントの増減を記録する台帳のエントリ（作成後は変更しない）
+type LedgerEntry struct {
+	Id          string    `json:"id"`
+	UserId      string    `json:"user_id"`
+	Amount      int       `json:"amount"` // 減算の場合は負の値
+	Reason      Reason    `json:"reason"`
+	HabitId     string    `json:"habit_id,omitempty"`
+	Date        string    `json:"date,omitempty"`         // 対象のdaily_trackの日付（YYYY-MM-DD）
+	Rule        string    `json:"rule,omitempty"`         // ポイントを付与したルール（ScoringPolicyのルール名）
+	ChallengeId string    `json:"challenge_id,omitempty"` // 成功ボーナスの対象のチャレンジ
+	CreatedAt   time.Time `json:"created_at"`
+}
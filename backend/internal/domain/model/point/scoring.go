@@ -0,0 +1,97 @@
+package point
+
+import (
+	"fmt"
+	"math"
+
+	"backend/internal/config"
+	"backend/internal/domain/model/habit"
+)
+
+// 全習慣完了のボーナスのルール名
+const RulePerfectDay = "perfect_day"
+
+// 付与するポイントとそのルール
+type Award struct {
+	Rule   string
+	Amount int
+}
+
+// 習慣の完了時に付与するポイントの計算方法
+// NOTE: 計算方法を変える場合はこのインターフェースを実装し、dailyTrackServiceに渡す
+type ScoringPolicy interface {
+	// 習慣を完了した時に付与するポイント（ルールごと）
+	// streakDaysは今回の完了を含めた連続達成日数（週単位の習慣は0）
+	ScoreCompletion(targetHabit *habit.Habit, streakDays int) []*Award
+	// その日の全ての習慣を完了した時に付与するポイント（ルールごと）
+	ScorePerfectDay() []*Award
+}
+
+// 連続達成日数に応じたポイントの倍率
+type StreakMultiplier struct {
+	Days       int     // この日数以上連続した場合に適用
+	Multiplier float64 // 基本ポイントに掛ける倍率
+}
+
+// 標準のポイントの計算方法
+// ・難易度ごとのポイント（customの場合は習慣に設定したポイント）
+// ・連続達成日数に応じた倍率（増えた分を別のルールとして付与）
+// ・全習慣完了のボーナス
+type DefaultScoringPolicy struct {
+	DifficultyPoints  map[habit.Difficulty]int
+	StreakMultipliers []StreakMultiplier // 日数の昇順
+	PerfectDayBonus   int
+}
+
+func NewDefaultScoringPolicy() *DefaultScoringPolicy {
+	return &DefaultScoringPolicy{
+		DifficultyPoints: map[habit.Difficulty]int{
+			habit.DifficultyEasy:   config.PointsForEasyHabitDone,
+			habit.DifficultyMedium: config.PointsForHabitDone,
+			habit.DifficultyHard:   config.PointsForHardHabitDone,
+		},
+		StreakMultipliers: []StreakMultiplier{
+			{Days: 7, Multiplier: 1.5},
+			{Days: 30, Multiplier: 2},
+		},
+		PerfectDayBonus: config.PointsForPerfectDay,
+	}
+}
+
+func (p *DefaultScoringPolicy) ScoreCompletion(targetHabit *habit.Habit, streakDays int) []*Award {
+	// 基本ポイント
+	base := &Award{Rule: "difficulty_" + string(targetHabit.Scoring.Difficulty)}
+	if targetHabit.Scoring.Difficulty == habit.DifficultyCustom {
+		base.Amount = targetHabit.Scoring.Points
+	} else {
+		points, ok := p.DifficultyPoints[targetHabit.Scoring.Difficulty]
+		if !ok {
+			base.Rule, points = "difficulty_"+string(habit.DifficultyMedium), p.DifficultyPoints[habit.DifficultyMedium]
+		}
+		base.Amount = points
+	}
+	awards := []*Award{base}
+
+	// 連続達成の倍率（条件を満たす最大のもの）
+	var applied *StreakMultiplier
+	for i := range p.StreakMultipliers {
+		if streakDays >= p.StreakMultipliers[i].Days {
+			applied = &p.StreakMultipliers[i]
+		}
+	}
+	if applied != nil {
+		extra := int(math.Round(float64(base.Amount)*applied.Multiplier)) - base.Amount
+		if extra > 0 {
+			awards = append(awards, &Award{Rule: fmt.Sprintf("streak_%d_days", applied.Days), Amount: extra})
+		}
+	}
+
+	return awards
+}
+
+func (p *DefaultScoringPolicy) ScorePerfectDay() []*Award {
+	if p.PerfectDayBonus <= 0 {
+		return nil
+	}
+	return []*Award{{Rule: RulePerfectDay, Amount: p.PerfectDayBonus}}
+}
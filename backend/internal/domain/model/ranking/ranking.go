@@ -0,0 +1,95 @@
+package ranking
+
+import (
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/habit"
+)
+
+// ランキングの種類
+type Board string
+
+const (
+	// 累計ポイント（現在の所持ポイント）
+	BoardPoints Board = "points"
+	// 今週の完了で獲得したポイント
+	BoardWeekly Board = "weekly"
+	// 今月の完了で獲得したポイント
+	BoardMonthly Board = "monthly"
+	// 継続中の連続達成日数（全習慣のうち最長）
+	BoardStreak Board = "streak"
+)
+
+func ParseBoard(value string) (Board, error) {
+	switch board := Board(value); board {
+	case BoardPoints, BoardWeekly, BoardMonthly, BoardStreak:
+		return board, nil
+	}
+	return "", common.ErrInvalidLeaderboard
+}
+
+// ランキングの対象
+type Scope string
+
+const (
+	// ランキングを公開している全ユーザー
+	ScopeGlobal Scope = "global"
+	// 自分と承認済みのフレンド
+	ScopeFriends Scope = "friends"
+)
+
+// 未指定の場合は全ユーザー
+func ParseScope(value string) (Scope, error) {
+	switch scope := Scope(value); scope {
+	case "":
+		return ScopeGlobal, nil
+	case ScopeGlobal, ScopeFriends:
+		return scope, nil
+	}
+	return "", common.ErrInvalidLeaderboard
+}
+
+// 指定日が属する集計期間（期間ごとに集計しないランキングは空文字列）
+// 週は週の開始日（YYYY-MM-DD）、月はYYYY-MM
+func (b Board) Period(date time.Time) string {
+	switch b {
+	case BoardWeekly:
+		return common.FormatDate(habit.WeekStart(date))
+	case BoardMonthly:
+		return date.Format("2006-01")
+	}
+	return ""
+}
+
+// ランキング集計用のユーザーごとのスコア
+type Score struct {
+	UserId      string
+	Board       Board
+	Period      string
+	Score       int
+	Hidden      bool   // ランキングに表示しない
+	ActiveUntil string // 連続達成が途切れない最終日（連続達成日数のランキングのみ）
+	UpdatedAt   time.Time
+}
+
+// ランキングの1行
+type Entry struct {
+	Rank     int    `json:"rank"`
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+	Score    int    `json:"score"`
+	IsMe     bool   `json:"is_me"`
+}
+
+// ランキングのページ
+type Leaderboard struct {
+	Board   Board    `json:"board"`
+	Scope   Scope    `json:"scope"`
+	Period  string   `json:"period,omitempty"`
+	Entries []*Entry `json:"entries"`
+	Me      *Entry   `json:"me"` // 自分の順位（非公開・未集計の場合はnull）
+	Page    int      `json:"page"`
+	Limit   int      `json:"limit"`
+	Total   int64    `json:"total"`
+}
@@ -0,0 +1,55 @@
+package reminder
+
+import (
+	"time"
+
+	"backend/internal/domain/common"
+)
+
+// 時刻の形式（ユーザーのタイムゾーンでの時刻）
+const TimeLayout = "15:04"
+
+// 習慣ごとのリマインダー（1つの時刻につき1件）
+type Reminder struct {
+	Id        string    `json:"id"`
+	UserId    string    `json:"user_id"`
+	HabitId   string    `json:"habit_id"`
+	Time      string    `json:"time"`    // HH:MM（ユーザーのタイムゾーン）
+	NextAt    time.Time `json:"next_at"` // 次に送信する日時
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// 時刻を検証し、HH:MMの形式に揃える
+func ParseTime(value string) (string, error) {
+	parsed, err := time.Parse(TimeLayout, value)
+	if err != nil {
+		return "", common.ErrInvalidReminder
+	}
+	return parsed.Format(TimeLayout), nil
+}
+
+// afterより後で、指定したタイムゾーンの時刻に最も近い日時
+// NOTE: 夏時間の切り替えで存在しない時刻の場合は、time.Dateの正規化に従う
+func NextAt(clock string, location *time.Location, after time.Time) time.Time {
+	parsed, err := time.Parse(TimeLayout, clock)
+	if err != nil {
+		return after.Add(24 * time.Hour)
+	}
+
+	local := after.In(location)
+	for days := 0; ; days++ {
+		next := time.Date(local.Year(), local.Month(), local.Day()+days, parsed.Hour(), parsed.Minute(), 0, 0, location)
+		if next.After(after) {
+			return next
+		}
+	}
+}
+
+// 送信済みの記録（リマインダー・日付ごとに1件）
+// NOTE: 再起動・複数台での実行時に同じ日に二重に送信しないために使用する
+type Delivery struct {
+	ReminderId string
+	UserId     string
+	Date       string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,22 @@
+package reward
+
+import "time"
+
+// ごほうびの交換履歴（作成後は変更しない）
+// NOTE: ごほうびが削除・変更されても履歴が分かるよう、交換時の名前とポイントを保存する
+type Redemption struct {
+	Id         string    `json:"id"`
+	UserId     string    `json:"user_id"`
+	RewardId   string    `json:"reward_id"`
+	RewardName string    `json:"reward_name"`
+	Cost       int       `json:"cost"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// 交換履歴のページ
+type RedemptionHistory struct {
+	Redemptions []*Redemption `json:"redemptions"`
+	Page        int           `json:"page"`
+	Limit       int           `json:"limit"`
+	Total       int64         `json:"total"`
+}
@@ -0,0 +1,50 @@
+package reward
+
+import (
+	"time"
+
+	"backend/internal/domain/common"
+)
+
+// ユーザーが自分で設定するごほうび（ポイントと交換する）
+type Reward struct {
+	Id             string     `json:"id"`
+	UserId         string     `json:"user_id"`
+	Name           string     `json:"name"`
+	Cost           int        `json:"cost"`                       // 交換に必要なポイント
+	Stock          *int       `json:"stock,omitempty"`            // 残りの在庫（nilの場合は無制限）
+	CooldownHours  int        `json:"cooldown_hours,omitempty"`   // 交換後、次に交換できるまでの時間（0の場合は制限なし）
+	LastRedeemedAt *time.Time `json:"last_redeemed_at,omitempty"` // 最後に交換した日時
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ごほうびの内容を検証する
+func (r *Reward) Validate() error {
+	if r.Name == "" || r.Cost < 1 || r.CooldownHours < 0 {
+		return common.ErrInvalidReward
+	}
+	if r.Stock != nil && *r.Stock < 0 {
+		return common.ErrInvalidReward
+	}
+	return nil
+}
+
+// 次に交換できる日時（クールダウンが無い、もしくは未交換の場合はnil）
+func (r *Reward) NextAvailableAt() *time.Time {
+	if r.CooldownHours == 0 || r.LastRedeemedAt == nil {
+		return nil
+	}
+	next := r.LastRedeemedAt.Add(time.Duration(r.CooldownHours) * time.Hour)
+	return &next
+}
+
+// 交換できるかどうか（在庫切れの場合はErrRewardOutOfStock、クールダウン中の場合はErrRewardCoolingDown）
+func (r *Reward) CanRedeem(now time.Time) error {
+	if r.Stock != nil && *r.Stock == 0 {
+		return common.ErrRewardOutOfStock
+	}
+	if next := r.NextAvailableAt(); next != nil && now.Before(*next) {
+		return common.ErrRewardCoolingDown
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package session
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"backend/internal/domain/common"
+)
+
+// ログインごと（端末ごと）のセッション
+// リフレッシュトークンは使用するたびに新しいものに置き換える（ローテーション）
+type Session struct {
+	Id               string     `json:"id"`
+	UserId           string     `json:"user_id"`
+	RefreshTokenHash string     `json:"-"` // 現在有効なリフレッシュトークンのハッシュ
+	UsedTokenHashes  []string   `json:"-"` // ローテーション済み（使用済み）のリフレッシュトークンのハッシュ
+	ExpiresAt        time.Time  `json:"expires_at"`
+	RevokedAt        *time.Time `json:"revoked_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// アクセストークンとリフレッシュトークンの組
+type TokenPair struct {
+	AccessToken  string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"` // アクセストークンの有効期限
+}
+
+// 有効なセッションかどうか（失効済み・期限切れは無効）
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// ローテーション済みのリフレッシュトークンかどうか（再利用の検知に使用）
+func (s *Session) IsUsedToken(tokenHash string) bool {
+	for _, usedHash := range s.UsedTokenHashes {
+		if usedHash == tokenHash {
+			return true
+		}
+	}
+	return false
+}
+
+// リフレッシュトークンの秘密部分を生成する
+func NewRefreshSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// リフレッシュトークンの秘密部分のハッシュ（DBには平文を保存しない）
+func HashRefreshSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// リフレッシュトークン（<セッションID>.<秘密>）を作成する
+func FormatRefreshToken(sessionId string, secret string) string {
+	return sessionId + "." + secret
+}
+
+// リフレッシュトークンをセッションIDと秘密に分解する
+func ParseRefreshToken(refreshToken string) (string, string, error) {
+	sessionId, secret, found := strings.Cut(refreshToken, ".")
+	if !found || sessionId == "" || secret == "" {
+		return "", "", common.ErrInvalidToken
+	}
+	return sessionId, secret, nil
+}
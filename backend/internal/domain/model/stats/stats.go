@@ -0,0 +1,51 @@
+package stats
+
+// 集計の単位
+type GroupBy string
+
+const (
+	GroupByHabit   GroupBy = "habit"
+	GroupByWeekday GroupBy = "weekday"
+	GroupByMonth   GroupBy = "month"
+	GroupByDate    GroupBy = "date"
+)
+
+// 習慣ごとの達成率を算出する期間（日数）
+var RateWindows = []int{7, 30, 90}
+
+// 達成状況の集計結果
+type Completion struct {
+	Key       string  `json:"key"`                  // 集計単位の値（習慣ID、曜日、YYYY-MM、YYYY-MM-DD）
+	HabitName string  `json:"habit_name,omitempty"` // 習慣ごとの集計のみ
+	Done      int     `json:"done"`                 // 達成数
+	Scheduled int     `json:"scheduled"`            // 実施予定数（daily_trackに含まれていた数）
+	Rate      float64 `json:"rate"`                 // 達成率（0〜1）
+}
+
+// 達成率を算出する
+func (c *Completion) CalculateRate() {
+	if c.Scheduled == 0 {
+		c.Rate = 0
+		return
+	}
+	c.Rate = float64(c.Done) / float64(c.Scheduled)
+}
+
+// 習慣ごとの期間別の達成率
+type HabitStats struct {
+	HabitId   string                 `json:"habit_id"`
+	HabitName string                 `json:"habit_name"`
+	Rates     map[string]*Completion `json:"rates"` // "7d", "30d", "90d" -> 達成状況
+}
+
+type Stats struct {
+	WindowDays int           `json:"window_days"`
+	From       string        `json:"from"`
+	To         string        `json:"to"`
+	HabitId    string        `json:"habit_id,omitempty"` // 習慣で絞り込んだ場合のみ
+	Overall    *Completion   `json:"overall"`            // 期間全体の達成状況
+	Daily      []*Completion `json:"daily"`              // 日ごとの達成状況
+	Weekdays   []*Completion `json:"weekdays"`           // 曜日ごとの達成状況
+	Monthly    []*Completion `json:"monthly"`            // 月ごとの達成状況（直近数ヶ月の推移）
+	Habits     []*HabitStats `json:"habits"`             // 習慣ごとの期間別の達成率
+}
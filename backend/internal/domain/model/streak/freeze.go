@@ -0,0 +1,12 @@
+package streak
+
+import "time"
+
+// ストリークフリーズ（未達成の日に使用すると、その日は連続が途切れない）
+type Freeze struct {
+	Id        string    `json:"id"`
+	UserId    string    `json:"user_id"`
+	HabitId   string    `json:"habit_id"`
+	Date      string    `json:"date"` // 保護した日付（YYYY-MM-DD）
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,143 @@
+package streak
+
+import (
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
+)
+
+type Unit string
+
+const (
+	// 日単位の継続（daily / weekdays / interval）
+	UnitDay Unit = "day"
+	// 週単位の継続（weekly_quota）
+	UnitWeek Unit = "week"
+)
+
+type Streak struct {
+	HabitId string `json:"habit_id"`
+	Unit    Unit   `json:"unit"`
+	Current int    `json:"current"` // 現在の連続達成数
+	Longest int    `json:"longest"` // 最長の連続達成数
+
+	// 渡した履歴の中で連続が途切れたかどうか（途切れていれば、それより前の履歴は現在の連続達成数に影響しない）
+	Broken bool `json:"-"`
+}
+
+// daily_trackの履歴から習慣の連続達成数を算出する
+// ・実施予定日でない日は判定しない
+// ・daily_trackはあるが習慣が含まれていない日は、その日の対象外だったとみなし判定しない
+// ・daily_trackが無い実施予定日は未達成とみなす
+// ・未達成の日でもストリークフリーズを使用した日は連続が途切れない
+// ・今日（今週）はまだ途中のため、未達成でも連続は途切れない
+// NOTE: dailyTracksは日付の昇順で渡す
+func Calculate(targetHabit *habit.Habit, dailyTracks []*daily_track.DailyTrack, frozenDates map[string]bool, today time.Time) *Streak {
+	history := newHabitHistory(targetHabit.Id, dailyTracks)
+
+	if targetHabit.Schedule.IsWeeklyQuota() {
+		return calculateWeekly(targetHabit, history, frozenDates, today)
+	}
+	return calculateDaily(targetHabit, history, frozenDates, today)
+}
+
+func calculateDaily(targetHabit *habit.Habit, history *habitHistory, frozenDates map[string]bool, today time.Time) *Streak {
+	result := &Streak{HabitId: targetHabit.Id, Unit: UnitDay}
+	if history.firstDate == nil {
+		return result
+	}
+
+	run := 0
+	for date := *history.firstDate; !date.After(today); date = date.AddDate(0, 0, 1) {
+		if !targetHabit.IsScheduledOn(date) {
+			continue
+		}
+
+		dateString := common.FormatDate(date)
+		status, hasStatus := history.statuses[dateString]
+		switch {
+		case hasStatus && status.IsDone:
+			run++
+			result.Longest = max(result.Longest, run)
+		case history.trackDates[dateString] && !hasStatus:
+			continue
+		case frozenDates[dateString], date.Equal(today):
+			continue
+		default:
+			run = 0
+			result.Broken = true
+		}
+	}
+	result.Current = run
+
+	return result
+}
+
+func calculateWeekly(targetHabit *habit.Habit, history *habitHistory, frozenDates map[string]bool, today time.Time) *Streak {
+	result := &Streak{HabitId: targetHabit.Id, Unit: UnitWeek}
+	if history.firstDate == nil {
+		return result
+	}
+
+	currentWeek := habit.WeekStart(today)
+	run := 0
+	for week := habit.WeekStart(*history.firstDate); !week.After(currentWeek); week = week.AddDate(0, 0, 7) {
+		done, frozen := 0, false
+		for date := week; date.Before(week.AddDate(0, 0, 7)); date = date.AddDate(0, 0, 1) {
+			dateString := common.FormatDate(date)
+			if status, ok := history.statuses[dateString]; ok && status.IsDone {
+				done++
+			}
+			frozen = frozen || frozenDates[dateString]
+		}
+
+		switch {
+		case done >= targetHabit.Schedule.TimesPerWeek:
+			run++
+			result.Longest = max(result.Longest, run)
+		case frozen, week.Equal(currentWeek):
+			continue
+		default:
+			run = 0
+			result.Broken = true
+		}
+	}
+	result.Current = run
+
+	return result
+}
+
+// 習慣ごとに整理したdaily_trackの履歴
+type habitHistory struct {
+	firstDate  *time.Time                          // 習慣が初めてdaily_trackに含まれた日
+	statuses   map[string]*daily_track.HabitStatus // 日付 -> 習慣のステータス
+	trackDates map[string]bool                     // daily_trackが存在する日付
+}
+
+func newHabitHistory(habitId string, dailyTracks []*daily_track.DailyTrack) *habitHistory {
+	history := &habitHistory{
+		statuses:   make(map[string]*daily_track.HabitStatus),
+		trackDates: make(map[string]bool),
+	}
+
+	for _, dailyTrack := range dailyTracks {
+		history.trackDates[dailyTrack.Date] = true
+		for _, habitStatus := range dailyTrack.HabitStatuses {
+			if habitStatus.HabitId != habitId {
+				continue
+			}
+			history.statuses[dailyTrack.Date] = habitStatus
+
+			if history.firstDate != nil {
+				continue
+			}
+			if date, err := common.ParseDate(dailyTrack.Date); err == nil {
+				history.firstDate = &date
+			}
+		}
+	}
+
+	return history
+}
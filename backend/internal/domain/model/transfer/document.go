@@ -0,0 +1,173 @@
+package transfer
+
+import (
+	"fmt"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/point"
+)
+
+// エクスポート形式のバージョン（形式を変更した場合は上げる）
+const CurrentVersion = 1
+
+type Mode string
+
+const (
+	// 既存のデータに追加する（同じ日のdaily_trackは統合する）
+	ModeMerge Mode = "merge"
+	// 既存の習慣・daily_trackを削除してから取り込む
+	ModeReplace Mode = "replace"
+)
+
+// エクスポートするユーザー情報（パスワードは含めない）
+type ExportedUser struct {
+	Username     string `json:"username"`
+	Points       int    `json:"points"`
+	FreezeTokens int    `json:"freeze_tokens"`
+	Timezone     string `json:"timezone,omitempty"`
+	DayStartHour int    `json:"day_start_hour"`
+}
+
+// エクスポート・インポートするドキュメント
+type Document struct {
+	Version      int                       `json:"version"`
+	ExportedAt   time.Time                 `json:"exported_at"`
+	User         ExportedUser              `json:"user"`
+	Habits       []*habit.Habit            `json:"habits"`
+	DailyTracks  []*daily_track.DailyTrack `json:"daily_tracks"`
+	PointsLedger []*point.LedgerEntry      `json:"points_ledger"`
+}
+
+// インポート結果
+// NOTE: DryRunの場合は登録せず、登録される内容を返す
+type ImportResult struct {
+	Mode               Mode     `json:"mode"`
+	DryRun             bool     `json:"dry_run"`
+	CreatedHabits      int      `json:"created_habits"`       // 新しく登録した習慣の数
+	CreatedHabitNames  []string `json:"created_habit_names"`  // 新しく登録した習慣の名前
+	MatchedHabits      int      `json:"matched_habits"`       // 同名の既存の習慣に対応付けた数（mergeのみ）
+	CreatedDailyTracks int      `json:"created_daily_tracks"` // 新しく登録したdaily_trackの数
+	MergedDailyTracks  int      `json:"merged_daily_tracks"`  // 既存のdaily_trackに統合した数（mergeのみ）
+}
+
+// インポートのモードを検証する（未指定の場合はmerge）
+func ParseMode(mode string) (Mode, error) {
+	switch Mode(mode) {
+	case "", ModeMerge:
+		return ModeMerge, nil
+	case ModeReplace:
+		return ModeReplace, nil
+	default:
+		return "", common.ErrInvalidImport
+	}
+}
+
+// インポートするドキュメントを検証する
+func (d *Document) Validate() error {
+	if d.Version != CurrentVersion {
+		return fmt.Errorf("%w: unsupported version %d", common.ErrInvalidImport, d.Version)
+	}
+
+	// ポイント・ストリークフリーズの所持数（取り込みには使用しない）
+	if d.User.Points < 0 || d.User.FreezeTokens < 0 {
+		return fmt.Errorf("%w: points and freeze_tokens must not be negative", common.ErrInvalidImport)
+	}
+
+	// 習慣（IDと名前はドキュメント内で一意）
+	habitIds := make(map[string]bool)
+	habitNames := make(map[string]bool)
+	for _, h := range d.Habits {
+		if h == nil || h.Id == "" || h.Name == "" {
+			return fmt.Errorf("%w: habit id and name are required", common.ErrInvalidImport)
+		}
+		if habitIds[h.Id] || habitNames[h.Name] {
+			return fmt.Errorf("%w: duplicate habit %s", common.ErrInvalidImport, h.Name)
+		}
+		habitIds[h.Id], habitNames[h.Name] = true, true
+
+		if err := h.Schedule.Validate(); err != nil {
+			return fmt.Errorf("%w: habit %s: %v", common.ErrInvalidImport, h.Name, err)
+		}
+		if h.Measure != nil {
+			if err := h.Measure.Validate(); err != nil {
+				return fmt.Errorf("%w: habit %s: %v", common.ErrInvalidImport, h.Name, err)
+			}
+		}
+		if h.Scoring.Difficulty != "" {
+			if err := h.Scoring.Validate(); err != nil {
+				return fmt.Errorf("%w: habit %s: %v", common.ErrInvalidImport, h.Name, err)
+			}
+		}
+		switch h.Status {
+		case "", habit.StatusActive, habit.StatusArchived:
+		case habit.StatusPaused:
+			if _, err := common.ParseDate(h.PausedUntil); err != nil {
+				return fmt.Errorf("%w: habit %s: invalid paused_until", common.ErrInvalidImport, h.Name)
+			}
+		default:
+			return fmt.Errorf("%w: habit %s: invalid status %s", common.ErrInvalidImport, h.Name, h.Status)
+		}
+	}
+
+	// daily_track（日付はドキュメント内で一意、習慣はドキュメント内の習慣のみ）
+	dates := make(map[string]bool)
+	for _, track := range d.DailyTracks {
+		if track == nil {
+			return fmt.Errorf("%w: empty daily_track", common.ErrInvalidImport)
+		}
+		if _, err := common.ParseDate(track.Date); err != nil {
+			return fmt.Errorf("%w: invalid date %s", common.ErrInvalidImport, track.Date)
+		}
+		if dates[track.Date] {
+			return fmt.Errorf("%w: duplicate daily_track %s", common.ErrInvalidImport, track.Date)
+		}
+		dates[track.Date] = true
+
+		trackHabitIds := make(map[string]bool)
+		for _, habitStatus := range track.HabitStatuses {
+			if habitStatus == nil || !habitIds[habitStatus.HabitId] {
+				return fmt.Errorf("%w: daily_track %s: unknown habit", common.ErrInvalidImport, track.Date)
+			}
+			if trackHabitIds[habitStatus.HabitId] {
+				return fmt.Errorf("%w: daily_track %s: duplicate habit %s", common.ErrInvalidImport, track.Date, habitStatus.HabitId)
+			}
+			trackHabitIds[habitStatus.HabitId] = true
+		}
+	}
+
+	return nil
+}
+
+// 取り込むdaily_trackの習慣のステータスを既存のdaily_trackに統合する
+// 両方にある習慣は、どちらかが完了なら完了とし、記録量は大きい方を採用する
+// 既存のdaily_trackが変更された場合にtrueを返す
+func MergeHabitStatuses(existing *daily_track.DailyTrack, imported *daily_track.DailyTrack) bool {
+	changed := false
+	for _, importedStatus := range imported.HabitStatuses {
+		var existingStatus *daily_track.HabitStatus
+		for _, habitStatus := range existing.HabitStatuses {
+			if habitStatus.HabitId == importedStatus.HabitId {
+				existingStatus = habitStatus
+				break
+			}
+		}
+
+		if existingStatus == nil {
+			existing.HabitStatuses = append(existing.HabitStatuses, importedStatus)
+			changed = true
+			continue
+		}
+		if importedStatus.IsDone && !existingStatus.IsDone {
+			existingStatus.IsDone = true
+			changed = true
+		}
+		if importedStatus.Value > existingStatus.Value {
+			existingStatus.Value = importedStatus.Value
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,38 @@
+package transfer
+
+import (
+	"errors"
+	"testing"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/habit"
+)
+
+func TestDocumentValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    ExportedUser
+		wantErr bool
+	}{
+		{name: "有効", user: ExportedUser{Username: "alice", Points: 10, FreezeTokens: 1}},
+		{name: "ポイントが負", user: ExportedUser{Username: "alice", Points: -1}, wantErr: true},
+		{name: "ストリークフリーズの所持数が負", user: ExportedUser{Username: "alice", FreezeTokens: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document := &Document{
+				Version: CurrentVersion,
+				User:    tt.user,
+				Habits:  []*habit.Habit{{Id: "h1", Name: "読書", Schedule: habit.DefaultSchedule()}},
+			}
+			err := document.Validate()
+			if tt.wantErr && !errors.Is(err, common.ErrInvalidImport) {
+				t.Errorf("got error %v, want ErrInvalidImport", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package transfer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/point"
+)
+
+// エクスポートの書き出し先
+// NOTE: daily_trackとポイント台帳は件数が多いため、全件をメモリに載せずに1件ずつ書き出す
+// WriteHeader -> WriteDailyTrack -> WriteLedgerEntry -> Close の順に呼び出す
+type Exporter interface {
+	WriteHeader(exportedAt time.Time, user ExportedUser, habits []*habit.Habit) error
+	WriteDailyTrack(track *daily_track.DailyTrack) error
+	WriteLedgerEntry(entry *point.LedgerEntry) error
+	Close() error
+}
+
+// 書き出し中の配列
+const (
+	jsonSectionHeader = iota
+	jsonSectionDailyTracks
+	jsonSectionPointsLedger
+)
+
+// Documentと同じ形式のJSONを書き出す（インポートでそのまま取り込める）
+type jsonExporter struct {
+	w       io.Writer
+	section int
+	count   int // 書き出し中の配列の要素数
+}
+
+func NewJSONExporter(w io.Writer) Exporter {
+	return &jsonExporter{w: w}
+}
+
+func (e *jsonExporter) WriteHeader(exportedAt time.Time, user ExportedUser, habits []*habit.Habit) error {
+	if habits == nil {
+		habits = make([]*habit.Habit, 0)
+	}
+
+	// Documentのdaily_tracksより前のフィールド
+	header, err := json.Marshal(struct {
+		Version    int            `json:"version"`
+		ExportedAt time.Time      `json:"exported_at"`
+		User       ExportedUser   `json:"user"`
+		Habits     []*habit.Habit `json:"habits"`
+	}{CurrentVersion, exportedAt, user, habits})
+	if err != nil {
+		return err
+	}
+
+	// 閉じ括弧の代わりにdaily_tracksの配列を開始する
+	header = append(header[:len(header)-1], `,"daily_tracks":[`...)
+	if _, err = e.w.Write(header); err != nil {
+		return err
+	}
+
+	e.section = jsonSectionDailyTracks
+	return nil
+}
+
+func (e *jsonExporter) WriteDailyTrack(track *daily_track.DailyTrack) error {
+	return e.writeElement(track)
+}
+
+func (e *jsonExporter) WriteLedgerEntry(entry *point.LedgerEntry) error {
+	if e.section == jsonSectionDailyTracks {
+		if err := e.nextSection(); err != nil {
+			return err
+		}
+	}
+	return e.writeElement(entry)
+}
+
+func (e *jsonExporter) Close() error {
+	if e.section == jsonSectionDailyTracks {
+		if err := e.nextSection(); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(e.w, "]}\n")
+	return err
+}
+
+// daily_tracksの配列を閉じ、points_ledgerの配列を開始する
+func (e *jsonExporter) nextSection() error {
+	e.section = jsonSectionPointsLedger
+	e.count = 0
+	_, err := io.WriteString(e.w, `],"points_ledger":[`)
+	return err
+}
+
+// 配列の要素を書き出す（2件目以降は区切りを付ける）
+func (e *jsonExporter) writeElement(value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if e.count > 0 {
+		data = append([]byte(","), data...)
+	}
+	e.count++
+
+	_, err = e.w.Write(data)
+	return err
+}
+
+// CSVのヘッダー
+var CSVHeader = []string{"date", "habit_id", "habit_name", "is_done", "value", "unit", "target"}
+
+// daily_trackを習慣・日付ごとに1行のCSVで書き出す（ポイント台帳は含めない）
+type csvExporter struct {
+	writer *csv.Writer
+}
+
+func NewCSVExporter(w io.Writer) Exporter {
+	return &csvExporter{writer: csv.NewWriter(w)}
+}
+
+func (e *csvExporter) WriteHeader(exportedAt time.Time, user ExportedUser, habits []*habit.Habit) error {
+	return e.writer.Write(CSVHeader)
+}
+
+func (e *csvExporter) WriteDailyTrack(track *daily_track.DailyTrack) error {
+	for _, habitStatus := range track.HabitStatuses {
+		value, unit, target := "", "", ""
+		if habitStatus.Measure != nil {
+			value = strconv.FormatFloat(habitStatus.Value, 'f', -1, 64)
+			unit = habitStatus.Measure.Unit
+			target = strconv.FormatFloat(habitStatus.Measure.Target, 'f', -1, 64)
+		}
+		err := e.writer.Write([]string{
+			track.Date,
+			habitStatus.HabitId,
+			habitStatus.HabitName,
+			strconv.FormatBool(habitStatus.IsDone),
+			value,
+			unit,
+			target,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *csvExporter) WriteLedgerEntry(entry *point.LedgerEntry) error {
+	return nil
+}
+
+func (e *csvExporter) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
@@ -0,0 +1,104 @@
+package transfer
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/point"
+)
+
+func TestJSONExporter(t *testing.T) {
+	exportedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	user := ExportedUser{Username: "alice", Points: 10, FreezeTokens: 1, Timezone: "Asia/Tokyo", DayStartHour: 4}
+	habits := []*habit.Habit{{Id: "h1", Name: "読書", Schedule: habit.DefaultSchedule()}}
+	tracks := []*daily_track.DailyTrack{
+		{Date: "2026-01-01", HabitStatuses: []*daily_track.HabitStatus{{HabitId: "h1", HabitName: "読書", IsDone: true}}},
+		{Date: "2026-01-02", HabitStatuses: []*daily_track.HabitStatus{{HabitId: "h1", HabitName: "読書"}}},
+	}
+	entries := []*point.LedgerEntry{{UserId: "u1", Amount: 3, Reason: point.ReasonHabitDone}}
+
+	tests := []struct {
+		name    string
+		tracks  []*daily_track.DailyTrack
+		entries []*point.LedgerEntry
+	}{
+		{name: "daily_trackとポイント台帳あり", tracks: tracks, entries: entries},
+		{name: "daily_trackのみ", tracks: tracks},
+		{name: "ポイント台帳のみ", entries: entries},
+		{name: "空"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			exporter := NewJSONExporter(&buf)
+			if err := exporter.WriteHeader(exportedAt, user, habits); err != nil {
+				t.Fatal(err)
+			}
+			for _, track := range tt.tracks {
+				if err := exporter.WriteDailyTrack(track); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, entry := range tt.entries {
+				if err := exporter.WriteLedgerEntry(entry); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := exporter.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			// インポートでそのまま取り込める形式であること
+			var document Document
+			if err := json.Unmarshal(buf.Bytes(), &document); err != nil {
+				t.Fatalf("invalid json: %v\n%s", err, buf.String())
+			}
+			if err := document.Validate(); err != nil {
+				t.Fatalf("Validate() = %v", err)
+			}
+			if document.Version != CurrentVersion || !document.ExportedAt.Equal(exportedAt) || document.User != user {
+				t.Errorf("header = %+v", document)
+			}
+			if len(document.Habits) != 1 || len(document.DailyTracks) != len(tt.tracks) || len(document.PointsLedger) != len(tt.entries) {
+				t.Errorf("habits = %d, daily_tracks = %d, points_ledger = %d", len(document.Habits), len(document.DailyTracks), len(document.PointsLedger))
+			}
+		})
+	}
+}
+
+func TestCSVExporter(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := NewCSVExporter(&buf)
+	if err := exporter.WriteHeader(time.Now(), ExportedUser{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	err := exporter.WriteDailyTrack(&daily_track.DailyTrack{Date: "2026-01-01", HabitStatuses: []*daily_track.HabitStatus{
+		{HabitId: "h1", HabitName: "読書", IsDone: true},
+		{HabitId: "h2", HabitName: "水", Measure: &habit.Measure{Unit: "杯", Target: 8}, Value: 3},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.WriteLedgerEntry(&point.LedgerEntry{Amount: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"date,habit_id,habit_name,is_done,value,unit,target",
+		"2026-01-01,h1,読書,true,,,",
+		"2026-01-01,h2,水,false,3,杯,8",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Errorf("csv =\n%s\nwant\n%s", got, want)
+	}
+}
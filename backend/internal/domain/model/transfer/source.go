@@ -0,0 +1,23 @@
+package transfer
+
+import "backend/internal/domain/common"
+
+// 他の習慣管理アプリのデータ形式
+type Source string
+
+const (
+	// Loop Habit Tracker のCSVバックアップ（Habits.csvと習慣ごとのCheckmarks.csvを含むzip）
+	SourceLoop Source = "loop"
+	// Habitica のデータエクスポート（JSON）
+	SourceHabitica Source = "habitica"
+)
+
+// データ形式を検証する
+func ParseSource(source string) (Source, error) {
+	switch Source(source) {
+	case SourceLoop, SourceHabitica:
+		return Source(source), nil
+	default:
+		return "", common.ErrInvalidImport
+	}
+}
@@ -3,8 +3,9 @@ package user
 import "github.com/golang-jwt/jwt/v4"
 
 type Claims struct {
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	UserId    string `json:"user_id"`
+	Username  string `json:"username"`
+	SessionId string `json:"session_id"`
 	jwt.RegisteredClaims
 }
 
@@ -0,0 +1,38 @@
+package user
+
+import (
+	"time"
+
+	"backend/internal/domain/common"
+)
+
+// タイムゾーン・1日の始まりの時刻の設定を検証する
+func ValidateDaySettings(timezone string, dayStartHour int) error {
+	if timezone == "" || dayStartHour < 0 || dayStartHour > 23 {
+		return common.ErrInvalidDaySettings
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return common.ErrInvalidDaySettings
+	}
+	return nil
+}
+
+// ユーザーのタイムゾーン
+// NOTE: 未設定（既存ユーザー）・不正な場合はサーバーのタイムゾーンとして扱う
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.Local
+	}
+	location, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return location
+}
+
+// ユーザーにとっての「今日」を返す（ParseDateの結果と比較できるようUTCの0時に揃える）
+// 1日の始まりの時刻より前は前日として扱う（例: 4時始まりの場合、深夜2時は前日）
+func (u *User) Today(now time.Time) time.Time {
+	localNow := now.In(u.Location()).Add(-time.Duration(u.DayStartHour) * time.Hour)
+	return common.TruncateToDate(localNow)
+}
@@ -1,8 +1,24 @@
 package user
 
+import (
+	"backend/internal/domain/model/level"
+	"backend/internal/domain/model/notification"
+)
+
 type User struct {
 	Id       string `json:"id"`
 	Username string `json:"username"`
 	Password string `json:"password"`
 	Points   int    `json:"points"`
+
+	FreezeTokens int `json:"freeze_tokens"` // 所持しているストリークフリーズの数
+
+	Timezone     string `json:"timezone"`       // IANAタイムゾーン（例: Asia/Tokyo）
+	DayStartHour int    `json:"day_start_hour"` // 1日の始まりの時刻（0〜23時）
+
+	HideFromLeaderboard bool `json:"hide_from_leaderboard"` // ランキングに表示しない
+
+	Notification *notification.Settings `json:"notification,omitempty"` // リマインダーなどの通知先（未設定の場合は通知しない）
+
+	Level *level.Level `json:"level,omitempty"` // 台帳から算出する（DBには保存しない）
 }
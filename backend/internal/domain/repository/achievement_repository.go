@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"backend/internal/domain/model/achievement"
+	"context"
+)
+
+type AchievementRepository interface {
+	FetchAll(ctx context.Context, userId string) ([]*achievement.Badge, error)
+	Register(ctx context.Context, badge *achievement.Badge) (*achievement.Badge, error)
+	DeleteAll(ctx context.Context, userId string) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"backend/internal/domain/model/challenge"
+	"context"
+)
+
+type ChallengeRepository interface {
+	FetchByUser(ctx context.Context, userId string) ([]*challenge.Challenge, error)
+	FetchJoinedByHabit(ctx context.Context, userId string, habitId string, date string) ([]*challenge.Challenge, error)
+	Find(ctx context.Context, userId string, id string) (*challenge.Challenge, error)
+	Register(ctx context.Context, challenge *challenge.Challenge) (*challenge.Challenge, error)
+	AddParticipant(ctx context.Context, id string, participant *challenge.Participant) error
+	UpdateParticipant(ctx context.Context, id string, participant *challenge.Participant) error
+	RemoveParticipant(ctx context.Context, id string, userId string) error
+	UnlinkHabit(ctx context.Context, userId string, habitId string) error
+	MarkCompleted(ctx context.Context, id string, userId string, completed bool) (bool, error)
+	Delete(ctx context.Context, ownerId string, id string) error
+	DeleteAll(ctx context.Context, userId string) error
+}
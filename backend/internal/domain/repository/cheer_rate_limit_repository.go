@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type CheerRateLimitRepository interface {
+	Increment(ctx context.Context, userId string, window time.Time, expiresAt time.Time, limit int) (bool, error)
+}
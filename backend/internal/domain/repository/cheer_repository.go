@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"backend/internal/domain/model/feed"
+	"context"
+)
+
+type CheerRepository interface {
+	Find(ctx context.Context, userId string, id string) (*feed.Cheer, error)
+	FetchByItems(ctx context.Context, itemIds []string) ([]*feed.Cheer, error)
+	Register(ctx context.Context, cheer *feed.Cheer) (*feed.Cheer, error)
+	Delete(ctx context.Context, userId string, id string) error
+	DeleteReaction(ctx context.Context, itemId string, userId string, emoji feed.Emoji) error
+	DeleteByCompletion(ctx context.Context, ownerId string, habitId string, date string) error
+	DeleteByHabit(ctx context.Context, ownerId string, habitId string) error
+	DeleteAll(ctx context.Context, userId string) error
+}
@@ -2,11 +2,22 @@ package repository
 
 import (
 	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
 	"context"
 )
 
 type DailyTrackRepository interface {
 	FindDailyTrack(ctx context.Context, userId string, targetDate string) (*daily_track.DailyTrack, error)
+	FindDailyTracks(ctx context.Context, userId string, fromDate string, toDate string) ([]*daily_track.DailyTrack, error)
+	ExistsHabitStatusBefore(ctx context.Context, userId string, habitIds []string, beforeDate string) (bool, error)
+	CountDone(ctx context.Context, userId string, toDate string) (int, error)
+	EachDailyTrack(ctx context.Context, userId string, fn func(dailyTrack *daily_track.DailyTrack) error) error
 	RegisterDailyTrack(ctx context.Context, dailyTrack *daily_track.DailyTrack) (*daily_track.DailyTrack, error)
+	RegisterDailyTracks(ctx context.Context, dailyTracks []*daily_track.DailyTrack) error
 	UpdateHabitStatuses(ctx context.Context, dailyTrack *daily_track.DailyTrack) error
+	UpdateHabitDone(ctx context.Context, userId string, dailyTrackId string, habitId string, isDone bool) (bool, error)
+	LogHabitAmount(ctx context.Context, userId string, dailyTrackId string, habitId string, amount float64, measure *habit.Measure) (*daily_track.DailyTrack, bool, error)
+	RenameHabit(ctx context.Context, userId string, habitId string, habitName string, fromDate string) error
+	RemoveHabit(ctx context.Context, userId string, habitId string) error
+	DeleteAll(ctx context.Context, userId string) error
 }
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"backend/internal/domain/model/feed"
+	"context"
+)
+
+type FeedItemRepository interface {
+	Find(ctx context.Context, userId string, sharedHabitIds map[string][]string, id string) (*feed.Item, error)
+	FetchFeed(ctx context.Context, userId string, sharedHabitIds map[string][]string, offset int, limit int) ([]*feed.Item, int64, error)
+	Register(ctx context.Context, item *feed.Item) (*feed.Item, error)
+	DeleteByCompletion(ctx context.Context, userId string, habitId string, date string) error
+	DeleteByHabit(ctx context.Context, userId string, habitId string) error
+	DeleteAll(ctx context.Context, userId string) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"backend/internal/domain/model/friend"
+	"context"
+)
+
+type FriendshipRepository interface {
+	Find(ctx context.Context, userId string, otherUserId string) (*friend.Friendship, error)
+	FetchAll(ctx context.Context, userId string) ([]*friend.Friendship, error)
+	Register(ctx context.Context, friendship *friend.Friendship) (*friend.Friendship, error)
+	Update(ctx context.Context, userId string, friendship *friend.Friendship) error
+	Delete(ctx context.Context, userId string, id string) error
+	DeleteAll(ctx context.Context, userId string) error
+}
@@ -7,6 +7,11 @@ import (
 
 type HabitRepository interface {
 	FetchAll(ctx context.Context, userId string) ([]*habit.Habit, error)
+	Find(ctx context.Context, userId string, id string) (*habit.Habit, error)
 	Register(ctx context.Context, habit *habit.Habit) (*habit.Habit, error)
-	Delete(ctx context.Context, id string) error
+	Update(ctx context.Context, habit *habit.Habit) error
+	UpdateSortOrders(ctx context.Context, userId string, habitIds []string) error
+	UpdateLongestStreak(ctx context.Context, userId string, id string, longest int) error
+	Delete(ctx context.Context, userId string, id string) error
+	DeleteAll(ctx context.Context, userId string) error
 }
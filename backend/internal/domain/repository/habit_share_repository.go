@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"backend/internal/domain/model/friend"
+	"context"
+)
+
+type HabitShareRepository interface {
+	Find(ctx context.Context, userId string, friendId string) (*friend.Share, error)
+	FetchAll(ctx context.Context, userId string) ([]*friend.Share, error)
+	FetchSharedWith(ctx context.Context, friendId string) ([]*friend.Share, error)
+	Update(ctx context.Context, share *friend.Share) (*friend.Share, error)
+	RemoveHabit(ctx context.Context, userId string, habitId string) error
+	DeleteBetween(ctx context.Context, userId string, friendId string) error
+	DeleteAll(ctx context.Context, userId string) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"backend/internal/domain/model/point"
+	"context"
+)
+
+type PointLedgerRepository interface {
+	Append(ctx context.Context, entry *point.LedgerEntry) (*point.LedgerEntry, error)
+	FetchHistory(ctx context.Context, userId string, offset int, limit int) ([]*point.LedgerEntry, int64, error)
+	EachEntry(ctx context.Context, userId string, fn func(entry *point.LedgerEntry) error) error
+	SumAmount(ctx context.Context, userId string) (int, error)
+	SumEarned(ctx context.Context, userId string) (int, error)
+	SumAmountByDate(ctx context.Context, userId string, date string, habitId string) (int, error)
+	DeleteAll(ctx context.Context, userId string) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"backend/internal/domain/model/notification"
+	"context"
+)
+
+type PushSubscriptionRepository interface {
+	FetchByUser(ctx context.Context, userId string) ([]*notification.PushSubscription, error)
+	Register(ctx context.Context, subscription *notification.PushSubscription) (*notification.PushSubscription, error)
+	Delete(ctx context.Context, userId string, endpoint string) error
+	DeleteByEndpoint(ctx context.Context, endpoint string) error
+	DeleteAll(ctx context.Context, userId string) error
+}
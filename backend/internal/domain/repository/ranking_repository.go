@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"backend/internal/domain/model/ranking"
+	"context"
+)
+
+type RankingRepository interface {
+	IncrementScore(ctx context.Context, score *ranking.Score) error
+	UpdateScore(ctx context.Context, score *ranking.Score) error
+	FindScore(ctx context.Context, userId string, board ranking.Board, period string) (*ranking.Score, error)
+	FetchTop(ctx context.Context, board ranking.Board, period string, activeFrom string, userIds []string, offset int, limit int) ([]*ranking.Score, int64, error)
+	CountHigher(ctx context.Context, board ranking.Board, period string, activeFrom string, userIds []string, score int) (int64, error)
+	UpdateHidden(ctx context.Context, userId string, hidden bool) error
+	DeleteAll(ctx context.Context, userId string) error
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"backend/internal/domain/model/reminder"
+	"context"
+)
+
+type ReminderDeliveryRepository interface {
+	Register(ctx context.Context, delivery *reminder.Delivery) error
+	DeleteAll(ctx context.Context, userId string) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"backend/internal/domain/model/reminder"
+	"context"
+	"time"
+)
+
+type ReminderRepository interface {
+	FetchByHabit(ctx context.Context, userId string, habitId string) ([]*reminder.Reminder, error)
+	FetchByUser(ctx context.Context, userId string) ([]*reminder.Reminder, error)
+	FetchDue(ctx context.Context, now time.Time, limit int) ([]*reminder.Reminder, error)
+	ReplaceByHabit(ctx context.Context, userId string, habitId string, reminders []*reminder.Reminder) ([]*reminder.Reminder, error)
+	Advance(ctx context.Context, id string, from time.Time, to time.Time) (bool, error)
+	UpdateNextAt(ctx context.Context, userId string, id string, nextAt time.Time) error
+	DeleteByHabit(ctx context.Context, userId string, habitId string) error
+	DeleteAll(ctx context.Context, userId string) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"backend/internal/domain/model/reward"
+	"context"
+)
+
+type RewardRedemptionRepository interface {
+	Register(ctx context.Context, redemption *reward.Redemption) (*reward.Redemption, error)
+	FetchHistory(ctx context.Context, userId string, offset int, limit int) ([]*reward.Redemption, int64, error)
+	DeleteAll(ctx context.Context, userId string) error
+}
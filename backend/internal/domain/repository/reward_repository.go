@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"backend/internal/domain/model/reward"
+	"context"
+	"time"
+)
+
+type RewardRepository interface {
+	FetchAll(ctx context.Context, userId string) ([]*reward.Reward, error)
+	Find(ctx context.Context, userId string, id string) (*reward.Reward, error)
+	Register(ctx context.Context, reward *reward.Reward) (*reward.Reward, error)
+	Update(ctx context.Context, reward *reward.Reward) error
+	Redeem(ctx context.Context, reward *reward.Reward, now time.Time) (*reward.Reward, error)
+	Delete(ctx context.Context, userId string, id string) error
+	DeleteAll(ctx context.Context, userId string) error
+}
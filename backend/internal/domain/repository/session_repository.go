@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"backend/internal/domain/model/session"
+	"context"
+	"time"
+)
+
+type SessionRepository interface {
+	Find(ctx context.Context, id string) (*session.Session, error)
+	Register(ctx context.Context, session *session.Session) (*session.Session, error)
+	Rotate(ctx context.Context, id string, currentHash string, newHash string, expiresAt time.Time) error
+	Revoke(ctx context.Context, userId string, id string) error
+	RevokeAll(ctx context.Context, userId string) error
+	RevokeOthers(ctx context.Context, userId string, id string) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"backend/internal/domain/model/stats"
+	"context"
+)
+
+type StatsRepository interface {
+	AggregateCompletion(ctx context.Context, userId string, fromDate string, toDate string, habitId string, groupBy stats.GroupBy) ([]*stats.Completion, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"backend/internal/domain/model/streak"
+	"context"
+)
+
+type StreakFreezeRepository interface {
+	FetchAll(ctx context.Context, userId string) ([]*streak.Freeze, error)
+	Register(ctx context.Context, freeze *streak.Freeze) (*streak.Freeze, error)
+	DeleteByHabit(ctx context.Context, userId string, habitId string) error
+	DeleteAll(ctx context.Context, userId string) error
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"backend/internal/domain/model/notification"
 	"backend/internal/domain/model/user"
 	"context"
 )
@@ -10,4 +11,16 @@ type UserRepository interface {
 	FindByUserName(ctx context.Context, username string) (*user.User, error)
 	Register(ctx context.Context, user *user.User) (*user.User, error)
 	UpdatePoints(ctx context.Context, userId string, points int) error
+	IncrementPoints(ctx context.Context, userId string, delta int) (int, error)
+	SpendPoints(ctx context.Context, userId string, cost int) (int, error)
+	IncrementFreezeTokens(ctx context.Context, userId string, delta int) (int, error)
+	UpdateDaySettings(ctx context.Context, userId string, timezone string, dayStartHour int) error
+	UpdateLeaderboardVisibility(ctx context.Context, userId string, hidden bool) error
+	UpdateNotificationSettings(ctx context.Context, userId string, settings *notification.Settings) error
+	FetchByIds(ctx context.Context, ids []string) ([]*user.User, error)
+	FetchTopPoints(ctx context.Context, userIds []string, offset int, limit int) ([]*user.User, int64, error)
+	CountHigherPoints(ctx context.Context, userIds []string, points int) (int64, error)
+	UpdatePassword(ctx context.Context, userId string, password string) error
+	UpdateUsername(ctx context.Context, userId string, username string) error
+	Delete(ctx context.Context, userId string) error
 }
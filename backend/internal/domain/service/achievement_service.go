@@ -0,0 +1,10 @@
+package service
+
+import (
+	"backend/internal/domain/model/achievement"
+	"context"
+)
+
+type AchievementService interface {
+	GetAchievements(ctx context.Context, userId string) ([]*achievement.Badge, error)
+}
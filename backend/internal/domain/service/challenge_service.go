@@ -0,0 +1,16 @@
+package service
+
+import (
+	"backend/internal/domain/model/challenge"
+	"context"
+)
+
+type ChallengeService interface {
+	GetChallenges(ctx context.Context, userId string) ([]*challenge.Challenge, error)
+	CreateChallenge(ctx context.Context, userId string, name string, startDate string, endDate string, targetDays int, habitId string) (*challenge.Challenge, error)
+	InviteParticipant(ctx context.Context, userId string, challengeId string, friendId string) (*challenge.Challenge, error)
+	JoinChallenge(ctx context.Context, userId string, challengeId string, habitId string) (*challenge.Challenge, error)
+	LeaveChallenge(ctx context.Context, userId string, challengeId string) error
+	DeleteChallenge(ctx context.Context, userId string, challengeId string) error
+	GetChallengeBoard(ctx context.Context, userId string, challengeId string) (*challenge.Board, error)
+}
@@ -7,5 +7,9 @@ import (
 
 type DailyTrackService interface {
 	GetDailyTrack(ctx context.Context, userId string, targetDate string) (*daily_track.DailyTrack, error)
-	UpdateDoneDailyTrack(ctx context.Context, userId string, targetDate string, targetHabitId string) error
+	GetDailyTracks(ctx context.Context, userId string, fromDate string, toDate string) ([]*daily_track.DailyTrack, error)
+	GetHeatmaps(ctx context.Context, userId string, fromDate string, toDate string) ([]*daily_track.HabitHeatmap, error)
+	UpdateDoneDailyTrack(ctx context.Context, userId string, targetDate string, targetHabitId string) (*daily_track.CompletionResult, error)
+	UndoDoneDailyTrack(ctx context.Context, userId string, targetDate string, targetHabitId string) (*daily_track.CompletionResult, error)
+	LogHabitAmount(ctx context.Context, userId string, targetDate string, targetHabitId string, amount float64) (*daily_track.CompletionResult, error)
 }
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"backend/internal/domain/model/feed"
+	"context"
+)
+
+type FeedService interface {
+	GetFeed(ctx context.Context, userId string, page int, limit int) (*feed.Feed, error)
+	AddReaction(ctx context.Context, userId string, itemId string, emoji feed.Emoji) (*feed.Item, error)
+	RemoveReaction(ctx context.Context, userId string, itemId string, emoji feed.Emoji) (*feed.Item, error)
+	AddComment(ctx context.Context, userId string, itemId string, body string) (*feed.Item, error)
+	DeleteComment(ctx context.Context, userId string, commentId string) error
+}
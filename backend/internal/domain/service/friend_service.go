@@ -0,0 +1,18 @@
+package service
+
+import (
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/friend"
+	"context"
+)
+
+type FriendService interface {
+	GetFriends(ctx context.Context, userId string) ([]*friend.Friend, error)
+	RequestFriend(ctx context.Context, userId string, username string) (*friend.Friend, error)
+	AcceptFriend(ctx context.Context, userId string, friendId string) (*friend.Friend, error)
+	RemoveFriend(ctx context.Context, userId string, friendId string) error
+	BlockUser(ctx context.Context, userId string, targetUserId string) error
+	UnblockUser(ctx context.Context, userId string, targetUserId string) error
+	ShareHabits(ctx context.Context, userId string, friendId string, habitIds []string) (*friend.Share, error)
+	GetFriendDailyTrack(ctx context.Context, userId string, friendId string, targetDate string) (*daily_track.DailyTrack, error)
+}
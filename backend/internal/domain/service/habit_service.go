@@ -6,7 +6,13 @@ import (
 )
 
 type HabitService interface {
-	GetHabitList(ctx context.Context, userId string) ([]*habit.Habit, error)
-	RegisterHabit(ctx context.Context, userId string, habitName string) (*habit.Habit, error)
-	DeleteHabit(ctx context.Context, userId string, habitId string) error
+	GetHabitList(ctx context.Context, userId string, includeArchived bool) ([]*habit.Habit, error)
+	RegisterHabit(ctx context.Context, userId string, habitName string, schedule habit.Schedule, measure *habit.Measure, scoring habit.Scoring) (*habit.Habit, error)
+	UpdateHabit(ctx context.Context, userId string, habitId string, habitName string, sortOrder *int, scoring *habit.Scoring) (*habit.Habit, error)
+	ReorderHabits(ctx context.Context, userId string, habitIds []string) error
+	PauseHabit(ctx context.Context, userId string, habitId string, pausedUntil string) (*habit.Habit, error)
+	ResumeHabit(ctx context.Context, userId string, habitId string) (*habit.Habit, error)
+	ArchiveHabit(ctx context.Context, userId string, habitId string) (*habit.Habit, error)
+	RestoreHabit(ctx context.Context, userId string, habitId string) (*habit.Habit, error)
+	PurgeHabit(ctx context.Context, userId string, habitId string) error
 }
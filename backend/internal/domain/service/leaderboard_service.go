@@ -0,0 +1,10 @@
+package service
+
+import (
+	"backend/internal/domain/model/ranking"
+	"context"
+)
+
+type LeaderboardService interface {
+	GetLeaderboard(ctx context.Context, userId string, board ranking.Board, scope ranking.Scope, page int, limit int) (*ranking.Leaderboard, error)
+}
@@ -0,0 +1,11 @@
+package service
+
+import (
+	"backend/internal/domain/model/notification"
+	"context"
+)
+
+// 通知の送信（送信方法ごとに実装する）
+type Notifier interface {
+	Notify(ctx context.Context, recipient *notification.Recipient, message *notification.Message) error
+}
@@ -0,0 +1,10 @@
+package service
+
+import (
+	"backend/internal/domain/model/point"
+	"context"
+)
+
+type PointService interface {
+	GetPointHistory(ctx context.Context, userId string, page int, limit int) (*point.History, error)
+}
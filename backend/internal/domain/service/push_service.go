@@ -0,0 +1,12 @@
+package service
+
+import (
+	"backend/internal/domain/model/notification"
+	"context"
+)
+
+type PushService interface {
+	GetPublicKey() (string, error)
+	Subscribe(ctx context.Context, userId string, subscription *notification.PushSubscription) (*notification.PushSubscription, error)
+	Unsubscribe(ctx context.Context, userId string, endpoint string) error
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"backend/internal/domain/model/reminder"
+	"context"
+	"time"
+)
+
+type ReminderService interface {
+	GetReminders(ctx context.Context, userId string, habitId string) ([]*reminder.Reminder, error)
+	UpdateReminders(ctx context.Context, userId string, habitId string, times []string) ([]*reminder.Reminder, error)
+	DispatchDueReminders(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"backend/internal/domain/model/reward"
+	"context"
+)
+
+type RewardService interface {
+	GetRewards(ctx context.Context, userId string) ([]*reward.Reward, error)
+	RegisterReward(ctx context.Context, userId string, name string, cost int, stock *int, cooldownHours int) (*reward.Reward, error)
+	UpdateReward(ctx context.Context, userId string, rewardId string, name string, cost int, stock *int, cooldownHours int) (*reward.Reward, error)
+	DeleteReward(ctx context.Context, userId string, rewardId string) error
+	RedeemReward(ctx context.Context, userId string, rewardId string) (*reward.Redemption, *reward.Reward, int, error)
+	GetRedemptionHistory(ctx context.Context, userId string, page int, limit int) (*reward.RedemptionHistory, error)
+}
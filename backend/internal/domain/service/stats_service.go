@@ -0,0 +1,10 @@
+package service
+
+import (
+	"backend/internal/domain/model/stats"
+	"context"
+)
+
+type StatsService interface {
+	GetStats(ctx context.Context, userId string, windowDays int, habitId string) (*stats.Stats, error)
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"backend/internal/domain/model/streak"
+	"context"
+)
+
+type StreakService interface {
+	GetStreaks(ctx context.Context, userId string) (map[string]*streak.Streak, error)
+	GetStreak(ctx context.Context, userId string, habitId string) (*streak.Streak, error)
+	PurchaseFreezeToken(ctx context.Context, userId string) (int, int, error)
+	UseFreeze(ctx context.Context, userId string, habitId string, targetDate string) (*streak.Streak, int, error)
+}
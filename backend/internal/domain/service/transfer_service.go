@@ -0,0 +1,12 @@
+package service
+
+import (
+	"backend/internal/domain/model/transfer"
+	"context"
+)
+
+type TransferService interface {
+	Export(ctx context.Context, userId string, exporter transfer.Exporter) error
+	Import(ctx context.Context, userId string, document *transfer.Document, mode transfer.Mode, dryRun bool) (*transfer.ImportResult, error)
+	ImportExternal(ctx context.Context, userId string, source transfer.Source, data []byte, mode transfer.Mode, dryRun bool) (*transfer.ImportResult, error)
+}
@@ -1,11 +1,24 @@
 package service
 
 import (
+	"backend/internal/domain/model/notification"
+	sessionModel "backend/internal/domain/model/session"
 	userModel "backend/internal/domain/model/user"
 	"context"
 )
 
 type UserService interface {
 	SignUp(ctx context.Context, userName string, password string) (*userModel.User, error)
-	Login(ctx context.Context, userName string, password string) (*userModel.User, string, error)
+	Login(ctx context.Context, userName string, password string) (*userModel.User, *sessionModel.TokenPair, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*sessionModel.TokenPair, error)
+	Logout(ctx context.Context, userId string, sessionId string) error
+	LogoutAll(ctx context.Context, userId string) error
+	ValidateSession(ctx context.Context, userId string, sessionId string) error
+	ChangePassword(ctx context.Context, userId string, sessionId string, currentPassword string, newPassword string) error
+	ChangeUsername(ctx context.Context, userId string, userName string) (*userModel.User, error)
+	DeleteAccount(ctx context.Context, userId string, password string) error
+	UpdateDaySettings(ctx context.Context, userId string, timezone string, dayStartHour int) (*userModel.User, error)
+	UpdateLeaderboardVisibility(ctx context.Context, userId string, hidden bool) (*userModel.User, error)
+	UpdateNotificationSettings(ctx context.Context, userId string, settings *notification.Settings) (*userModel.User, error)
+	GetProfile(ctx context.Context, userId string) (*userModel.User, error)
 }
@@ -0,0 +1,39 @@
+package handler
+
+// handler規約
+// フロントで表示するメッセージはここに定義
+// サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
+
+import (
+	"log"
+	"net/http"
+
+	"backend/internal/domain/service"
+	"backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AchievementHandler struct {
+	achievementService service.AchievementService
+}
+
+func NewAchievementHandler(achievementService service.AchievementService) *AchievementHandler {
+	return &AchievementHandler{
+		achievementService: achievementService,
+	}
+}
+
+// 獲得したバッジ一覧
+func (h *AchievementHandler) GetAchievements(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+
+	badges, err := h.achievementService.GetAchievements(c.Request.Context(), userId)
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, badges)
+}
@@ -0,0 +1,243 @@
+package handler
+
+// handler規約
+// フロントで表示するメッセージはここに定義
+// サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/service"
+	"backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChallengeHandler struct {
+	challengeService service.ChallengeService
+}
+
+func NewChallengeHandler(challengeService service.ChallengeService) *ChallengeHandler {
+	return &ChallengeHandler{
+		challengeService: challengeService,
+	}
+}
+
+// TODO: requestパッケージ作成
+type ChallengeRequest struct {
+	Name       string `json:"name" binding:"required"`
+	StartDate  string `json:"start_date" binding:"required"`
+	EndDate    string `json:"end_date" binding:"required"`
+	TargetDays int    `json:"target_days" binding:"required"`
+	HabitId    string `json:"habit_id" binding:"required"` // 自分が参加する習慣
+}
+
+// TODO: requestパッケージ作成
+type InviteChallengeRequest struct {
+	UserId string `json:"user_id" binding:"required"`
+}
+
+// TODO: requestパッケージ作成
+type JoinChallengeRequest struct {
+	HabitId string `json:"habit_id" binding:"required"`
+}
+
+func (h *ChallengeHandler) GetChallenges(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+
+	challenges, err := h.challengeService.GetChallenges(c.Request.Context(), userId)
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, challenges)
+}
+
+func (h *ChallengeHandler) CreateChallenge(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+
+	// バリデーション
+	var challengeRequest ChallengeRequest
+	if err := c.ShouldBindJSON(&challengeRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	createdChallenge, err := h.challengeService.CreateChallenge(c.Request.Context(), userId, challengeRequest.Name, challengeRequest.StartDate, challengeRequest.EndDate, challengeRequest.TargetDays, challengeRequest.HabitId)
+
+	if err != nil {
+		respondChallengeHabitError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "challenge": createdChallenge})
+}
+
+// フレンドをチャレンジに招待する
+func (h *ChallengeHandler) InviteParticipant(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetChallengeId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetChallengeId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	// バリデーション
+	var inviteChallengeRequest InviteChallengeRequest
+	if err := c.ShouldBindJSON(&inviteChallengeRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	invitedChallenge, err := h.challengeService.InviteParticipant(c.Request.Context(), userId, targetChallengeId, inviteChallengeRequest.UserId)
+
+	if err != nil {
+		if errors.Is(err, common.ErrNotFriends) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "フレンドのみ招待できます。"})
+			return
+		}
+		if errors.Is(err, common.ErrAlreadyExists) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "すでに招待済みのユーザーです。"})
+			return
+		}
+		respondChallengeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "challenge": invitedChallenge})
+}
+
+// 招待されたチャレンジに参加する（参加中の場合は習慣の変更）
+func (h *ChallengeHandler) JoinChallenge(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetChallengeId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetChallengeId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	// バリデーション
+	var joinChallengeRequest JoinChallengeRequest
+	if err := c.ShouldBindJSON(&joinChallengeRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	joinedChallenge, err := h.challengeService.JoinChallenge(c.Request.Context(), userId, targetChallengeId, joinChallengeRequest.HabitId)
+
+	if err != nil {
+		respondChallengeHabitError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "challenge": joinedChallenge})
+}
+
+// チャレンジからの退出（招待の辞退を含む）
+func (h *ChallengeHandler) LeaveChallenge(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetChallengeId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetChallengeId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	err := h.challengeService.LeaveChallenge(c.Request.Context(), userId, targetChallengeId)
+
+	if err != nil {
+		respondChallengeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success"})
+}
+
+func (h *ChallengeHandler) DeleteChallenge(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetChallengeId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetChallengeId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	err := h.challengeService.DeleteChallenge(c.Request.Context(), userId, targetChallengeId)
+
+	if err != nil {
+		respondChallengeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success"})
+}
+
+// 参加者ごとの進捗
+func (h *ChallengeHandler) GetChallengeBoard(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetChallengeId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetChallengeId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	board, err := h.challengeService.GetChallengeBoard(c.Request.Context(), userId, targetChallengeId)
+
+	if err != nil {
+		respondChallengeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+// 習慣を指定する操作（作成・参加）のエラーレスポンス
+// NOTE: 習慣が見つからない場合もErrNotFoundになるため、チャレンジより先に習慣として扱う
+func respondChallengeHabitError(c *gin.Context, err error) {
+	if errors.Is(err, common.ErrInvalidChallenge) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "チャレンジの内容が不正です。"})
+		return
+	}
+	if errors.Is(err, common.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "対象のチャレンジまたは習慣が見つかりません。"})
+		return
+	}
+	if errors.Is(err, common.ErrForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "このチャレンジまたは習慣を操作する権限がありません。"})
+		return
+	}
+
+	log.Printf("[ERROR] %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+}
+
+func respondChallengeError(c *gin.Context, err error) {
+	if errors.Is(err, common.ErrInvalidChallenge) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "現在の状態では操作できません。"})
+		return
+	}
+	if errors.Is(err, common.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "対象のチャレンジが見つかりません。"})
+		return
+	}
+	if errors.Is(err, common.ErrForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "このチャレンジを操作する権限がありません。"})
+		return
+	}
+
+	log.Printf("[ERROR] %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+}
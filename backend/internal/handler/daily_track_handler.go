@@ -5,9 +5,11 @@ package handler
 // サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
+	"backend/internal/domain/common"
 	"backend/internal/domain/service"
 	"backend/internal/utils"
 
@@ -36,6 +38,15 @@ func (h *DailyTrackHandler) GetDailyTrack(c *gin.Context) {
 	userId := utils.GetUserIdFromContext(c)
 	todaysTrack, err := h.dailyTrackService.GetDailyTrack(c.Request.Context(), userId, dateParam)
 	if err != nil {
+		if errors.Is(err, common.ErrInvalidDate) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "日付の形式が不正です。"})
+			return
+		}
+		if errors.Is(err, common.ErrDateOutOfRange) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "指定できない日付です。"})
+			return
+		}
+
 		log.Printf("[ERROR] %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
 		return
@@ -44,6 +55,58 @@ func (h *DailyTrackHandler) GetDailyTrack(c *gin.Context) {
 	c.JSON(http.StatusOK, todaysTrack)
 }
 
+func (h *DailyTrackHandler) GetDailyTracks(c *gin.Context) {
+	fromParam := c.Query("from")
+	toParam := c.Query("to")
+
+	// 期間が指定されていない場合のチェック
+	if fromParam == "" || toParam == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "期間（from, to）を指定してください。"})
+		return
+	}
+
+	userId := utils.GetUserIdFromContext(c)
+
+	// format=heatmap の場合は習慣ごとの日別の達成状況のみを返す
+	if c.Query("format") == "heatmap" {
+		heatmaps, err := h.dailyTrackService.GetHeatmaps(c.Request.Context(), userId, fromParam, toParam)
+		if err != nil {
+			respondDateRangeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"from": fromParam, "to": toParam, "heatmaps": heatmaps})
+		return
+	}
+
+	dailyTracks, err := h.dailyTrackService.GetDailyTracks(c.Request.Context(), userId, fromParam, toParam)
+	if err != nil {
+		respondDateRangeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"from": fromParam, "to": toParam, "daily_tracks": dailyTracks})
+}
+
+// 期間指定の取得時のエラーレスポンス
+func respondDateRangeError(c *gin.Context, err error) {
+	if errors.Is(err, common.ErrInvalidDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "日付の形式が不正です。"})
+		return
+	}
+	if errors.Is(err, common.ErrDateOutOfRange) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "指定できない日付です。"})
+		return
+	}
+	if errors.Is(err, common.ErrInvalidDateRange) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "期間の指定が不正です。"})
+		return
+	}
+
+	log.Printf("[ERROR] %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+}
+
 // TODO: requestパッケージ作成
 type updateDoneDailyTrackRequest struct {
 	Date    string `json:"date"   binding:"required"`
@@ -61,14 +124,107 @@ func (h *DailyTrackHandler) UpdateDoneDailyTrack(c *gin.Context) {
 	// ユーザーID取得
 	userId := utils.GetUserIdFromContext(c)
 
-	// todaysTrack 取得
-	err := h.dailyTrackService.UpdateDoneDailyTrack(c.Request.Context(), userId, updateDoneDailyTrackRequest.Date, updateDoneDailyTrackRequest.HabitId)
+	// 完了にする
+	result, err := h.dailyTrackService.UpdateDoneDailyTrack(c.Request.Context(), userId, updateDoneDailyTrackRequest.Date, updateDoneDailyTrackRequest.HabitId)
 
 	if err != nil {
+		respondUpdateDoneError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "daily_track": result.DailyTrack, "points": result.Points, "achievements": result.Achievements, "challenges": result.Challenges, "level": result.Level, "level_up": result.LevelUp})
+}
+
+func (h *DailyTrackHandler) UndoDoneDailyTrack(c *gin.Context) {
+	// バリデーション
+	var updateDoneDailyTrackRequest updateDoneDailyTrackRequest
+	if err := c.ShouldBindJSON(&updateDoneDailyTrackRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	// ユーザーID取得
+	userId := utils.GetUserIdFromContext(c)
+
+	// 未完了に戻す
+	result, err := h.dailyTrackService.UndoDoneDailyTrack(c.Request.Context(), userId, updateDoneDailyTrackRequest.Date, updateDoneDailyTrackRequest.HabitId)
+
+	if err != nil {
+		respondUpdateDoneError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "daily_track": result.DailyTrack, "points": result.Points, "level": result.Level})
+}
+
+// 完了状態の更新時のエラーレスポンス
+func respondUpdateDoneError(c *gin.Context, err error) {
+	if respondHabitAccessError(c, err) {
+		return
+	}
+	if errors.Is(err, common.ErrInvalidDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "日付の形式が不正です。"})
+		return
+	}
+	if errors.Is(err, common.ErrDateOutOfRange) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "指定できない日付です。"})
+		return
+	}
+	if errors.Is(err, common.ErrAmountRequired) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "量を記録する習慣は、記録した量で完了になります。"})
+		return
+	}
+
+	log.Printf("[ERROR] %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+}
+
+// TODO: requestパッケージ作成
+type logHabitAmountRequest struct {
+	Date    string  `json:"date"     binding:"required"`
+	HabitId string  `json:"habit_id" binding:"required"`
+	Amount  float64 `json:"amount"   binding:"required"`
+}
+
+func (h *DailyTrackHandler) LogHabitAmount(c *gin.Context) {
+	// バリデーション
+	var logHabitAmountRequest logHabitAmountRequest
+	if err := c.ShouldBindJSON(&logHabitAmountRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	// ユーザーID取得
+	userId := utils.GetUserIdFromContext(c)
+
+	// 記録
+	result, err := h.dailyTrackService.LogHabitAmount(c.Request.Context(), userId, logHabitAmountRequest.Date, logHabitAmountRequest.HabitId, logHabitAmountRequest.Amount)
+
+	if err != nil {
+		if respondHabitAccessError(c, err) {
+			return
+		}
+		if errors.Is(err, common.ErrNotMeasurable) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "量を記録できない習慣です。"})
+			return
+		}
+		if errors.Is(err, common.ErrInvalidAmount) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "記録する量は0より大きい値を指定してください。"})
+			return
+		}
+		if errors.Is(err, common.ErrInvalidDate) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "日付の形式が不正です。"})
+			return
+		}
+		if errors.Is(err, common.ErrDateOutOfRange) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "指定できない日付です。"})
+			return
+		}
+
 		log.Printf("[ERROR] %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "success"})
+	c.JSON(http.StatusOK, gin.H{"message": "success", "daily_track": result.DailyTrack, "points": result.Points, "achievements": result.Achievements, "challenges": result.Challenges, "level": result.Level, "level_up": result.LevelUp})
 }
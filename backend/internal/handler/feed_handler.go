@@ -0,0 +1,202 @@
+package handler
+
+// handler規約
+// フロントで表示するメッセージはここに定義
+// サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/feed"
+	"backend/internal/domain/service"
+	"backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// フィードの1ページあたりの件数（デフォルト・上限）
+	defaultFeedLimit = 20
+	maxFeedLimit     = 50
+)
+
+type FeedHandler struct {
+	feedService service.FeedService
+}
+
+func NewFeedHandler(feedService service.FeedService) *FeedHandler {
+	return &FeedHandler{
+		feedService: feedService,
+	}
+}
+
+// TODO: requestパッケージ作成
+type ReactionRequest struct {
+	Emoji string `json:"emoji" binding:"required"`
+}
+
+// TODO: requestパッケージ作成
+type CommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+func (h *FeedHandler) GetFeed(c *gin.Context) {
+	// バリデーション
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "ページの指定が不正です。"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultFeedLimit)))
+	if err != nil || limit < 1 || limit > maxFeedLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "件数の指定が不正です。"})
+		return
+	}
+
+	userId := utils.GetUserIdFromContext(c)
+	result, err := h.feedService.GetFeed(c.Request.Context(), userId, page, limit)
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *FeedHandler) AddReaction(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetItemId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetItemId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	// バリデーション
+	var reactionRequest ReactionRequest
+	if err := c.ShouldBindJSON(&reactionRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+	emoji, err := feed.ParseEmoji(reactionRequest.Emoji)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "リアクションの種類が不正です。"})
+		return
+	}
+
+	item, err := h.feedService.AddReaction(c.Request.Context(), userId, targetItemId, emoji)
+
+	if err != nil {
+		respondFeedError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "item": item})
+}
+
+func (h *FeedHandler) RemoveReaction(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetItemId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetItemId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	// バリデーション
+	emoji, err := feed.ParseEmoji(c.Param("emoji"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "リアクションの種類が不正です。"})
+		return
+	}
+
+	item, err := h.feedService.RemoveReaction(c.Request.Context(), userId, targetItemId, emoji)
+
+	if err != nil {
+		respondFeedError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "item": item})
+}
+
+func (h *FeedHandler) AddComment(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetItemId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetItemId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	// バリデーション
+	var commentRequest CommentRequest
+	if err := c.ShouldBindJSON(&commentRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	item, err := h.feedService.AddComment(c.Request.Context(), userId, targetItemId, commentRequest.Body)
+
+	if err != nil {
+		respondFeedError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "item": item})
+}
+
+// コメントの削除（コメントしたユーザーと、フィードの持ち主のみ）
+func (h *FeedHandler) DeleteComment(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetCommentId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetCommentId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	err := h.feedService.DeleteComment(c.Request.Context(), userId, targetCommentId)
+
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": "対象のコメントが見つかりません。"})
+			return
+		}
+		respondFeedError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success"})
+}
+
+func respondFeedError(c *gin.Context, err error) {
+	if errors.Is(err, common.ErrInvalidCheer) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "コメントは1〜140文字で入力してください。"})
+		return
+	}
+	if errors.Is(err, common.ErrTooManyCheers) {
+		c.JSON(http.StatusTooManyRequests, gin.H{"message": "応援の送信が多すぎます。しばらく待ってから再度お試しください。"})
+		return
+	}
+	if errors.Is(err, common.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "対象の投稿が見つかりません。"})
+		return
+	}
+	if errors.Is(err, common.ErrForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "このコメントを削除する権限がありません。"})
+		return
+	}
+
+	log.Printf("[ERROR] %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+}
@@ -0,0 +1,212 @@
+package handler
+
+// handler規約
+// フロントで表示するメッセージはここに定義
+// サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/service"
+	"backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FriendHandler struct {
+	friendService service.FriendService
+}
+
+func NewFriendHandler(friendService service.FriendService) *FriendHandler {
+	return &FriendHandler{
+		friendService: friendService,
+	}
+}
+
+// TODO: requestパッケージ作成
+type FriendRequest struct {
+	Username string `json:"username" binding:"required"`
+}
+
+// TODO: requestパッケージ作成
+type ShareHabitsRequest struct {
+	HabitIds []string `json:"habit_ids"` // 空の場合は全て非公開
+}
+
+func (h *FriendHandler) GetFriends(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+
+	friends, err := h.friendService.GetFriends(c.Request.Context(), userId)
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, friends)
+}
+
+// フレンド申請（相手から申請が届いている場合は承認）
+func (h *FriendHandler) RequestFriend(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+
+	// バリデーション
+	var friendRequest FriendRequest
+	if err := c.ShouldBindJSON(&friendRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	result, err := h.friendService.RequestFriend(c.Request.Context(), userId, friendRequest.Username)
+
+	if err != nil {
+		if errors.Is(err, common.ErrAlreadyExists) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "すでに申請済み、またはフレンドです。"})
+			return
+		}
+		respondFriendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "friend": result})
+}
+
+func (h *FriendHandler) AcceptFriend(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	friendId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if friendId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	result, err := h.friendService.AcceptFriend(c.Request.Context(), userId, friendId)
+
+	if err != nil {
+		respondFriendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "friend": result})
+}
+
+// フレンド解除（申請の取り消し・拒否を含む）
+func (h *FriendHandler) RemoveFriend(c *gin.Context) {
+	h.changeFriendship(c, h.friendService.RemoveFriend)
+}
+
+func (h *FriendHandler) BlockUser(c *gin.Context) {
+	h.changeFriendship(c, h.friendService.BlockUser)
+}
+
+func (h *FriendHandler) UnblockUser(c *gin.Context) {
+	h.changeFriendship(c, h.friendService.UnblockUser)
+}
+
+// フレンドに公開する習慣の設定
+func (h *FriendHandler) ShareHabits(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	friendId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if friendId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	// バリデーション
+	var shareHabitsRequest ShareHabitsRequest
+	if err := c.ShouldBindJSON(&shareHabitsRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	share, err := h.friendService.ShareHabits(c.Request.Context(), userId, friendId, shareHabitsRequest.HabitIds)
+
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": "対象の習慣が見つかりません。"})
+			return
+		}
+		if errors.Is(err, common.ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"message": "この習慣を操作する権限がありません。"})
+			return
+		}
+		respondFriendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "share": share})
+}
+
+// フレンドの指定日の記録（公開されている習慣のみ）
+func (h *FriendHandler) GetFriendDailyTrack(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	friendId := c.Param("id")
+	targetDate := c.Param("date")
+
+	// idが空文字列の場合のチェック
+	if friendId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	dailyTrack, err := h.friendService.GetFriendDailyTrack(c.Request.Context(), userId, friendId, targetDate)
+
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidDate) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "日付の指定が不正です。"})
+			return
+		}
+		if errors.Is(err, common.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": "指定日の記録がありません。"})
+			return
+		}
+		respondFriendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dailyTrack)
+}
+
+// フレンド関係の変更（リクエストボディなし）の共通処理
+func (h *FriendHandler) changeFriendship(c *gin.Context, change func(ctx context.Context, userId string, targetUserId string) error) {
+	userId := utils.GetUserIdFromContext(c)
+	targetUserId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetUserId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	if err := change(c.Request.Context(), userId, targetUserId); err != nil {
+		respondFriendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success"})
+}
+
+func respondFriendError(c *gin.Context, err error) {
+	if errors.Is(err, common.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "対象のユーザーが見つかりません。"})
+		return
+	}
+	if errors.Is(err, common.ErrInvalidFriendRequest) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "現在の状態では操作できません。"})
+		return
+	}
+	if errors.Is(err, common.ErrNotFriends) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "フレンドではありません。"})
+		return
+	}
+
+	log.Printf("[ERROR] %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+}
@@ -5,11 +5,14 @@ package handler
 // サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
 
 import (
+	"context"
 	"errors"
 	"log"
 	"net/http"
 
 	"backend/internal/domain/common"
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/streak"
 	"backend/internal/domain/service"
 	"backend/internal/utils"
 
@@ -17,19 +20,46 @@ import (
 )
 
 type HabitHandler struct {
-	habitService service.HabitService
+	habitService  service.HabitService
+	streakService service.StreakService
 }
 
-func NewHabitHandler(habitService service.HabitService) *HabitHandler {
+func NewHabitHandler(habitService service.HabitService, streakService service.StreakService) *HabitHandler {
 	return &HabitHandler{
-		habitService: habitService,
+		habitService:  habitService,
+		streakService: streakService,
 	}
 }
 
 // TODO: requestパッケージ作成
 type HabitRequest struct {
-	Id   string `json:"id"   binding:"required"`
-	Name string `json:"name" binding:"required"`
+	Name     string          `json:"name" binding:"required"`
+	Schedule *habit.Schedule `json:"schedule"`
+	Measure  *habit.Measure  `json:"measure"`
+	Scoring  *habit.Scoring  `json:"scoring"`
+}
+
+// TODO: requestパッケージ作成
+type UpdateHabitRequest struct {
+	Name      string         `json:"name" binding:"required"`
+	SortOrder *int           `json:"sort_order"`
+	Scoring   *habit.Scoring `json:"scoring"`
+}
+
+// TODO: requestパッケージ作成
+type ReorderHabitsRequest struct {
+	HabitIds []string `json:"habit_ids" binding:"required"`
+}
+
+// TODO: requestパッケージ作成
+type PauseHabitRequest struct {
+	Until string `json:"until" binding:"required"` // 再開日 YYYY-MM-DD
+}
+
+// 習慣一覧のレスポンス（習慣に連続達成数を付与）
+type habitListItem struct {
+	*habit.Habit
+	Streak *streak.Streak `json:"streak"`
 }
 
 // メモ
@@ -38,7 +68,19 @@ type HabitRequest struct {
 func (h *HabitHandler) GetHabitList(c *gin.Context) {
 
 	userId := utils.GetUserIdFromContext(c)
-	habits, err := h.habitService.GetHabitList(c.Request.Context(), userId)
+
+	// アーカイブ済みの習慣を含めるか
+	includeArchived := c.Query("include_archived") == "true"
+
+	habits, err := h.habitService.GetHabitList(c.Request.Context(), userId, includeArchived)
+
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	streaks, err := h.streakService.GetStreaks(c.Request.Context(), userId)
 
 	if err != nil {
 		log.Printf("[ERROR] %v", err)
@@ -46,7 +88,12 @@ func (h *HabitHandler) GetHabitList(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, habits)
+	habitList := make([]*habitListItem, 0, len(habits))
+	for _, targetHabit := range habits {
+		habitList = append(habitList, &habitListItem{Habit: targetHabit, Streak: streaks[targetHabit.Id]})
+	}
+
+	c.JSON(http.StatusOK, habitList)
 }
 
 func (h *HabitHandler) RegisterHabit(c *gin.Context) {
@@ -59,23 +106,157 @@ func (h *HabitHandler) RegisterHabit(c *gin.Context) {
 		return
 	}
 
-	habit, err := h.habitService.RegisterHabit(c.Request.Context(), userId, habitRequest.Name)
+	// スケジュール未指定の場合は毎日
+	schedule := habit.DefaultSchedule()
+	if habitRequest.Schedule != nil {
+		schedule = *habitRequest.Schedule
+	}
+
+	// 難易度未指定の場合は普通
+	scoring := habit.DefaultScoring()
+	if habitRequest.Scoring != nil {
+		scoring = *habitRequest.Scoring
+	}
+
+	registeredHabit, err := h.habitService.RegisterHabit(c.Request.Context(), userId, habitRequest.Name, schedule, habitRequest.Measure, scoring)
 
 	if err != nil {
 		if errors.Is(err, common.ErrAlreadyExists) {
 			c.JSON(http.StatusBadRequest, gin.H{"message": "すでに登録済みの習慣です。"})
 			return
 		}
+		if errors.Is(err, common.ErrInvalidSchedule) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "スケジュールの指定が不正です。"})
+			return
+		}
+		if errors.Is(err, common.ErrInvalidMeasure) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "目標量の指定が不正です。"})
+			return
+		}
+		if errors.Is(err, common.ErrInvalidScoring) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "難易度の指定が不正です。"})
+			return
+		}
 
 		log.Printf("[ERROR] %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "success", "id": habit.Id})
+	c.JSON(http.StatusOK, gin.H{"message": "success", "id": registeredHabit.Id})
 }
 
-func (h *HabitHandler) DeleteHabit(c *gin.Context) {
+func (h *HabitHandler) UpdateHabit(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetHabitId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetHabitId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	// バリデーション
+	var updateHabitRequest UpdateHabitRequest
+	if err := c.ShouldBindJSON(&updateHabitRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	// 更新
+	updatedHabit, err := h.habitService.UpdateHabit(c.Request.Context(), userId, targetHabitId, updateHabitRequest.Name, updateHabitRequest.SortOrder, updateHabitRequest.Scoring)
+
+	if err != nil {
+		if respondHabitAccessError(c, err) {
+			return
+		}
+		if errors.Is(err, common.ErrAlreadyExists) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "すでに登録済みの習慣です。"})
+			return
+		}
+		if errors.Is(err, common.ErrInvalidScoring) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "難易度の指定が不正です。"})
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "habit": updatedHabit})
+}
+
+func (h *HabitHandler) ReorderHabits(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+
+	// バリデーション
+	var reorderHabitsRequest ReorderHabitsRequest
+	if err := c.ShouldBindJSON(&reorderHabitsRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	// 並び替え
+	err := h.habitService.ReorderHabits(c.Request.Context(), userId, reorderHabitsRequest.HabitIds)
+
+	if err != nil {
+		if respondHabitAccessError(c, err) {
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success"})
+}
+
+func (h *HabitHandler) PauseHabit(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetHabitId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetHabitId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	// バリデーション
+	var pauseHabitRequest PauseHabitRequest
+	if err := c.ShouldBindJSON(&pauseHabitRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	// 一時停止
+	pausedHabit, err := h.habitService.PauseHabit(c.Request.Context(), userId, targetHabitId, pauseHabitRequest.Until)
+
+	if err != nil {
+		respondHabitStatusError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "habit": pausedHabit})
+}
+
+func (h *HabitHandler) ResumeHabit(c *gin.Context) {
+	h.changeHabitStatus(c, h.habitService.ResumeHabit)
+}
+
+// 習慣のアーカイブ（履歴は残す）
+// NOTE: 既存の DELETE /habit/:id/delete もアーカイブとして扱う
+func (h *HabitHandler) ArchiveHabit(c *gin.Context) {
+	h.changeHabitStatus(c, h.habitService.ArchiveHabit)
+}
+
+func (h *HabitHandler) RestoreHabit(c *gin.Context) {
+	h.changeHabitStatus(c, h.habitService.RestoreHabit)
+}
+
+// 習慣の完全削除（履歴も削除）
+func (h *HabitHandler) PurgeHabit(c *gin.Context) {
 	userId := utils.GetUserIdFromContext(c)
 	targetHabitId := c.Param("id")
 
@@ -85,9 +266,13 @@ func (h *HabitHandler) DeleteHabit(c *gin.Context) {
 		return
 	}
 	// 削除
-	err := h.habitService.DeleteHabit(c.Request.Context(), userId, targetHabitId)
+	err := h.habitService.PurgeHabit(c.Request.Context(), userId, targetHabitId)
 
 	if err != nil {
+		if respondHabitAccessError(c, err) {
+			return
+		}
+
 		log.Printf("[ERROR] %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
 		return
@@ -95,3 +280,45 @@ func (h *HabitHandler) DeleteHabit(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "success"})
 }
+
+// 習慣の状態変更（リクエストボディなし）の共通処理
+func (h *HabitHandler) changeHabitStatus(c *gin.Context, change func(ctx context.Context, userId string, habitId string) (*habit.Habit, error)) {
+	userId := utils.GetUserIdFromContext(c)
+	targetHabitId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetHabitId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	changedHabit, err := change(c.Request.Context(), userId, targetHabitId)
+
+	if err != nil {
+		respondHabitStatusError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "habit": changedHabit})
+}
+
+func respondHabitStatusError(c *gin.Context, err error) {
+	if respondHabitAccessError(c, err) {
+		return
+	}
+	if errors.Is(err, common.ErrInvalidHabitStatus) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "現在の状態では変更できません。"})
+		return
+	}
+	if errors.Is(err, common.ErrInvalidDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "再開日の指定が不正です。"})
+		return
+	}
+	if errors.Is(err, common.ErrAlreadyExists) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "同じ名前の習慣がすでに登録されています。"})
+		return
+	}
+
+	log.Printf("[ERROR] %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+}
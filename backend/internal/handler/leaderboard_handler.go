@@ -0,0 +1,67 @@
+package handler
+
+// handler規約
+// フロントで表示するメッセージはここに定義
+// サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"backend/internal/domain/model/ranking"
+	"backend/internal/domain/service"
+	"backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// ランキングの1ページあたりの件数（デフォルト・上限）
+	defaultLeaderboardLimit = 20
+	maxLeaderboardLimit     = 100
+)
+
+type LeaderboardHandler struct {
+	leaderboardService service.LeaderboardService
+}
+
+func NewLeaderboardHandler(leaderboardService service.LeaderboardService) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		leaderboardService: leaderboardService,
+	}
+}
+
+func (h *LeaderboardHandler) GetLeaderboard(c *gin.Context) {
+	// バリデーション
+	board, err := ranking.ParseBoard(c.Param("board"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "ランキングの種類が不正です。"})
+		return
+	}
+	scope, err := ranking.ParseScope(c.Query("scope"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "ランキングの対象が不正です。"})
+		return
+	}
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "ページの指定が不正です。"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultLeaderboardLimit)))
+	if err != nil || limit < 1 || limit > maxLeaderboardLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "件数の指定が不正です。"})
+		return
+	}
+
+	userId := utils.GetUserIdFromContext(c)
+	leaderboard, err := h.leaderboardService.GetLeaderboard(c.Request.Context(), userId, board, scope, page, limit)
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, leaderboard)
+}
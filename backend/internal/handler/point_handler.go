@@ -0,0 +1,56 @@
+package handler
+
+// handler規約
+// フロントで表示するメッセージはここに定義
+// サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"backend/internal/domain/service"
+	"backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// ポイント履歴の1ページあたりの件数（デフォルト・上限）
+	defaultPointHistoryLimit = 20
+	maxPointHistoryLimit     = 100
+)
+
+type PointHandler struct {
+	pointService service.PointService
+}
+
+func NewPointHandler(pointService service.PointService) *PointHandler {
+	return &PointHandler{
+		pointService: pointService,
+	}
+}
+
+func (h *PointHandler) GetPointHistory(c *gin.Context) {
+	// バリデーション
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "ページの指定が不正です。"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultPointHistoryLimit)))
+	if err != nil || limit < 1 || limit > maxPointHistoryLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "件数の指定が不正です。"})
+		return
+	}
+
+	userId := utils.GetUserIdFromContext(c)
+	history, err := h.pointService.GetPointHistory(c.Request.Context(), userId, page, limit)
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
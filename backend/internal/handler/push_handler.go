@@ -0,0 +1,117 @@
+package handler
+
+// handler規約
+// フロントで表示するメッセージはここに定義
+// サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/notification"
+	"backend/internal/domain/service"
+	"backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PushHandler struct {
+	pushService service.PushService
+}
+
+func NewPushHandler(pushService service.PushService) *PushHandler {
+	return &PushHandler{
+		pushService: pushService,
+	}
+}
+
+// TODO: requestパッケージ作成
+// NOTE: ブラウザのPushSubscription.toJSON()の形式
+type PushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint" binding:"required"`
+	Keys     struct {
+		P256dh string `json:"p256dh" binding:"required"`
+		Auth   string `json:"auth" binding:"required"`
+	} `json:"keys"`
+}
+
+type PushUnsubscribeRequest struct {
+	Endpoint string `json:"endpoint" binding:"required"`
+}
+
+// ブラウザで購読する際に使う公開鍵（applicationServerKey）
+func (h *PushHandler) GetPublicKey(c *gin.Context) {
+	publicKey, err := h.pushService.GetPublicKey()
+
+	if err != nil {
+		respondPushError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"public_key": publicKey})
+}
+
+func (h *PushHandler) Subscribe(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+
+	// バリデーション
+	var subscriptionRequest PushSubscriptionRequest
+	if err := c.ShouldBindJSON(&subscriptionRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	subscription := &notification.PushSubscription{
+		Endpoint: subscriptionRequest.Endpoint,
+		P256dh:   subscriptionRequest.Keys.P256dh,
+		Auth:     subscriptionRequest.Keys.Auth,
+	}
+	result, err := h.pushService.Subscribe(c.Request.Context(), userId, subscription)
+
+	if err != nil {
+		respondPushError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "subscription": result})
+}
+
+func (h *PushHandler) Unsubscribe(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+
+	// バリデーション
+	var unsubscribeRequest PushUnsubscribeRequest
+	if err := c.ShouldBindJSON(&unsubscribeRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	err := h.pushService.Unsubscribe(c.Request.Context(), userId, unsubscribeRequest.Endpoint)
+
+	if err != nil {
+		respondPushError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success"})
+}
+
+func respondPushError(c *gin.Context, err error) {
+	if errors.Is(err, common.ErrPushNotConfigured) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"message": "ブラウザ通知は現在利用できません。"})
+		return
+	}
+	if errors.Is(err, common.ErrInvalidPushSubscription) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "ブラウザ通知の購読情報が不正です。"})
+		return
+	}
+	if errors.Is(err, common.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "ブラウザ通知の購読が見つかりません。"})
+		return
+	}
+
+	log.Printf("[ERROR] %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+}
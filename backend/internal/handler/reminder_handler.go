@@ -0,0 +1,93 @@
+package handler
+
+// handler規約
+// フロントで表示するメッセージはここに定義
+// サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/service"
+	"backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderHandler struct {
+	reminderService service.ReminderService
+}
+
+func NewReminderHandler(reminderService service.ReminderService) *ReminderHandler {
+	return &ReminderHandler{
+		reminderService: reminderService,
+	}
+}
+
+// TODO: requestパッケージ作成
+type RemindersRequest struct {
+	Times []string `json:"times"` // HH:MM（空の場合は全て削除）
+}
+
+func (h *ReminderHandler) GetReminders(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetHabitId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetHabitId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	reminders, err := h.reminderService.GetReminders(c.Request.Context(), userId, targetHabitId)
+
+	if err != nil {
+		respondReminderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reminders)
+}
+
+// 習慣のリマインダーの時刻を置き換える
+func (h *ReminderHandler) UpdateReminders(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetHabitId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetHabitId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	// バリデーション
+	var remindersRequest RemindersRequest
+	if err := c.ShouldBindJSON(&remindersRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	reminders, err := h.reminderService.UpdateReminders(c.Request.Context(), userId, targetHabitId, remindersRequest.Times)
+
+	if err != nil {
+		respondReminderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "reminders": reminders})
+}
+
+func respondReminderError(c *gin.Context, err error) {
+	if errors.Is(err, common.ErrInvalidReminder) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "リマインダーの時刻が不正です（HH:MM形式で5件まで）。"})
+		return
+	}
+	if respondHabitAccessError(c, err) {
+		return
+	}
+
+	log.Printf("[ERROR] %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+}
@@ -0,0 +1,29 @@
+package handler
+
+// handler規約
+// フロントで表示するメッセージはここに定義
+// サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/internal/domain/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 習慣の所有者チェックのエラーをレスポンスに変換する
+// 変換した場合はtrueを返す（呼び出し元はそのままreturnする）
+// NOTE: 他のユーザーの習慣は存在しない場合と同じくErrNotFound（404）になる
+func respondHabitAccessError(c *gin.Context, err error) bool {
+	if errors.Is(err, common.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "対象の習慣が見つかりません。"})
+		return true
+	}
+	if errors.Is(err, common.ErrForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "この習慣を操作する権限がありません。"})
+		return true
+	}
+	return false
+}
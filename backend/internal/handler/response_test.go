@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/streak"
+	"backend/internal/domain/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NOTE: 以下のfakeはインターフェースを埋め込み、テストで使用するメソッドのみ実装する
+
+type fakeHabitService struct {
+	service.HabitService
+	err error
+}
+
+func (s *fakeHabitService) UpdateHabit(ctx context.Context, userId string, habitId string, habitName string, sortOrder *int, scoring *habit.Scoring) (*habit.Habit, error) {
+	return nil, s.err
+}
+
+func (s *fakeHabitService) PauseHabit(ctx context.Context, userId string, habitId string, pausedUntil string) (*habit.Habit, error) {
+	return nil, s.err
+}
+
+func (s *fakeHabitService) ArchiveHabit(ctx context.Context, userId string, habitId string) (*habit.Habit, error) {
+	return nil, s.err
+}
+
+func (s *fakeHabitService) PurgeHabit(ctx context.Context, userId string, habitId string) error {
+	return s.err
+}
+
+type fakeDailyTrackService struct {
+	service.DailyTrackService
+	err error
+}
+
+func (s *fakeDailyTrackService) UpdateDoneDailyTrack(ctx context.Context, userId string, targetDate string, targetHabitId string) (*daily_track.CompletionResult, error) {
+	return nil, s.err
+}
+
+func (s *fakeDailyTrackService) UndoDoneDailyTrack(ctx context.Context, userId string, targetDate string, targetHabitId string) (*daily_track.CompletionResult, error) {
+	return nil, s.err
+}
+
+func (s *fakeDailyTrackService) LogHabitAmount(ctx context.Context, userId string, targetDate string, targetHabitId string, amount float64) (*daily_track.CompletionResult, error) {
+	return nil, s.err
+}
+
+type fakeStreakService struct {
+	service.StreakService
+	err error
+}
+
+func (s *fakeStreakService) GetStreak(ctx context.Context, userId string, habitId string) (*streak.Streak, error) {
+	return nil, s.err
+}
+
+func (s *fakeStreakService) UseFreeze(ctx context.Context, userId string, habitId string, targetDate string) (*streak.Streak, int, error) {
+	return nil, 0, s.err
+}
+
+func TestRespondHabitAccessError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantHandled bool
+		wantStatus  int
+	}{
+		{"not found", common.ErrNotFound, true, http.StatusNotFound},
+		{"wrapped not found", fmt.Errorf("failed to find habit: %w", common.ErrNotFound), true, http.StatusNotFound},
+		{"forbidden", common.ErrForbidden, true, http.StatusForbidden},
+		{"other", errors.New("unexpected"), false, http.StatusOK},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+
+			if handled := respondHabitAccessError(c, tt.err); handled != tt.wantHandled {
+				t.Fatalf("handled = %v, want %v", handled, tt.wantHandled)
+			}
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// 習慣を指定するエンドポイントが、所有者チェックのエラーを404・403に変換する
+func TestHabitAccessErrorResponses(t *testing.T) {
+	endpoints := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		route  func(router *gin.Engine, err error)
+	}{
+		{"UpdateHabit", http.MethodPut, "/habit/h1", `{"name":"読書"}`, func(router *gin.Engine, err error) {
+			router.PUT("/habit/:id", NewHabitHandler(&fakeHabitService{err: err}, nil).UpdateHabit)
+		}},
+		{"PauseHabit", http.MethodPost, "/habit/h1/pause", `{"until":"2999-01-01"}`, func(router *gin.Engine, err error) {
+			router.POST("/habit/:id/pause", NewHabitHandler(&fakeHabitService{err: err}, nil).PauseHabit)
+		}},
+		{"ArchiveHabit", http.MethodPost, "/habit/h1/archive", "", func(router *gin.Engine, err error) {
+			router.POST("/habit/:id/archive", NewHabitHandler(&fakeHabitService{err: err}, nil).ArchiveHabit)
+		}},
+		{"PurgeHabit", http.MethodDelete, "/habit/h1", "", func(router *gin.Engine, err error) {
+			router.DELETE("/habit/:id", NewHabitHandler(&fakeHabitService{err: err}, nil).PurgeHabit)
+		}},
+		{"UpdateDoneDailyTrack", http.MethodPut, "/daily-track/done", `{"date":"2024-01-01","habit_id":"h1"}`, func(router *gin.Engine, err error) {
+			router.PUT("/daily-track/done", NewDailyTrackHandler(&fakeDailyTrackService{err: err}).UpdateDoneDailyTrack)
+		}},
+		{"UndoDoneDailyTrack", http.MethodPut, "/daily-track/undo", `{"date":"2024-01-01","habit_id":"h1"}`, func(router *gin.Engine, err error) {
+			router.PUT("/daily-track/undo", NewDailyTrackHandler(&fakeDailyTrackService{err: err}).UndoDoneDailyTrack)
+		}},
+		{"LogHabitAmount", http.MethodPut, "/daily-track/amount", `{"date":"2024-01-01","habit_id":"h1","amount":1}`, func(router *gin.Engine, err error) {
+			router.PUT("/daily-track/amount", NewDailyTrackHandler(&fakeDailyTrackService{err: err}).LogHabitAmount)
+		}},
+		{"GetStreak", http.MethodGet, "/streak/h1", "", func(router *gin.Engine, err error) {
+			router.GET("/streak/:id", NewStreakHandler(&fakeStreakService{err: err}).GetStreak)
+		}},
+		{"UseFreeze", http.MethodPost, "/streak/h1/freeze", `{"date":"2024-01-01"}`, func(router *gin.Engine, err error) {
+			router.POST("/streak/:id/freeze", NewStreakHandler(&fakeStreakService{err: err}).UseFreeze)
+		}},
+	}
+
+	errorCases := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"not found", common.ErrNotFound, http.StatusNotFound},
+		{"forbidden", common.ErrForbidden, http.StatusForbidden},
+		{"unexpected", errors.New("unexpected"), http.StatusInternalServerError},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, endpoint := range endpoints {
+		for _, errorCase := range errorCases {
+			t.Run(endpoint.name+"/"+errorCase.name, func(t *testing.T) {
+				router := gin.New()
+				router.Use(func(c *gin.Context) { c.Set("user_id", "user-1") })
+				endpoint.route(router, errorCase.err)
+
+				recorder := httptest.NewRecorder()
+				request := httptest.NewRequest(endpoint.method, endpoint.path, strings.NewReader(endpoint.body))
+				request.Header.Set("Content-Type", "application/json")
+				router.ServeHTTP(recorder, request)
+
+				if recorder.Code != errorCase.wantStatus {
+					t.Errorf("status = %d, want %d (body: %s)", recorder.Code, errorCase.wantStatus, recorder.Body.String())
+				}
+			})
+		}
+	}
+}
+
+// 完了状態の更新・量の記録で、日付のエラーを400に変換する
+func TestDailyTrackDateErrorResponses(t *testing.T) {
+	endpoints := []struct {
+		name   string
+		path   string
+		body   string
+		handle func(h *DailyTrackHandler) gin.HandlerFunc
+	}{
+		{"UpdateDoneDailyTrack", "/daily-track/done", `{"date":"2999-01-01","habit_id":"h1"}`, func(h *DailyTrackHandler) gin.HandlerFunc { return h.UpdateDoneDailyTrack }},
+		{"UndoDoneDailyTrack", "/daily-track/undo", `{"date":"2999-01-01","habit_id":"h1"}`, func(h *DailyTrackHandler) gin.HandlerFunc { return h.UndoDoneDailyTrack }},
+		{"LogHabitAmount", "/daily-track/amount", `{"date":"2999-01-01","habit_id":"h1","amount":1}`, func(h *DailyTrackHandler) gin.HandlerFunc { return h.LogHabitAmount }},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, endpoint := range endpoints {
+		for _, err := range []error{common.ErrInvalidDate, common.ErrDateOutOfRange} {
+			t.Run(endpoint.name+"/"+err.Error(), func(t *testing.T) {
+				router := gin.New()
+				router.Use(func(c *gin.Context) { c.Set("user_id", "user-1") })
+				router.PUT(endpoint.path, endpoint.handle(NewDailyTrackHandler(&fakeDailyTrackService{err: err})))
+
+				recorder := httptest.NewRecorder()
+				request := httptest.NewRequest(http.MethodPut, endpoint.path, strings.NewReader(endpoint.body))
+				request.Header.Set("Content-Type", "application/json")
+				router.ServeHTTP(recorder, request)
+
+				if recorder.Code != http.StatusBadRequest {
+					t.Errorf("status = %d, want %d (body: %s)", recorder.Code, http.StatusBadRequest, recorder.Body.String())
+				}
+			})
+		}
+	}
+}
+
+// 量を記録する習慣の完了は400に変換する
+func TestUpdateDoneAmountRequiredResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("user_id", "user-1") })
+	router.PUT("/daily-track/done", NewDailyTrackHandler(&fakeDailyTrackService{err: common.ErrAmountRequired}).UpdateDoneDailyTrack)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPut, "/daily-track/done", strings.NewReader(`{"date":"2024-01-01","habit_id":"h1"}`))
+	request.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d (body: %s)", recorder.Code, http.StatusBadRequest, recorder.Body.String())
+	}
+}
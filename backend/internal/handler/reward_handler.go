@@ -0,0 +1,202 @@
+package handler
+
+// handler規約
+// フロントで表示するメッセージはここに定義
+// サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/service"
+	"backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// 交換履歴の1ページあたりの件数（デフォルト・上限）
+	defaultRedemptionHistoryLimit = 20
+	maxRedemptionHistoryLimit     = 100
+)
+
+type RewardHandler struct {
+	rewardService service.RewardService
+}
+
+func NewRewardHandler(rewardService service.RewardService) *RewardHandler {
+	return &RewardHandler{
+		rewardService: rewardService,
+	}
+}
+
+// TODO: requestパッケージ作成
+type RewardRequest struct {
+	Name          string `json:"name" binding:"required"`
+	Cost          int    `json:"cost" binding:"required"`
+	Stock         *int   `json:"stock"`          // 未指定の場合は無制限
+	CooldownHours int    `json:"cooldown_hours"` // 未指定の場合は制限なし
+}
+
+func (h *RewardHandler) GetRewards(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+
+	rewards, err := h.rewardService.GetRewards(c.Request.Context(), userId)
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rewards)
+}
+
+func (h *RewardHandler) RegisterReward(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+
+	// バリデーション
+	var rewardRequest RewardRequest
+	if err := c.ShouldBindJSON(&rewardRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	registeredReward, err := h.rewardService.RegisterReward(c.Request.Context(), userId, rewardRequest.Name, rewardRequest.Cost, rewardRequest.Stock, rewardRequest.CooldownHours)
+
+	if err != nil {
+		respondRewardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "reward": registeredReward})
+}
+
+func (h *RewardHandler) UpdateReward(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetRewardId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetRewardId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	// バリデーション
+	var rewardRequest RewardRequest
+	if err := c.ShouldBindJSON(&rewardRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	updatedReward, err := h.rewardService.UpdateReward(c.Request.Context(), userId, targetRewardId, rewardRequest.Name, rewardRequest.Cost, rewardRequest.Stock, rewardRequest.CooldownHours)
+
+	if err != nil {
+		respondRewardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "reward": updatedReward})
+}
+
+func (h *RewardHandler) DeleteReward(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetRewardId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetRewardId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	err := h.rewardService.DeleteReward(c.Request.Context(), userId, targetRewardId)
+
+	if err != nil {
+		respondRewardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success"})
+}
+
+// ポイントを消費してごほうびと交換する
+func (h *RewardHandler) RedeemReward(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetRewardId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetRewardId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	redemption, redeemedReward, points, err := h.rewardService.RedeemReward(c.Request.Context(), userId, targetRewardId)
+
+	if err != nil {
+		if errors.Is(err, common.ErrInsufficientPoints) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "ポイントが足りません。"})
+			return
+		}
+		if errors.Is(err, common.ErrRewardOutOfStock) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "このごほうびは在庫切れです。"})
+			return
+		}
+		if errors.Is(err, common.ErrRewardCoolingDown) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "このごほうびはまだ交換できません。"})
+			return
+		}
+
+		respondRewardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "redemption": redemption, "reward": redeemedReward, "points": points})
+}
+
+func (h *RewardHandler) GetRedemptionHistory(c *gin.Context) {
+	// バリデーション
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "ページの指定が不正です。"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultRedemptionHistoryLimit)))
+	if err != nil || limit < 1 || limit > maxRedemptionHistoryLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "件数の指定が不正です。"})
+		return
+	}
+
+	userId := utils.GetUserIdFromContext(c)
+	history, err := h.rewardService.GetRedemptionHistory(c.Request.Context(), userId, page, limit)
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+func respondRewardError(c *gin.Context, err error) {
+	if errors.Is(err, common.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "対象のごほうびが見つかりません。"})
+		return
+	}
+	if errors.Is(err, common.ErrForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "このごほうびを操作する権限がありません。"})
+		return
+	}
+	if errors.Is(err, common.ErrAlreadyExists) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "すでに登録済みのごほうびです。"})
+		return
+	}
+	if errors.Is(err, common.ErrInvalidReward) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "ごほうびの指定が不正です。"})
+		return
+	}
+
+	log.Printf("[ERROR] %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+}
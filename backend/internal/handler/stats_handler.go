@@ -0,0 +1,56 @@
+package handler
+
+// handler規約
+// フロントで表示するメッセージはここに定義
+// サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/service"
+	"backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 統計の集計期間のデフォルト（日数）
+const defaultStatsWindowDays = 30
+
+type StatsHandler struct {
+	statsService service.StatsService
+}
+
+func NewStatsHandler(statsService service.StatsService) *StatsHandler {
+	return &StatsHandler{
+		statsService: statsService,
+	}
+}
+
+func (h *StatsHandler) GetStats(c *gin.Context) {
+	// バリデーション
+	windowDays, err := strconv.Atoi(c.DefaultQuery("window", strconv.Itoa(defaultStatsWindowDays)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "集計期間の指定が不正です。"})
+		return
+	}
+	habitId := c.Query("habit_id")
+
+	userId := utils.GetUserIdFromContext(c)
+	result, err := h.statsService.GetStats(c.Request.Context(), userId, windowDays, habitId)
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidDateRange) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "集計期間の指定が不正です。"})
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
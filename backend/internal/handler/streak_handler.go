@@ -0,0 +1,121 @@
+package handler
+
+// handler規約
+// フロントで表示するメッセージはここに定義
+// サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/service"
+	"backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StreakHandler struct {
+	streakService service.StreakService
+}
+
+func NewStreakHandler(streakService service.StreakService) *StreakHandler {
+	return &StreakHandler{
+		streakService: streakService,
+	}
+}
+
+func (h *StreakHandler) GetStreak(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetHabitId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetHabitId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	streak, err := h.streakService.GetStreak(c.Request.Context(), userId, targetHabitId)
+	if err != nil {
+		if respondHabitAccessError(c, err) {
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, streak)
+}
+
+// TODO: requestパッケージ作成
+type useStreakFreezeRequest struct {
+	Date string `json:"date" binding:"required"`
+}
+
+func (h *StreakHandler) UseFreeze(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	targetHabitId := c.Param("id")
+
+	// idが空文字列の場合のチェック
+	if targetHabitId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "IDは必須です。"})
+		return
+	}
+
+	// バリデーション
+	var useStreakFreezeRequest useStreakFreezeRequest
+	if err := c.ShouldBindJSON(&useStreakFreezeRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	streak, freezeTokens, err := h.streakService.UseFreeze(c.Request.Context(), userId, targetHabitId, useStreakFreezeRequest.Date)
+	if err != nil {
+		if respondHabitAccessError(c, err) {
+			return
+		}
+		if errors.Is(err, common.ErrInvalidDate) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "日付の形式が不正です。"})
+			return
+		}
+		if errors.Is(err, common.ErrFreezeNotNeeded) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "この日にストリークフリーズは使用できません。"})
+			return
+		}
+		if errors.Is(err, common.ErrAlreadyExists) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "この日にはすでにストリークフリーズを使用しています。"})
+			return
+		}
+		if errors.Is(err, common.ErrNoFreezeTokens) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "ストリークフリーズを所持していません。"})
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "streak": streak, "freeze_tokens": freezeTokens})
+}
+
+func (h *StreakHandler) PurchaseFreezeToken(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+
+	freezeTokens, points, err := h.streakService.PurchaseFreezeToken(c.Request.Context(), userId)
+	if err != nil {
+		if errors.Is(err, common.ErrInsufficientPoints) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "ポイントが足りません。"})
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "freeze_tokens": freezeTokens, "points": points})
+}
@@ -0,0 +1,153 @@
+package handler
+
+// handler規約
+// フロントで表示するメッセージはここに定義
+// サービスからのエラーはlog.Printf("[ERROR] ~")でそのまま出力
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"backend/internal/config"
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/transfer"
+	"backend/internal/domain/service"
+	"backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransferHandler struct {
+	transferService service.TransferService
+}
+
+func NewTransferHandler(transferService service.TransferService) *TransferHandler {
+	return &TransferHandler{
+		transferService: transferService,
+	}
+}
+
+// データのエクスポート
+// format=csvの場合は習慣・日付ごとに1行のCSV、それ以外はJSON
+func (h *TransferHandler) Export(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "形式の指定が不正です。"})
+		return
+	}
+
+	// レスポンスに直接書き出す
+	exporter := transfer.NewJSONExporter(c.Writer)
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="habit-tracker-export.json"`)
+	if format == "csv" {
+		exporter = transfer.NewCSVExporter(c.Writer)
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="habit-tracker-export.csv"`)
+	}
+
+	userId := utils.GetUserIdFromContext(c)
+	err := h.transferService.Export(c.Request.Context(), userId, exporter)
+
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		// 書き出しを始めた後はステータスを変更できないため、途中で終了する
+		if !c.Writer.Written() {
+			c.Header("Content-Type", "")
+			c.Header("Content-Disposition", "")
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		}
+	}
+}
+
+// データのインポート（mode=merge|replace、未指定の場合はmerge）
+// dry_run=trueの場合は何も変更せず、取り込まれる内容のみを返す
+func (h *TransferHandler) Import(c *gin.Context) {
+	mode, err := transfer.ParseMode(c.Query("mode"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "インポートのモードが不正です。"})
+		return
+	}
+
+	// バリデーション
+	var document transfer.Document
+	if err := c.ShouldBindJSON(&document); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+		return
+	}
+
+	userId := utils.GetUserIdFromContext(c)
+	dryRun := c.Query("dry_run") == "true"
+	result, err := h.transferService.Import(c.Request.Context(), userId, &document, mode, dryRun)
+
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidImport) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "インポートするデータが不正です。", "detail": err.Error()})
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "result": result})
+}
+
+// 他の習慣管理アプリのデータのインポート
+// multipart/form-dataのfileで受け取る（source=loop|habitica、mode・dry_runはImportと同じ）
+func (h *TransferHandler) ImportExternal(c *gin.Context) {
+	source, err := transfer.ParseSource(c.Query("source"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "インポート元の指定が不正です。"})
+		return
+	}
+
+	mode, err := transfer.ParseMode(c.Query("mode"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "インポートのモードが不正です。"})
+		return
+	}
+
+	// バリデーション
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, config.MaxImportFileBytes)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "ファイルを指定してください。"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	userId := utils.GetUserIdFromContext(c)
+	dryRun := c.Query("dry_run") == "true"
+	result, err := h.transferService.ImportExternal(c.Request.Context(), userId, source, data, mode, dryRun)
+
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidImport) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "インポートするデータが不正です。", "detail": err.Error()})
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success", "result": result})
+}
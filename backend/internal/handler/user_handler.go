@@ -10,7 +10,9 @@ import (
 	"net/http"
 
 	"backend/internal/domain/common"
+	"backend/internal/domain/model/notification"
 	"backend/internal/domain/service"
+	"backend/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -36,6 +38,38 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword    string `json:"current_password" binding:"required"`
+	NewPassword        string `json:"new_password" binding:"required"`
+	ConfirmNewPassword string `json:"confirm_new_password" binding:"required"`
+}
+
+type ChangeUsernameRequest struct {
+	Username string `json:"username" binding:"required"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+type DaySettingsRequest struct {
+	Timezone     string `json:"timezone" binding:"required"`
+	DayStartHour int    `json:"day_start_hour"`
+}
+
+type PrivacySettingsRequest struct {
+	HideFromLeaderboard *bool `json:"hide_from_leaderboard" binding:"required"`
+}
+
+type NotificationSettingsRequest struct {
+	Channel string `json:"channel"` // 空の場合は通知しない
+	Address string `json:"address"`
+}
+
 func (h *UserHandler) SignUp(c *gin.Context) {
 	var signUpRequest SignUpRequest
 
@@ -78,7 +112,7 @@ func (h *UserHandler) Login(c *gin.Context) {
 	}
 
 	// ログインサービス実行
-	user, tokenString, err := h.userService.Login(c.Request.Context(), loginRequest.Username, loginRequest.Password)
+	user, tokenPair, err := h.userService.Login(c.Request.Context(), loginRequest.Username, loginRequest.Password)
 
 	if err != nil {
 		if err == common.ErrNotFound {
@@ -97,7 +131,287 @@ func (h *UserHandler) Login(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"token": tokenString,
-		"user":  user,
+		"token":         tokenPair.AccessToken,
+		"refresh_token": tokenPair.RefreshToken,
+		"expires_at":    tokenPair.ExpiresAt,
+		"user":          user,
+	})
+}
+
+func (h *UserHandler) RefreshToken(c *gin.Context) {
+	var refreshTokenRequest RefreshTokenRequest
+
+	// リクエスト内容の検証・構造体バインド
+	if err := c.ShouldBindJSON(&refreshTokenRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "リクエストが不正です。"})
+		return
+	}
+
+	// トークン再発行サービス実行
+	tokenPair, err := h.userService.RefreshToken(c.Request.Context(), refreshTokenRequest.RefreshToken)
+
+	if err != nil {
+		if errors.Is(err, common.ErrRefreshTokenReused) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "不正なトークンの使用を検知したため、ログアウトしました。再度ログインしてください。"})
+			return
+		}
+		if errors.Is(err, common.ErrInvalidToken) || errors.Is(err, common.ErrSessionRevoked) || errors.Is(err, common.ErrNotFound) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "ログインの有効期限が切れました。再度ログインしてください。"})
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":         tokenPair.AccessToken,
+		"refresh_token": tokenPair.RefreshToken,
+		"expires_at":    tokenPair.ExpiresAt,
+	})
+}
+
+// ログアウト（この端末のみ）
+func (h *UserHandler) Logout(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	sessionId := utils.GetSessionIdFromContext(c)
+
+	err := h.userService.Logout(c.Request.Context(), userId, sessionId)
+
+	if err != nil && !errors.Is(err, common.ErrNotFound) {
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success"})
+}
+
+// 全端末からログアウト
+func (h *UserHandler) LogoutAll(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+
+	err := h.userService.LogoutAll(c.Request.Context(), userId)
+
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success"})
+}
+
+// タイムゾーンと1日の始まりの時刻の設定
+// ログイン中のユーザー情報
+func (h *UserHandler) GetProfile(c *gin.Context) {
+	userId := utils.GetUserIdFromContext(c)
+	user, err := h.userService.GetProfile(c.Request.Context(), userId)
+
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "ユーザーが見つかりません。"})
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": user,
+	})
+}
+
+func (h *UserHandler) UpdateDaySettings(c *gin.Context) {
+	var daySettingsRequest DaySettingsRequest
+
+	// リクエスト内容の検証・構造体バインド
+	if err := c.ShouldBindJSON(&daySettingsRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "リクエストが不正です。"})
+		return
+	}
+
+	userId := utils.GetUserIdFromContext(c)
+	user, err := h.userService.UpdateDaySettings(c.Request.Context(), userId, daySettingsRequest.Timezone, daySettingsRequest.DayStartHour)
+
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidDaySettings) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "タイムゾーンまたは1日の始まりの時刻が不正です。"})
+			return
+		}
+		if errors.Is(err, common.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "ユーザーが見つかりません。"})
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": user,
+	})
+}
+
+// ランキングの公開設定
+func (h *UserHandler) UpdatePrivacySettings(c *gin.Context) {
+	var privacySettingsRequest PrivacySettingsRequest
+
+	// リクエスト内容の検証・構造体バインド
+	if err := c.ShouldBindJSON(&privacySettingsRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "リクエストが不正です。"})
+		return
+	}
+
+	userId := utils.GetUserIdFromContext(c)
+	user, err := h.userService.UpdateLeaderboardVisibility(c.Request.Context(), userId, *privacySettingsRequest.HideFromLeaderboard)
+
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "ユーザーが見つかりません。"})
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": user,
+	})
+}
+
+// 通知設定（リマインダーなどの通知先）
+func (h *UserHandler) UpdateNotificationSettings(c *gin.Context) {
+	var notificationSettingsRequest NotificationSettingsRequest
+
+	// リクエスト内容の検証・構造体バインド
+	if err := c.ShouldBindJSON(&notificationSettingsRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "リクエストが不正です。"})
+		return
+	}
+
+	var settings *notification.Settings
+	if notificationSettingsRequest.Channel != "" {
+		settings = &notification.Settings{
+			Channel: notification.Channel(notificationSettingsRequest.Channel),
+			Address: notificationSettingsRequest.Address,
+		}
+	}
+
+	userId := utils.GetUserIdFromContext(c)
+	user, err := h.userService.UpdateNotificationSettings(c.Request.Context(), userId, settings)
+
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidNotification) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "通知方法または通知先が不正です。"})
+			return
+		}
+		if errors.Is(err, common.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "ユーザーが見つかりません。"})
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": user,
 	})
 }
+
+// パスワード変更
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	var changePasswordRequest ChangePasswordRequest
+
+	// リクエスト内容の検証・構造体バインド
+	if err := c.ShouldBindJSON(&changePasswordRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "リクエストが不正です。"})
+		return
+	}
+
+	if changePasswordRequest.NewPassword != changePasswordRequest.ConfirmNewPassword {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "確認用パスワードが一致しません。"})
+		return
+	}
+
+	userId := utils.GetUserIdFromContext(c)
+	sessionId := utils.GetSessionIdFromContext(c)
+	err := h.userService.ChangePassword(c.Request.Context(), userId, sessionId, changePasswordRequest.CurrentPassword, changePasswordRequest.NewPassword)
+
+	if err != nil {
+		if errors.Is(err, common.ErrPasswordMismatch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "現在のパスワードが正しくありません。"})
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success"})
+}
+
+// ユーザーネーム変更
+func (h *UserHandler) ChangeUsername(c *gin.Context) {
+	var changeUsernameRequest ChangeUsernameRequest
+
+	// リクエスト内容の検証・構造体バインド
+	if err := c.ShouldBindJSON(&changeUsernameRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "リクエストが不正です。"})
+		return
+	}
+
+	userId := utils.GetUserIdFromContext(c)
+	user, err := h.userService.ChangeUsername(c.Request.Context(), userId, changeUsernameRequest.Username)
+
+	if err != nil {
+		if errors.Is(err, common.ErrAlreadyExists) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "使用できないユーザーネームです。"})
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": user,
+	})
+}
+
+// アカウント削除
+func (h *UserHandler) DeleteAccount(c *gin.Context) {
+	var deleteAccountRequest DeleteAccountRequest
+
+	// リクエスト内容の検証・構造体バインド
+	if err := c.ShouldBindJSON(&deleteAccountRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "リクエストが不正です。"})
+		return
+	}
+
+	userId := utils.GetUserIdFromContext(c)
+	err := h.userService.DeleteAccount(c.Request.Context(), userId, deleteAccountRequest.Password)
+
+	if err != nil {
+		if errors.Is(err, common.ErrPasswordMismatch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "パスワードが正しくありません。"})
+			return
+		}
+
+		log.Printf("[ERROR] %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "エラーが発生しました。"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success"})
+}
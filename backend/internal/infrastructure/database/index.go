@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 各コレクションのインデックス定義
+var indexes = map[string][]mongo.IndexModel{
+	"achievements": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	},
+	"challenges": {
+		{Keys: bson.D{{Key: "participants.user_id", Value: 1}, {Key: "start_date", Value: -1}}},
+		{Keys: bson.D{{Key: "owner_id", Value: 1}}},
+	},
+	"cheers": {
+		// リアクションは1人につき絵文字ごとに1件
+		{
+			Keys:    bson.D{{Key: "item_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "emoji", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"kind": "reaction"}),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "item_owner_id", Value: 1}, {Key: "habit_id", Value: 1}, {Key: "date", Value: 1}}},
+	},
+	"cheer_rate_limits": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "window", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		// 期間が過ぎたドキュメントは自動で削除する
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	"daily_track": {
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "date", Value: 1}}},
+	},
+	"feed_items": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "habit_id", Value: 1}, {Key: "date", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+	},
+	"friendships": {
+		{
+			Keys:    bson.D{{Key: "pair_key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "requester_id", Value: 1}}},
+		{Keys: bson.D{{Key: "addressee_id", Value: 1}}},
+	},
+	"habit_shares": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "friend_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "friend_id", Value: 1}}},
+	},
+	"points_ledger": {
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "date", Value: 1}, {Key: "habit_id", Value: 1}}},
+		// 台帳導入前のポイントの繰越は1ユーザーにつき1件
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"reason": "opening_balance"}),
+		},
+	},
+	"push_subscriptions": {
+		{
+			Keys:    bson.D{{Key: "endpoint", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	},
+	"reminder_deliveries": {
+		{
+			Keys:    bson.D{{Key: "reminder_id", Value: 1}, {Key: "date", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		// 送信済みの記録は一定期間で自動で削除する
+		{Keys: bson.D{{Key: "created_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(config.ReminderDeliveryRetentionDay * 24 * 60 * 60)},
+	},
+	"reminders": {
+		{Keys: bson.D{{Key: "next_at", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "habit_id", Value: 1}}},
+	},
+	"rewards": {
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: 1}}},
+	},
+	"rankings": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "board", Value: 1}, {Key: "period", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "board", Value: 1}, {Key: "period", Value: 1}, {Key: "hidden", Value: 1}, {Key: "score", Value: -1}, {Key: "user_id", Value: 1}}},
+	},
+	"reward_redemptions": {
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	},
+	"sessions": {
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		// 期限切れのセッションは自動で削除する
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	"streak_freezes": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "habit_id", Value: 1}, {Key: "date", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	},
+	"user": {
+		// ポイントのランキング
+		{Keys: bson.D{{Key: "points", Value: -1}, {Key: "_id", Value: 1}}},
+	},
+}
+
+// EnsureIndexes は各コレクションにインデックスを作成する（作成済みの場合は何もしない）
+func EnsureIndexes(ctx context.Context, db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	for collectionName, models := range indexes {
+		if _, err := db.Collection(collectionName).Indexes().CreateMany(ctx, models); err != nil {
+			return err
+		}
+	}
+	return nil
+}
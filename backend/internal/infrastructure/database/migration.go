@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/domain/model/point"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MigrateOpeningBalances は台帳導入前に貯まっていたポイントを、繰越として台帳に記録する
+// NOTE: 台帳にエントリが無く、ポイントが0でないユーザーのみ対象（記録済みの場合は何もしない）
+// 繰越は部分ユニークインデックスで1ユーザーにつき1件のため、複数のインスタンスが同時に起動しても二重に記録しない
+func MigrateOpeningBalances(ctx context.Context, db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"points": bson.M{"$ne": 0}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "points_ledger",
+			"let":  bson.M{"user_id": bson.M{"$toString": "$_id"}},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$user_id", "$$user_id"}}}},
+				bson.M{"$limit": 1},
+			},
+			"as": "entries",
+		}}},
+		{{Key: "$match", Value: bson.M{"entries": bson.M{"$size": 0}}}},
+		{{Key: "$project", Value: bson.M{"points": 1}}},
+	}
+
+	cursor, err := db.Collection("user").Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	ledger := db.Collection("points_ledger")
+	now := time.Now()
+	for cursor.Next(ctx) {
+		var user struct {
+			ID     primitive.ObjectID `bson:"_id"`
+			Points int                `bson:"points"`
+		}
+		if err := cursor.Decode(&user); err != nil {
+			return err
+		}
+
+		entry := bson.M{
+			"user_id":    user.ID.Hex(),
+			"amount":     user.Points,
+			"reason":     string(point.ReasonOpeningBalance),
+			"created_at": now,
+		}
+		if _, err := ledger.InsertOne(ctx, entry); err != nil && !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 繰越は台帳にエントリの無いユーザーのみ記録し、再実行しても二重に記録しない（MongoDBを使用する）
+func TestMigrateOpeningBalances(t *testing.T) {
+	uri := os.Getenv("TEST_DATABASE_URI")
+	if uri == "" {
+		t.Skip("TEST_DATABASE_URI is not set")
+	}
+	ctx := context.Background()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	db := client.Database(fmt.Sprintf("test_%d", time.Now().UnixNano()))
+	t.Cleanup(func() {
+		db.Drop(context.Background())
+		client.Disconnect(context.Background())
+	})
+	if err = EnsureIndexes(ctx, db); err != nil {
+		t.Fatalf("failed to ensure indexes: %v", err)
+	}
+
+	legacyId, ledgerId, zeroId := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	_, err = db.Collection("user").InsertMany(ctx, []interface{}{
+		bson.M{"_id": legacyId, "points": 120},
+		bson.M{"_id": ledgerId, "points": 30},
+		bson.M{"_id": zeroId, "points": 0},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert users: %v", err)
+	}
+	if _, err = db.Collection("points_ledger").InsertOne(ctx, bson.M{"user_id": ledgerId.Hex(), "amount": 30, "reason": "habit_done"}); err != nil {
+		t.Fatalf("failed to insert ledger entry: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err = MigrateOpeningBalances(ctx, db); err != nil {
+			t.Fatalf("migration %d failed: %v", i, err)
+		}
+	}
+
+	var entries []bson.M
+	cursor, err := db.Collection("points_ledger").Find(ctx, bson.M{"reason": "opening_balance"})
+	if err != nil {
+		t.Fatalf("failed to find entries: %v", err)
+	}
+	if err = cursor.All(ctx, &entries); err != nil {
+		t.Fatalf("failed to decode entries: %v", err)
+	}
+	if len(entries) != 1 || entries[0]["user_id"] != legacyId.Hex() || entries[0]["amount"] != int32(120) {
+		t.Errorf("opening balances = %v, want only %s with 120", entries, legacyId.Hex())
+	}
+
+	// 同じユーザーの繰越はインデックスで拒否される
+	_, err = db.Collection("points_ledger").InsertOne(ctx, bson.M{"user_id": legacyId.Hex(), "amount": 120, "reason": "opening_balance"})
+	if !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("got error %v, want duplicate key error", err)
+	}
+}
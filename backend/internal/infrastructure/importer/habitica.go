@@ -0,0 +1,173 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/transfer"
+)
+
+// Habiticaのデータエクスポート（user data JSON）のうち、取り込みに必要な部分
+type habiticaExport struct {
+	Tasks struct {
+		Dailys []*habiticaTask `json:"dailys"`
+		Habits []*habiticaTask `json:"habits"`
+	} `json:"tasks"`
+}
+
+type habiticaTask struct {
+	Id        string            `json:"id"`
+	Text      string            `json:"text"`
+	Frequency string            `json:"frequency"` // daily, weekly, monthly, yearly
+	EveryX    int               `json:"everyX"`
+	Repeat    map[string]bool   `json:"repeat"` // m, t, w, th, f, s, su
+	StartDate string            `json:"startDate"`
+	Up        *bool             `json:"up"` // habits: 良い習慣かどうか
+	History   []habiticaHistory `json:"history"`
+}
+
+type habiticaHistory struct {
+	Date      json.RawMessage `json:"date"` // Unix時間（ミリ秒）またはISO8601の文字列
+	Value     float64         `json:"value"`
+	Completed *bool           `json:"completed"` // dailys（古いデータには無い）
+	ScoredUp  int             `json:"scoredUp"`  // habits
+}
+
+// Habiticaの曜日キー
+var habiticaWeekdays = []struct {
+	key     string
+	weekday time.Weekday
+}{
+	{"su", time.Sunday},
+	{"m", time.Monday},
+	{"t", time.Tuesday},
+	{"w", time.Wednesday},
+	{"th", time.Thursday},
+	{"f", time.Friday},
+	{"s", time.Saturday},
+}
+
+// HabiticaのデータエクスポートJSONを変換する
+// 日課（dailys）と良い習慣（habits）を取り込む。To-Doとごほうびは対象外
+func ParseHabitica(data []byte, location *time.Location) (*transfer.Document, error) {
+	var export habiticaExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, invalidf("habitica: %v", err)
+	}
+
+	builder := newDocumentBuilder()
+
+	// 日課
+	for _, task := range export.Tasks.Dailys {
+		if task.Text == "" {
+			return nil, invalidf("habitica: daily %s: text is required", task.Id)
+		}
+
+		startDate := common.FormatDate(time.Now().In(location))
+		if task.StartDate != "" {
+			parsed, err := time.Parse(time.RFC3339, task.StartDate)
+			if err != nil {
+				return nil, invalidf("habitica: daily %s: invalid startDate", task.Text)
+			}
+			startDate = common.FormatDate(parsed.In(location))
+		}
+
+		targetHabit := &habit.Habit{Id: "habitica-" + task.Id, Name: task.Text, Schedule: task.schedule(startDate)}
+		builder.addHabit(targetHabit)
+
+		var previousValue *float64
+		for _, history := range task.History {
+			date, err := history.date(location)
+			if err != nil {
+				return nil, invalidf("habitica: daily %s: %v", task.Text, err)
+			}
+
+			// completedが無い古いデータは、タスクの値が増えていれば完了とみなす
+			var isDone bool
+			switch {
+			case history.Completed != nil:
+				isDone = *history.Completed
+			case previousValue != nil:
+				isDone = history.Value > *previousValue
+			}
+			value := history.Value
+			previousValue = &value
+
+			builder.addRecord(targetHabit, date, isDone, 0)
+		}
+	}
+
+	// 良い習慣（悪い習慣のみのものは対象外）
+	for _, task := range export.Tasks.Habits {
+		if task.Up != nil && !*task.Up {
+			continue
+		}
+		if task.Text == "" {
+			return nil, invalidf("habitica: habit %s: text is required", task.Id)
+		}
+
+		targetHabit := &habit.Habit{Id: "habitica-" + task.Id, Name: task.Text, Schedule: habit.DefaultSchedule()}
+		builder.addHabit(targetHabit)
+
+		for _, history := range task.History {
+			date, err := history.date(location)
+			if err != nil {
+				return nil, invalidf("habitica: habit %s: %v", task.Text, err)
+			}
+			builder.addRecord(targetHabit, date, history.ScoredUp > 0, 0)
+		}
+	}
+
+	return builder.build(), nil
+}
+
+// 日課の繰り返し設定をスケジュールに変換する
+// NOTE: 月単位・年単位の日課は「N日ごと」に換算する
+func (t *habiticaTask) schedule(startDate string) habit.Schedule {
+	everyX := max(t.EveryX, 1)
+
+	switch t.Frequency {
+	case "weekly":
+		var weekdays []time.Weekday
+		for _, day := range habiticaWeekdays {
+			if t.Repeat[day.key] {
+				weekdays = append(weekdays, day.weekday)
+			}
+		}
+		if len(weekdays) == 0 || len(weekdays) == len(habiticaWeekdays) {
+			return habit.DefaultSchedule()
+		}
+		return habit.Schedule{Type: habit.ScheduleTypeWeekdays, Weekdays: weekdays}
+	case "monthly":
+		return frequencySchedule(1, everyX*30, startDate)
+	case "yearly":
+		return frequencySchedule(1, everyX*365, startDate)
+	default:
+		return frequencySchedule(1, everyX, startDate)
+	}
+}
+
+// 履歴の日時を日付に変換する
+func (h *habiticaHistory) date(location *time.Location) (time.Time, error) {
+	var millis int64
+	if err := json.Unmarshal(h.Date, &millis); err == nil {
+		return common.TruncateToDate(time.UnixMilli(millis).In(location)), nil
+	}
+
+	var dateString string
+	if err := json.Unmarshal(h.Date, &dateString); err != nil {
+		return time.Time{}, fmt.Errorf("invalid history date %s", string(h.Date))
+	}
+	if millis, err := strconv.ParseInt(dateString, 10, 64); err == nil {
+		return common.TruncateToDate(time.UnixMilli(millis).In(location)), nil
+	}
+	parsed, err := time.Parse(time.RFC3339, dateString)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid history date %s", dateString)
+	}
+	return common.TruncateToDate(parsed.In(location)), nil
+}
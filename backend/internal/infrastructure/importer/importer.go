@@ -0,0 +1,132 @@
+package importer
+
+// 他の習慣管理アプリのデータを、インポート用のドキュメント（transfer.Document）に変換する
+// 変換後のドキュメントはtransferServiceのインポートでそのまま取り込める
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/transfer"
+)
+
+// データ形式に応じて変換する
+// NOTE: locationは日時で記録されている履歴を日付に変換する際、および履歴の無い習慣の開始日（今日）を求める際のタイムゾーン
+func Parse(source transfer.Source, data []byte, location *time.Location) (*transfer.Document, error) {
+	switch source {
+	case transfer.SourceLoop:
+		return ParseLoop(data, location)
+	case transfer.SourceHabitica:
+		return ParseHabitica(data, location)
+	default:
+		return nil, common.ErrInvalidImport
+	}
+}
+
+// 変換エラー
+func invalidf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", common.ErrInvalidImport, fmt.Sprintf(format, args...))
+}
+
+// 習慣と日付ごとの記録を集め、ドキュメントを組み立てる
+type documentBuilder struct {
+	habits   []*habit.Habit
+	names    map[string]int
+	statuses map[string]map[string]*daily_track.HabitStatus // 日付 -> 習慣ID -> ステータス
+}
+
+func newDocumentBuilder() *documentBuilder {
+	return &documentBuilder{
+		names:    make(map[string]int),
+		statuses: make(map[string]map[string]*daily_track.HabitStatus),
+	}
+}
+
+// 習慣を追加する（名前が重複する場合は連番を付ける）
+func (b *documentBuilder) addHabit(h *habit.Habit) {
+	b.names[h.Name]++
+	if count := b.names[h.Name]; count > 1 {
+		h.Name = h.Name + " (" + strconv.Itoa(count) + ")"
+	}
+	if h.Status == "" {
+		h.Status = habit.StatusActive
+	}
+	h.SortOrder = len(b.habits)
+	b.habits = append(b.habits, h)
+}
+
+// 習慣の記録を追加する（同じ日に複数の記録がある場合は完了・記録量の大きい方を採用する）
+func (b *documentBuilder) addRecord(h *habit.Habit, date time.Time, isDone bool, value float64) {
+	dateString := common.FormatDate(date)
+	if b.statuses[dateString] == nil {
+		b.statuses[dateString] = make(map[string]*daily_track.HabitStatus)
+	}
+
+	habitStatus, ok := b.statuses[dateString][h.Id]
+	if !ok {
+		habitStatus = &daily_track.HabitStatus{
+			HabitId:   h.Id,
+			HabitName: h.Name,
+			Measure:   h.Measure,
+		}
+		if h.Schedule.IsWeeklyQuota() {
+			habitStatus.WeeklyTarget = h.Schedule.TimesPerWeek
+		}
+		b.statuses[dateString][h.Id] = habitStatus
+	}
+
+	habitStatus.IsDone = habitStatus.IsDone || isDone
+	if h.Measure != nil {
+		habitStatus.Value = max(habitStatus.Value, value)
+	}
+}
+
+// ドキュメントを作成する（daily_trackは日付順、ステータスは習慣の表示順）
+func (b *documentBuilder) build() *transfer.Document {
+	document := &transfer.Document{
+		Version:     transfer.CurrentVersion,
+		ExportedAt:  time.Now(),
+		Habits:      b.habits,
+		DailyTracks: make([]*daily_track.DailyTrack, 0, len(b.statuses)),
+	}
+
+	dates := make([]string, 0, len(b.statuses))
+	for date := range b.statuses {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	for _, date := range dates {
+		track := &daily_track.DailyTrack{Date: date}
+		for _, h := range b.habits {
+			if habitStatus, ok := b.statuses[date][h.Id]; ok {
+				track.HabitStatuses = append(track.HabitStatuses, habitStatus)
+			}
+		}
+		document.DailyTracks = append(document.DailyTracks, track)
+	}
+
+	return document
+}
+
+// 「期間あたりの回数」の頻度をスケジュールに変換する
+// NOTE: 週単位で表せない頻度（月3回など）は週あたりの回数に換算する
+func frequencySchedule(times int, days int, startDate string) habit.Schedule {
+	switch {
+	case times <= 0 || days <= 0 || times >= days:
+		return habit.DefaultSchedule()
+	case times == 1:
+		return habit.Schedule{Type: habit.ScheduleTypeInterval, IntervalDays: days, StartDate: startDate}
+	default:
+		timesPerWeek := (times*7 + days - 1) / days
+		if timesPerWeek >= 7 {
+			return habit.DefaultSchedule()
+		}
+		return habit.Schedule{Type: habit.ScheduleTypeWeeklyQuota, TimesPerWeek: max(timesPerWeek, 1)}
+	}
+}
@@ -0,0 +1,225 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/transfer"
+)
+
+// Loop Habit Tracker のチェックマークの値
+const (
+	loopCheckmarkNo         = 0 // 未完了
+	loopCheckmarkYesAuto    = 1 // 頻度の条件で自動的に達成扱い（実際には記録していない）
+	loopCheckmarkYesManual  = 2 // 完了
+	loopNumericalValueScale = 1000
+)
+
+// Loop Habit Tracker の数値を記録する習慣の種類
+const loopHabitTypeNumerical = "1"
+
+// Loop Habit Tracker のCSVバックアップ（zip）を変換する
+// zipにはHabits.csvと、習慣ごとのフォルダ（"001 習慣名/Checkmarks.csv"）が含まれる
+// NOTE: チェックマークは日付で記録されているため、locationは記録の無い習慣の開始日にのみ使う
+func ParseLoop(data []byte, location *time.Location) (*transfer.Document, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, invalidf("loop: invalid zip file")
+	}
+
+	// ファイル名 -> ファイル
+	files := make(map[string]*zip.File)
+	for _, file := range reader.File {
+		files[strings.TrimPrefix(path.Clean(file.Name), "/")] = file
+	}
+
+	habitsFile, ok := files["Habits.csv"]
+	if !ok {
+		return nil, invalidf("loop: Habits.csv not found")
+	}
+	// 展開後の合計サイズの残り（圧縮率の高いファイルで大量のメモリを使わせない）
+	remaining := int64(config.MaxImportUncompressedBytes)
+	habitRows, err := readLoopCSV(habitsFile, &remaining)
+	if err != nil {
+		return nil, err
+	}
+	if len(habitRows) == 0 {
+		return nil, invalidf("loop: Habits.csv is empty")
+	}
+
+	// 列名 -> 列番号（バージョンによって列が異なる）
+	columns := make(map[string]int)
+	for i, name := range habitRows[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	column := func(row []string, names ...string) string {
+		for _, name := range names {
+			if i, ok := columns[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+		}
+		return ""
+	}
+
+	builder := newDocumentBuilder()
+	for _, row := range habitRows[1:] {
+		position := column(row, "Position")
+		name := column(row, "Name")
+		if position == "" || name == "" {
+			return nil, invalidf("loop: Habits.csv: position and name are required")
+		}
+
+		targetHabit := &habit.Habit{Id: "loop-" + position, Name: name}
+		if column(row, "Archived?") == "true" {
+			now := time.Now()
+			targetHabit.Status = habit.StatusArchived
+			targetHabit.ArchivedAt = &now
+		}
+
+		// 数値を記録する習慣
+		// NOTE: 「目標以下」の習慣は表現できないため、目標以上で完了として扱う
+		numerical := column(row, "Type") == loopHabitTypeNumerical
+		if numerical {
+			target, err := strconv.ParseFloat(column(row, "Target Value"), 64)
+			if err != nil || target <= 0 {
+				return nil, invalidf("loop: habit %s: invalid target value", name)
+			}
+			unit := column(row, "Unit")
+			if unit == "" {
+				unit = "回"
+			}
+			targetHabit.Measure = &habit.Measure{Unit: unit, Target: target, Aggregation: habit.AggregationSum}
+		}
+
+		// 習慣ごとのチェックマーク
+		checkmarks, err := readLoopCheckmarks(files, position, &remaining)
+		if err != nil {
+			return nil, err
+		}
+
+		// 頻度（期間あたりの回数）。開始日は最初の記録の日付
+		times, _ := strconv.Atoi(column(row, "FrequencyNumerator", "NumRepetitions"))
+		days, _ := strconv.Atoi(column(row, "FrequencyDenominator", "Interval"))
+		startDate := common.FormatDate(time.Now().In(location))
+		if len(checkmarks) > 0 {
+			startDate = checkmarks[0].date
+		}
+		targetHabit.Schedule = frequencySchedule(times, days, startDate)
+
+		builder.addHabit(targetHabit)
+
+		for _, checkmark := range checkmarks {
+			date, _ := common.ParseDate(checkmark.date)
+			if numerical {
+				value := float64(checkmark.value) / loopNumericalValueScale
+				builder.addRecord(targetHabit, date, targetHabit.Measure.IsReached(value), value)
+				continue
+			}
+
+			// 自動達成・スキップ・不明は記録しない
+			switch checkmark.value {
+			case loopCheckmarkYesManual:
+				builder.addRecord(targetHabit, date, true, 0)
+			case loopCheckmarkNo:
+				builder.addRecord(targetHabit, date, false, 0)
+			}
+		}
+	}
+
+	return builder.build(), nil
+}
+
+type loopCheckmark struct {
+	date  string
+	value int
+}
+
+// 習慣のフォルダ（"<Position> <習慣名>/"）のCheckmarks.csvを日付順に読み込む
+// NOTE: フォルダが無い場合は記録なしとして扱う
+func readLoopCheckmarks(files map[string]*zip.File, position string, remaining *int64) ([]loopCheckmark, error) {
+	var checkmarksFile *zip.File
+	for name, file := range files {
+		if strings.HasPrefix(name, position+" ") && path.Base(name) == "Checkmarks.csv" {
+			checkmarksFile = file
+			break
+		}
+	}
+	if checkmarksFile == nil {
+		return nil, nil
+	}
+
+	rows, err := readLoopCSV(checkmarksFile, remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	var checkmarks []loopCheckmark
+	for i, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		date := strings.TrimSpace(row[0])
+		if _, err := common.ParseDate(date); err != nil {
+			// ヘッダー行は読み飛ばす
+			if i == 0 {
+				continue
+			}
+			return nil, invalidf("loop: %s: invalid date %s", checkmarksFile.Name, date)
+		}
+		value, err := strconv.Atoi(strings.TrimSpace(row[1]))
+		if err != nil {
+			return nil, invalidf("loop: %s: invalid value on %s", checkmarksFile.Name, date)
+		}
+		checkmarks = append(checkmarks, loopCheckmark{date: date, value: value})
+	}
+
+	// Loopは新しい順に出力するため、日付順に並べ替える
+	sort.Slice(checkmarks, func(i, j int) bool {
+		return checkmarks[i].date < checkmarks[j].date
+	})
+
+	return checkmarks, nil
+}
+
+// zip内のCSVファイルを読み込む
+// 展開後のサイズはファイルごとにMaxImportEntryBytes、合計でremainingまでに制限し、読み込んだ分をremainingから差し引く
+// NOTE: ヘッダーのサイズ（UncompressedSize64）は偽装できるため、実際に読み込むサイズもLimitReaderで制限する
+func readLoopCSV(file *zip.File, remaining *int64) ([][]string, error) {
+	limit := min(int64(config.MaxImportEntryBytes), *remaining)
+	if file.UncompressedSize64 > uint64(limit) {
+		return nil, invalidf("loop: %s is too large", file.Name)
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, invalidf("loop: failed to open %s", file.Name)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, invalidf("loop: failed to read %s", file.Name)
+	}
+	if int64(len(content)) > limit {
+		return nil, invalidf("loop: %s is too large", file.Name)
+	}
+	*remaining -= int64(len(content))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, invalidf("loop: %s: %v", file.Name, err)
+	}
+	return rows, nil
+}
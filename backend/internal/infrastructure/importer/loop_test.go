@@ -0,0 +1,121 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/domain/common"
+)
+
+// 展開後のサイズが上限を超えるファイルは、ヘッダーのサイズを偽装していても読み込まない
+func TestParseLoopRejectsLargeEntries(t *testing.T) {
+	oversized := bytes.Repeat([]byte("0"), config.MaxImportEntryBytes+1)
+
+	tests := []struct {
+		name  string
+		write func(t *testing.T, writer *zip.Writer)
+	}{
+		{"uncompressed size over the limit", func(t *testing.T, writer *zip.Writer) {
+			w, err := writer.Create("Habits.csv")
+			if err != nil {
+				t.Fatalf("failed to create entry: %v", err)
+			}
+			w.Write(oversized)
+		}},
+		{"forged uncompressed size", func(t *testing.T, writer *zip.Writer) {
+			var compressed bytes.Buffer
+			fw, _ := flate.NewWriter(&compressed, flate.BestCompression)
+			fw.Write(oversized)
+			fw.Close()
+
+			w, err := writer.CreateRaw(&zip.FileHeader{
+				Name:               "Habits.csv",
+				Method:             zip.Deflate,
+				CompressedSize64:   uint64(compressed.Len()),
+				UncompressedSize64: 16,
+			})
+			if err != nil {
+				t.Fatalf("failed to create entry: %v", err)
+			}
+			w.Write(compressed.Bytes())
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var archive bytes.Buffer
+			writer := zip.NewWriter(&archive)
+			tt.write(t, writer)
+			if err := writer.Close(); err != nil {
+				t.Fatalf("failed to close zip: %v", err)
+			}
+
+			_, err := ParseLoop(archive.Bytes(), time.UTC)
+			if !errors.Is(err, common.ErrInvalidImport) {
+				t.Fatalf("got error %v, want ErrInvalidImport", err)
+			}
+			if !strings.Contains(err.Error(), "Habits.csv") {
+				t.Errorf("error %q does not mention the entry", err)
+			}
+		})
+	}
+}
+
+// 合計の上限を超える場合は、ファイルごとの上限以内でも読み込まない
+func TestReadLoopCSVTotalLimit(t *testing.T) {
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	w, _ := writer.Create("Habits.csv")
+	w.Write([]byte("Position,Name\n001,読書\n"))
+	writer.Close()
+
+	reader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	if err != nil {
+		t.Fatalf("failed to open zip: %v", err)
+	}
+
+	remaining := int64(100)
+	if _, err = readLoopCSV(reader.File[0], &remaining); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining != 100-int64(len("Position,Name\n001,読書\n")) {
+		t.Errorf("remaining = %d", remaining)
+	}
+
+	remaining = 10
+	if _, err = readLoopCSV(reader.File[0], &remaining); !errors.Is(err, common.ErrInvalidImport) {
+		t.Errorf("got error %v, want ErrInvalidImport", err)
+	}
+}
+
+// 記録の無い習慣の開始日は、ユーザーのタイムゾーンでの今日にする
+func TestParseLoopStartDateInLocation(t *testing.T) {
+	// UTCと日付が異なる時間帯が長いタイムゾーン
+	location := time.FixedZone("UTC+14", 14*60*60)
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	w, err := writer.Create("Habits.csv")
+	if err != nil {
+		t.Fatalf("failed to create entry: %v", err)
+	}
+	w.Write([]byte("Position,Name,FrequencyNumerator,FrequencyDenominator\n001,散歩,1,3\n"))
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+
+	document, err := ParseLoop(archive.Bytes(), location)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := common.FormatDate(time.Now().In(location))
+	if got := document.Habits[0].Schedule.StartDate; got != want {
+		t.Errorf("start date = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,130 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"backend/internal/domain/model/notification"
+)
+
+// テストサーバーが受け取ったリクエスト
+type receivedRequest struct {
+	path   string
+	header http.Header
+	body   []byte
+}
+
+func newTestServer(t *testing.T, status int) (*httptest.Server, <-chan receivedRequest) {
+	t.Helper()
+	received := make(chan receivedRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedRequest{path: r.URL.Path, header: r.Header.Clone(), body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		wantStatus int // 0の場合はエラーにならない
+	}{
+		{"ok", http.StatusOK, 0},
+		{"no content", http.StatusNoContent, 0},
+		{"server error", http.StatusInternalServerError, http.StatusInternalServerError},
+		{"not found", http.StatusNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, received := newTestServer(t, tt.status)
+			message := notification.NewReminderMessage("habit-1", "読書", "2024-01-01")
+			recipient := &notification.Recipient{UserId: "user-1", Settings: notification.Settings{Channel: notification.ChannelWebhook, Address: server.URL + "/hook"}}
+
+			err := NewWebhookNotifier(time.Second).Notify(context.Background(), recipient, message)
+			assertStatusError(t, err, tt.wantStatus)
+
+			request := <-received
+			if request.path != "/hook" {
+				t.Errorf("path = %s, want /hook", request.path)
+			}
+			if got := request.header.Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %s, want application/json", got)
+			}
+			var got notification.Message
+			if err := json.Unmarshal(request.body, &got); err != nil {
+				t.Fatalf("failed to unmarshal body: %v", err)
+			}
+			if got != *message {
+				t.Errorf("body = %+v, want %+v", got, *message)
+			}
+		})
+	}
+}
+
+func TestNtfyNotifier(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		status     int
+		wantAuth   string
+		wantStatus int
+	}{
+		{"without token", "", http.StatusOK, "", 0},
+		{"with token", "secret", http.StatusOK, "Bearer secret", 0},
+		{"forbidden", "secret", http.StatusForbidden, "Bearer secret", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, received := newTestServer(t, tt.status)
+			message := notification.NewReminderMessage("habit-1", "読書", "2024-01-01")
+			recipient := &notification.Recipient{UserId: "user-1", Settings: notification.Settings{Channel: notification.ChannelNtfy, Address: "my-topic"}}
+
+			// 末尾のスラッシュは取り除かれる
+			notifier := NewNtfyNotifier(NtfyConfig{BaseURL: server.URL + "/", Token: tt.token}, time.Second)
+			err := notifier.Notify(context.Background(), recipient, message)
+			assertStatusError(t, err, tt.wantStatus)
+
+			request := <-received
+			if request.path != "/my-topic" {
+				t.Errorf("path = %s, want /my-topic", request.path)
+			}
+			if got := request.header.Get("Authorization"); got != tt.wantAuth {
+				t.Errorf("Authorization = %q, want %q", got, tt.wantAuth)
+			}
+			title, err := new(mime.WordDecoder).DecodeHeader(request.header.Get("Title"))
+			if err != nil || title != message.Title {
+				t.Errorf("Title = %q (%v), want %q", title, err, message.Title)
+			}
+			if string(request.body) != message.Body {
+				t.Errorf("body = %q, want %q", request.body, message.Body)
+			}
+		})
+	}
+}
+
+// wantStatusが0の場合はエラーなし、それ以外はそのステータスコードのstatusError
+func assertStatusError(t *testing.T, err error, wantStatus int) {
+	t.Helper()
+	if wantStatus == 0 {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	var statusErr *statusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != wantStatus {
+		t.Fatalf("got error %v, want status %d", err, wantStatus)
+	}
+}
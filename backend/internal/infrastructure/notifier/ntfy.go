@@ -0,0 +1,52 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"backend/internal/domain/model/notification"
+	"backend/internal/domain/service"
+)
+
+// ntfyサーバーの設定
+type NtfyConfig struct {
+	BaseURL string // 例: https://ntfy.sh
+	Token   string // アクセストークン（不要な場合は空）
+}
+
+// NtfyNotifier はntfy形式のHTTPプッシュ（{BaseURL}/{トピック名}へのPOST）で通知します
+type NtfyNotifier struct {
+	config NtfyConfig
+	client *http.Client
+}
+
+// NewNtfyNotifier は新しいNtfyNotifierインスタンスを作成します
+func NewNtfyNotifier(config NtfyConfig, timeout time.Duration) service.Notifier {
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	return &NtfyNotifier{
+		config: config,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (n *NtfyNotifier) Notify(ctx context.Context, recipient *notification.Recipient, message *notification.Message) error {
+	// NOTE: ヘッダーには日本語をそのまま入れられないため、RFC 2047の形式にする
+	headers := map[string]string{
+		"Content-Type": "text/plain; charset=utf-8",
+		"Title":        mime.QEncoding.Encode("utf-8", message.Title),
+		"Tags":         "bell",
+	}
+	if n.config.Token != "" {
+		headers["Authorization"] = "Bearer " + n.config.Token
+	}
+
+	url := n.config.BaseURL + "/" + recipient.Settings.Address
+	if err := post(ctx, n.client, url, []byte(message.Body), headers); err != nil {
+		return fmt.Errorf("failed to send ntfy notification (user_id: %s): %w", recipient.UserId, err)
+	}
+	return nil
+}
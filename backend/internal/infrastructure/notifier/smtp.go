@@ -0,0 +1,123 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"backend/internal/domain/model/notification"
+	"backend/internal/domain/service"
+)
+
+// SMTPサーバーの設定
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // 認証が不要な場合は空
+	Password string
+	From     string
+}
+
+// SMTPNotifier はメールで通知します
+// NOTE: サーバーが対応している場合はSTARTTLSで暗号化する
+type SMTPNotifier struct {
+	config  SMTPConfig
+	timeout time.Duration
+}
+
+// NewSMTPNotifier は新しいSMTPNotifierインスタンスを作成します
+func NewSMTPNotifier(config SMTPConfig, timeout time.Duration) service.Notifier {
+	return &SMTPNotifier{
+		config:  config,
+		timeout: timeout,
+	}
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, recipient *notification.Recipient, message *notification.Message) error {
+	if err := n.send(ctx, recipient.Settings.Address, n.buildMail(recipient.Settings.Address, message)); err != nil {
+		return fmt.Errorf("failed to send email (user_id: %s): %w", recipient.UserId, err)
+	}
+	return nil
+}
+
+func (n *SMTPNotifier) send(ctx context.Context, to string, mail []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	address := net.JoinHostPort(n.config.Host, strconv.Itoa(n.config.Port))
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, n.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.config.Host}); err != nil {
+			return err
+		}
+	}
+	if n.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(n.config.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(mail); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// 件名・本文をUTF-8で送るため、件名はRFC 2047、本文はbase64でエンコードする
+func (n *SMTPNotifier) buildMail(to string, message *notification.Message) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("From: " + n.config.From + "\r\n")
+	buf.WriteString("To: " + to + "\r\n")
+	buf.WriteString("Subject: " + mime.BEncoding.Encode("utf-8", message.Title) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+	buf.WriteString("\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(message.Body))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+
+	return buf.Bytes()
+}
@@ -0,0 +1,148 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/internal/domain/model/notification"
+)
+
+// テスト用のSMTPサーバーが受け取ったメール
+type receivedMail struct {
+	from string
+	to   []string
+	data string
+}
+
+// 最低限のコマンドのみ応答するSMTPサーバー（STARTTLS・認証には対応しない）
+// rejectRcptがtrueの場合はRCPTを拒否する
+func newStubSMTPServer(t *testing.T, rejectRcpt bool) (string, int, <-chan receivedMail) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan receivedMail, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+		var result receivedMail
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.TrimRight(line, "\r\n")
+			switch verb := strings.ToUpper(strings.SplitN(command, " ", 2)[0]); verb {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "MAIL":
+				result.from = strings.TrimPrefix(command, "MAIL FROM:")
+				reply("250 OK")
+			case "RCPT":
+				if rejectRcpt {
+					reply("550 no such user")
+					continue
+				}
+				result.to = append(result.to, strings.TrimPrefix(command, "RCPT TO:"))
+				reply("250 OK")
+			case "DATA":
+				reply("354 end data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				result.data = data.String()
+				reply("250 OK")
+			case "QUIT":
+				reply("221 bye")
+				received <- result
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	return host, portNumber, received
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	host, port, received := newStubSMTPServer(t, false)
+	message := notification.NewReminderMessage("habit-1", "読書", "2024-01-01")
+	recipient := &notification.Recipient{UserId: "user-1", Settings: notification.Settings{Channel: notification.ChannelEmail, Address: "user@example.com"}}
+
+	notifier := NewSMTPNotifier(SMTPConfig{Host: host, Port: port, From: "habit-tracker@example.com"}, 5*time.Second)
+	if err := notifier.Notify(context.Background(), recipient, message); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got receivedMail
+	select {
+	case got = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("mail was not received")
+	}
+
+	if got.from != "<habit-tracker@example.com>" {
+		t.Errorf("MAIL FROM = %s", got.from)
+	}
+	if len(got.to) != 1 || got.to[0] != "<user@example.com>" {
+		t.Errorf("RCPT TO = %v", got.to)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(got.data))
+	if err != nil {
+		t.Fatalf("failed to parse mail: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != message.Title {
+		t.Errorf("Subject = %q (%v), want %q", subject, err, message.Title)
+	}
+	if got := parsed.Header.Get("To"); got != "user@example.com" {
+		t.Errorf("To = %s", got)
+	}
+	body, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, parsed.Body))
+	if err != nil || string(body) != message.Body {
+		t.Errorf("body = %q (%v), want %q", body, err, message.Body)
+	}
+}
+
+func TestSMTPNotifierRejected(t *testing.T) {
+	host, port, _ := newStubSMTPServer(t, true)
+	recipient := &notification.Recipient{UserId: "user-1", Settings: notification.Settings{Channel: notification.ChannelEmail, Address: "unknown@example.com"}}
+
+	notifier := NewSMTPNotifier(SMTPConfig{Host: host, Port: port, From: "habit-tracker@example.com"}, 5*time.Second)
+	if err := notifier.Notify(context.Background(), recipient, notification.NewReminderMessage("habit-1", "読書", "2024-01-01")); err == nil {
+		t.Fatal("expected error when the recipient is rejected")
+	}
+}
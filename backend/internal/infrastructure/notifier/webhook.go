@@ -0,0 +1,37 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"backend/internal/domain/model/notification"
+	"backend/internal/domain/service"
+)
+
+// WebhookNotifier はユーザーが設定したURLに通知内容をJSONでPOSTします
+type WebhookNotifier struct {
+	client *http.Client
+}
+
+// NewWebhookNotifier は新しいWebhookNotifierインスタンスを作成します
+func NewWebhookNotifier(timeout time.Duration) service.Notifier {
+	return &WebhookNotifier{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, recipient *notification.Recipient, message *notification.Message) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	headers := map[string]string{"Content-Type": "application/json"}
+	if err := post(ctx, n.client, recipient.Settings.Address, body, headers); err != nil {
+		return fmt.Errorf("failed to send webhook (user_id: %s): %w", recipient.UserId, err)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package notifier
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/domain/model/notification"
+	"backend/internal/domain/repository"
+	"backend/internal/domain/service"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// aes128gcmのレコードサイズ（RFC 8188）
+// NOTE: 1レコードのみで送信するため、暗号化後のペイロードはこのサイズ以下である必要がある
+const webPushRecordSize = 4096
+
+// VAPIDのJWTの有効期限（RFC 8292では24時間以内）
+const vapidTokenExpiration = 12 * time.Hour
+
+// WebPushNotifier はユーザーが登録したブラウザの購読全てにWeb Pushで通知します
+// ペイロードはRFC 8291（aes128gcm）で暗号化し、VAPID（RFC 8292）で送信者を証明する
+type WebPushNotifier struct {
+	vapid            *config.VAPID
+	subscriptionRepo repository.PushSubscriptionRepository
+	client           *http.Client
+}
+
+// NewWebPushNotifier は新しいWebPushNotifierインスタンスを作成します
+func NewWebPushNotifier(vapid *config.VAPID, subscriptionRepo repository.PushSubscriptionRepository, timeout time.Duration) service.Notifier {
+	return &WebPushNotifier{
+		vapid:            vapid,
+		subscriptionRepo: subscriptionRepo,
+		client:           &http.Client{Timeout: timeout},
+	}
+}
+
+// NOTE: 購読が1件もない場合は何もしない。一部の購読への送信に失敗した場合も残りには送信する
+func (n *WebPushNotifier) Notify(ctx context.Context, recipient *notification.Recipient, message *notification.Message) error {
+	subscriptions, err := n.subscriptionRepo.FetchByUser(ctx, recipient.UserId)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal web push payload: %w", err)
+	}
+
+	var errs []error
+	for _, subscription := range subscriptions {
+		if err := n.send(ctx, subscription, payload); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send web push notification (user_id: %s, subscription_id: %s): %w", recipient.UserId, subscription.Id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// 1件の購読に送信する
+// プッシュサービスが404・410を返した場合は購読が無効になっているため削除する
+func (n *WebPushNotifier) send(ctx context.Context, subscription *notification.PushSubscription, payload []byte) error {
+	body, err := encryptWebPushPayload(subscription, payload)
+	if err != nil {
+		return err
+	}
+	authorization, err := n.authorization(subscription.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{
+		"Authorization":    authorization,
+		"Content-Encoding": "aes128gcm",
+		"Content-Type":     "application/octet-stream",
+		"TTL":              strconv.Itoa(config.WebPushTTLSecond),
+		"Urgency":          "normal",
+	}
+	err = post(ctx, n.client, subscription.Endpoint, body, headers)
+
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && (statusErr.StatusCode == http.StatusNotFound || statusErr.StatusCode == http.StatusGone) {
+		return n.subscriptionRepo.DeleteByEndpoint(ctx, subscription.Endpoint)
+	}
+	return err
+}
+
+// VAPIDのAuthorizationヘッダー（vapid t=JWT, k=公開鍵）
+// audはプッシュサービスのオリジン
+func (n *WebPushNotifier) authorization(endpoint string) (string, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse endpoint: %w", err)
+	}
+
+	claims := jwt.MapClaims{
+		"aud": parsed.Scheme + "://" + parsed.Host,
+		"exp": time.Now().Add(vapidTokenExpiration).Unix(),
+		"sub": n.vapid.Subject,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(n.vapid.PrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign vapid token: %w", err)
+	}
+
+	return fmt.Sprintf("vapid t=%s, k=%s", token, n.vapid.PublicKey), nil
+}
+
+// ペイロードをRFC 8291の形式で暗号化する
+// 送信ごとに使い捨ての鍵とランダムなsaltを作成する
+func encryptWebPushPayload(subscription *notification.PushSubscription, payload []byte) ([]byte, error) {
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return encryptWebPushRecord(subscription, payload, asPrivate, salt)
+}
+
+// 送信側の鍵（asPrivate）とsaltを指定して暗号化する（RFC 8291のテストベクターで検証できるよう分けている）
+// 戻り値はaes128gcmのヘッダー（salt || rs || idlen || 送信側の公開鍵）と暗号文を連結したもの
+func encryptWebPushRecord(subscription *notification.PushSubscription, payload []byte, asPrivate *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	// ブラウザの公開鍵と認証用シークレット
+	uaPublicBytes, err := notification.DecodeBase64URL(subscription.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode p256dh: %w", err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh: %w", err)
+	}
+	authSecret, err := notification.DecodeBase64URL(subscription.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode auth: %w", err)
+	}
+
+	// ECDHで共有鍵を求める
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+	sharedSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute shared secret: %w", err)
+	}
+
+	// IKM = HKDF(auth_secret, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public, 32)
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublicBytes...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, string(keyInfo), 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive ikm: %w", err)
+	}
+
+	// CEK・NONCEはsaltを使ってIKMから導出する（RFC 8188）
+	if len(salt) != 16 {
+		return nil, fmt.Errorf("invalid salt length: %d", len(salt))
+	}
+	cek, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive cek: %w", err)
+	}
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive nonce: %w", err)
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcm: %w", err)
+	}
+
+	// 最後のレコードの区切り（0x02）を付けて暗号化する
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	if len(plaintext)+gcm.Overhead() > webPushRecordSize {
+		return nil, fmt.Errorf("web push payload too large: %d bytes", len(payload))
+	}
+
+	header := make([]byte, 0, 16+4+1+len(asPublicBytes))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(asPublicBytes)))
+	header = append(header, asPublicBytes...)
+
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
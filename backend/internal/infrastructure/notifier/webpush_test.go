@@ -0,0 +1,257 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/domain/model/notification"
+	"backend/internal/domain/repository"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// RFC 8291 Appendix A のテストベクター
+const (
+	rfc8291Plaintext = "When I grow up, I want to be a watermelon"
+	rfc8291ASPrivate = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+	rfc8291UAPrivate = "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"
+	rfc8291UAPublic  = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	rfc8291Salt      = "DGv6ra1nlYgDCS1FRnbzlw"
+	rfc8291Auth      = "BTBZMqHH6r4Tts7J_aSIgg"
+	rfc8291Body      = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+)
+
+func decodeBase64URL(t *testing.T, value string) []byte {
+	t.Helper()
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		t.Fatalf("failed to decode %q: %v", value, err)
+	}
+	return decoded
+}
+
+// ブラウザ側の復号（RFC 8291）
+func decryptWebPushRecord(t *testing.T, uaPrivate *ecdh.PrivateKey, authSecret []byte, body []byte) []byte {
+	t.Helper()
+
+	salt, recordSize, idLength := body[:16], binary.BigEndian.Uint32(body[16:20]), int(body[20])
+	asPublicBytes, ciphertext := body[21:21+idLength], body[21+idLength:]
+	if recordSize != webPushRecordSize {
+		t.Fatalf("record size = %d, want %d", recordSize, webPushRecordSize)
+	}
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	if err != nil {
+		t.Fatalf("invalid sender public key: %v", err)
+	}
+	sharedSecret, err := uaPrivate.ECDH(asPublic)
+	if err != nil {
+		t.Fatalf("failed to compute shared secret: %v", err)
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), uaPrivate.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm, _ := hkdf.Key(sha256.New, sharedSecret, authSecret, string(keyInfo), 32)
+	cek, _ := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	nonce, _ := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		t.Fatalf("failed to decrypt: %v", err)
+	}
+
+	// 末尾の区切り（0x02）と、その後のパディング（0x00）を取り除く
+	end := bytes.LastIndexByte(plaintext, 0x02)
+	if end < 0 {
+		t.Fatalf("missing padding delimiter")
+	}
+	return plaintext[:end]
+}
+
+func TestEncryptWebPushRecordRFC8291(t *testing.T) {
+	asPrivate, err := ecdh.P256().NewPrivateKey(decodeBase64URL(t, rfc8291ASPrivate))
+	if err != nil {
+		t.Fatalf("invalid sender private key: %v", err)
+	}
+	uaPrivate, err := ecdh.P256().NewPrivateKey(decodeBase64URL(t, rfc8291UAPrivate))
+	if err != nil {
+		t.Fatalf("invalid receiver private key: %v", err)
+	}
+	subscription := &notification.PushSubscription{Endpoint: "https://push.example.net/push/JzLQ3raZJfFBR0aqvOMsLrt54w4rJUsV", P256dh: rfc8291UAPublic, Auth: rfc8291Auth}
+
+	body, err := encryptWebPushRecord(subscription, []byte(rfc8291Plaintext), asPrivate, decodeBase64URL(t, rfc8291Salt))
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+	if got := base64.RawURLEncoding.EncodeToString(body); got != rfc8291Body {
+		t.Errorf("encrypted body mismatch\n got: %s\nwant: %s", got, rfc8291Body)
+	}
+
+	// テストベクターの暗号文を復号できる
+	authSecret := decodeBase64URL(t, rfc8291Auth)
+	if got := decryptWebPushRecord(t, uaPrivate, authSecret, decodeBase64URL(t, rfc8291Body)); string(got) != rfc8291Plaintext {
+		t.Errorf("decrypted = %q, want %q", got, rfc8291Plaintext)
+	}
+
+	// ランダムな鍵・saltで暗号化したものも復号できる
+	body, err = encryptWebPushPayload(subscription, []byte(rfc8291Plaintext))
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+	if got := decryptWebPushRecord(t, uaPrivate, authSecret, body); string(got) != rfc8291Plaintext {
+		t.Errorf("decrypted = %q, want %q", got, rfc8291Plaintext)
+	}
+}
+
+func newTestVAPID(t *testing.T) *config.VAPID {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	privateKey, err := config.NewVAPIDPrivateKey(key.Bytes())
+	if err != nil {
+		t.Fatalf("failed to create vapid key: %v", err)
+	}
+	return &config.VAPID{
+		PrivateKey: privateKey,
+		PublicKey:  base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		Subject:    "mailto:admin@example.com",
+	}
+}
+
+// AuthorizationヘッダーのJWTが、k=の公開鍵（ES256）で検証できる
+func TestVAPIDAuthorization(t *testing.T) {
+	vapid := newTestVAPID(t)
+	notifier := NewWebPushNotifier(vapid, nil, time.Second).(*WebPushNotifier)
+
+	authorization, err := notifier.authorization("https://push.example.net:8443/push/abc?x=1")
+	if err != nil {
+		t.Fatalf("failed to create authorization: %v", err)
+	}
+
+	var token, key string
+	for _, part := range strings.Split(strings.TrimPrefix(authorization, "vapid "), ", ") {
+		switch {
+		case strings.HasPrefix(part, "t="):
+			token = strings.TrimPrefix(part, "t=")
+		case strings.HasPrefix(part, "k="):
+			key = strings.TrimPrefix(part, "k=")
+		}
+	}
+	if !strings.HasPrefix(authorization, "vapid ") || token == "" || key != vapid.PublicKey {
+		t.Fatalf("unexpected authorization header: %s", authorization)
+	}
+
+	publicBytes := decodeBase64URL(t, key)
+	publicKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(publicBytes[1:33]), Y: new(big.Int).SetBytes(publicBytes[33:])}
+
+	claims := jwt.MapClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodES256 {
+			t.Errorf("alg = %v, want ES256", token.Header["alg"])
+		}
+		return publicKey, nil
+	})
+	if err != nil || !parsed.Valid {
+		t.Fatalf("invalid signature: %v", err)
+	}
+
+	if claims["aud"] != "https://push.example.net:8443" {
+		t.Errorf("aud = %v", claims["aud"])
+	}
+	if claims["sub"] != vapid.Subject {
+		t.Errorf("sub = %v", claims["sub"])
+	}
+	expiresAt := time.Unix(int64(claims["exp"].(float64)), 0)
+	if !expiresAt.After(time.Now()) || expiresAt.After(time.Now().Add(24*time.Hour)) {
+		t.Errorf("exp = %v, want within 24 hours", expiresAt)
+	}
+
+	// 別の鍵では検証できない
+	otherKey := newTestVAPID(t).PrivateKey.PublicKey
+	if _, err := jwt.Parse(token, func(*jwt.Token) (interface{}, error) { return &otherKey, nil }); err == nil {
+		t.Error("token verified with a different key")
+	}
+}
+
+// 購読を返し、削除されたエンドポイントを記録する
+type fakePushSubscriptionRepo struct {
+	repository.PushSubscriptionRepository
+	subscriptions []*notification.PushSubscription
+	deleted       []string
+}
+
+func (r *fakePushSubscriptionRepo) FetchByUser(ctx context.Context, userId string) ([]*notification.PushSubscription, error) {
+	return r.subscriptions, nil
+}
+
+func (r *fakePushSubscriptionRepo) DeleteByEndpoint(ctx context.Context, endpoint string) error {
+	r.deleted = append(r.deleted, endpoint)
+	return nil
+}
+
+// プッシュサービスが404・410を返した購読は削除する
+func TestWebPushNotifierPrunesExpiredSubscriptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		wantDeleted bool
+		wantErr     bool
+	}{
+		{"created", http.StatusCreated, false, false},
+		{"not found", http.StatusNotFound, true, false},
+		{"gone", http.StatusGone, true, false},
+		{"server error", http.StatusInternalServerError, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, received := newTestServer(t, tt.status)
+			endpoint := server.URL + "/push/subscription-1"
+			repo := &fakePushSubscriptionRepo{subscriptions: []*notification.PushSubscription{
+				{Id: "subscription-1", UserId: "user-1", Endpoint: endpoint, P256dh: rfc8291UAPublic, Auth: rfc8291Auth},
+			}}
+			recipient := &notification.Recipient{UserId: "user-1", Settings: notification.Settings{Channel: notification.ChannelWebPush}}
+
+			err := NewWebPushNotifier(newTestVAPID(t), repo, time.Second).Notify(context.Background(), recipient, notification.NewAchievementMessage("初めの一歩", "初めて習慣を完了しました"))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, wantErr %v", err, tt.wantErr)
+			}
+
+			request := <-received
+			if got := request.header.Get("Content-Encoding"); got != "aes128gcm" {
+				t.Errorf("Content-Encoding = %s, want aes128gcm", got)
+			}
+			if !strings.HasPrefix(request.header.Get("Authorization"), "vapid t=") {
+				t.Errorf("Authorization = %s", request.header.Get("Authorization"))
+			}
+
+			if tt.wantDeleted {
+				if len(repo.deleted) != 1 || repo.deleted[0] != endpoint {
+					t.Errorf("deleted = %v, want [%s]", repo.deleted, endpoint)
+				}
+			} else if len(repo.deleted) > 0 {
+				t.Errorf("deleted = %v, want none", repo.deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/achievement"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DBに保存するための内部モデル
+type badgeDB struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	UserId      string             `bson:"user_id"`
+	Code        string             `bson:"code"`
+	Name        string             `bson:"name"`
+	Description string             `bson:"description"`
+	UnlockedAt  time.Time          `bson:"unlocked_at"`
+}
+
+// AchievementRepository はMongoDBのachievementsコレクションにアクセスします
+type AchievementRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAchievementRepository は新しいAchievementRepositoryインスタンスを作成します
+func NewAchievementRepository(collection *mongo.Collection) repository.AchievementRepository {
+	return &AchievementRepository{
+		collection: collection,
+	}
+}
+
+// 獲得したバッジを獲得順に全件取得
+func (r *AchievementRepository) FetchAll(ctx context.Context, userId string) ([]*achievement.Badge, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "unlocked_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(timeoutCtx, bson.M{"user_id": userId}, opts)
+	if err != nil {
+		log.Printf("[ERROR] AchievementRepository.FetchAll() failed to collection.Find (user_id: %s): %v", userId, err)
+		return nil, fmt.Errorf("failed to fetch badges: %w", err)
+	}
+
+	var badgeDBs []badgeDB
+	if err = cursor.All(timeoutCtx, &badgeDBs); err != nil {
+		log.Printf("[ERROR] AchievementRepository.FetchAll() failed to cursor.All : %v", err)
+		return nil, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	badges := make([]*achievement.Badge, 0, len(badgeDBs))
+	for _, badgeDB := range badgeDBs {
+		badges = append(badges, convertToBadge(&badgeDB))
+	}
+
+	return badges, nil
+}
+
+// バッジの登録（獲得済みの場合はErrAlreadyExists）
+// NOTE: user_id・codeのユニークインデックスにより、同時に判定しても重複して登録されない
+func (r *AchievementRepository) Register(ctx context.Context, badge *achievement.Badge) (*achievement.Badge, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if badge.UnlockedAt.IsZero() {
+		badge.UnlockedAt = time.Now()
+	}
+
+	badgeDB := badgeDB{
+		UserId:      badge.UserId,
+		Code:        badge.Code,
+		Name:        badge.Name,
+		Description: badge.Description,
+		UnlockedAt:  badge.UnlockedAt,
+	}
+
+	result, err := r.collection.InsertOne(timeoutCtx, badgeDB)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, common.ErrAlreadyExists
+		}
+		log.Printf("[ERROR] AchievementRepository.Register() failed to collection.InsertOne (data: %+v) : %v", badgeDB, err)
+		return nil, fmt.Errorf("failed to register badge: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		badge.Id = oid.Hex()
+	}
+
+	return badge, nil
+}
+
+// ユーザーのバッジを全て削除（アカウント削除時に使用）
+func (r *AchievementRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] AchievementRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete badges: %w", err)
+	}
+
+	return nil
+}
+
+// DBモデルをドメインモデルに変換
+func convertToBadge(badgeDB *badgeDB) *achievement.Badge {
+	return &achievement.Badge{
+		Id:          badgeDB.ID.Hex(),
+		UserId:      badgeDB.UserId,
+		Code:        badgeDB.Code,
+		Name:        badgeDB.Name,
+		Description: badgeDB.Description,
+		UnlockedAt:  badgeDB.UnlockedAt,
+	}
+}
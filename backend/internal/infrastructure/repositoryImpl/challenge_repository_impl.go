@@ -0,0 +1,401 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/challenge"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DBに保存するための内部モデル
+type challengeDB struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	OwnerId      string             `bson:"owner_id"`
+	Name         string             `bson:"name"`
+	StartDate    string             `bson:"start_date"`
+	EndDate      string             `bson:"end_date"`
+	TargetDays   int                `bson:"target_days"`
+	BonusPoints  int                `bson:"bonus_points"`
+	Participants []participantDB    `bson:"participants"`
+	CreatedAt    time.Time          `bson:"created_at"`
+}
+
+type participantDB struct {
+	UserId      string     `bson:"user_id"`
+	HabitId     string     `bson:"habit_id,omitempty"`
+	Status      string     `bson:"status"`
+	InvitedBy   string     `bson:"invited_by,omitempty"`
+	JoinedAt    *time.Time `bson:"joined_at,omitempty"`
+	CompletedAt *time.Time `bson:"completed_at,omitempty"`
+}
+
+// ChallengeRepository はMongoDBのchallengesコレクションにアクセスします
+// NOTE: 参加者はチャレンジのドキュメントに埋め込む
+type ChallengeRepository struct {
+	collection *mongo.Collection
+}
+
+// NewChallengeRepository は新しいChallengeRepositoryインスタンスを作成します
+func NewChallengeRepository(collection *mongo.Collection) repository.ChallengeRepository {
+	return &ChallengeRepository{
+		collection: collection,
+	}
+}
+
+// 招待中・参加中のチャレンジを取得（開始日の新しい順）
+func (r *ChallengeRepository) FetchByUser(ctx context.Context, userId string) ([]*challenge.Challenge, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "start_date", Value: -1}, {Key: "_id", Value: -1}})
+	return r.fetch(ctx, "FetchByUser", bson.M{"participants.user_id": userId}, opts)
+}
+
+// 指定した習慣で参加中の、指定日が期間内のチャレンジを取得
+func (r *ChallengeRepository) FetchJoinedByHabit(ctx context.Context, userId string, habitId string, date string) ([]*challenge.Challenge, error) {
+	filter := bson.M{
+		"participants": bson.M{"$elemMatch": bson.M{
+			"user_id":  userId,
+			"habit_id": habitId,
+			"status":   string(challenge.StatusJoined),
+		}},
+		"start_date": bson.M{"$lte": date},
+		"end_date":   bson.M{"$gte": date},
+	}
+	return r.fetch(ctx, "FetchJoinedByHabit", filter, options.Find())
+}
+
+func (r *ChallengeRepository) fetch(ctx context.Context, method string, filter bson.M, opts *options.FindOptions) ([]*challenge.Challenge, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(timeoutCtx, filter, opts)
+	if err != nil {
+		log.Printf("[ERROR] ChallengeRepository.%s() failed to collection.Find (filter: %+v): %v", method, filter, err)
+		return nil, fmt.Errorf("failed to fetch challenges: %w", err)
+	}
+
+	var challengeDBs []challengeDB
+	if err = cursor.All(timeoutCtx, &challengeDBs); err != nil {
+		log.Printf("[ERROR] ChallengeRepository.%s() failed to cursor.All : %v", method, err)
+		return nil, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	challenges := make([]*challenge.Challenge, 0, len(challengeDBs))
+	for _, challengeDB := range challengeDBs {
+		challenges = append(challenges, convertToChallenge(&challengeDB))
+	}
+
+	return challenges, nil
+}
+
+// 招待・参加しているチャレンジを取得（それ以外のチャレンジはErrNotFound）
+func (r *ChallengeRepository) Find(ctx context.Context, userId string, id string) (*challenge.Challenge, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	var challengeDB challengeDB
+	err = r.collection.FindOne(timeoutCtx, bson.M{"_id": objectID, "participants.user_id": userId}).Decode(&challengeDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, common.ErrNotFound
+		}
+		log.Printf("[ERROR] ChallengeRepository.Find() failed to collection.FindOne (_id: %s, user_id: %s) : %v", id, userId, err)
+		return nil, fmt.Errorf("failed to find challenge: %w", err)
+	}
+
+	return convertToChallenge(&challengeDB), nil
+}
+
+// チャレンジの登録
+func (r *ChallengeRepository) Register(ctx context.Context, challenge *challenge.Challenge) (*challenge.Challenge, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if challenge.CreatedAt.IsZero() {
+		challenge.CreatedAt = time.Now()
+	}
+
+	participantDBs := make([]participantDB, 0, len(challenge.Participants))
+	for _, participant := range challenge.Participants {
+		participantDBs = append(participantDBs, convertToParticipantDB(participant))
+	}
+
+	challengeDB := challengeDB{
+		OwnerId:      challenge.OwnerId,
+		Name:         challenge.Name,
+		StartDate:    challenge.StartDate,
+		EndDate:      challenge.EndDate,
+		TargetDays:   challenge.TargetDays,
+		BonusPoints:  challenge.BonusPoints,
+		Participants: participantDBs,
+		CreatedAt:    challenge.CreatedAt,
+	}
+
+	result, err := r.collection.InsertOne(timeoutCtx, challengeDB)
+	if err != nil {
+		log.Printf("[ERROR] ChallengeRepository.Register() failed to collection.InsertOne (data: %+v) : %v", challengeDB, err)
+		return nil, fmt.Errorf("failed to register challenge: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		challenge.Id = oid.Hex()
+	}
+
+	return challenge, nil
+}
+
+// 参加者の追加（招待済み・参加済みの場合はErrAlreadyExists）
+func (r *ChallengeRepository) AddParticipant(ctx context.Context, id string, participant *challenge.Participant) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	// 同じユーザーが含まれていない場合のみ追加する
+	filter := bson.M{"_id": objectID, "participants.user_id": bson.M{"$ne": participant.UserId}}
+	update := bson.M{"$push": bson.M{"participants": convertToParticipantDB(participant)}}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] ChallengeRepository.AddParticipant() failed to collection.UpdateOne (_id: %s, user_id: %s) : %v", id, participant.UserId, err)
+		return fmt.Errorf("failed to add participant: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return r.existenceError(timeoutCtx, objectID, common.ErrAlreadyExists)
+	}
+
+	return nil
+}
+
+// 参加者の更新（参加する習慣・状態）
+func (r *ChallengeRepository) UpdateParticipant(ctx context.Context, id string, participant *challenge.Participant) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objectID, "participants.user_id": participant.UserId}
+	update := bson.M{
+		"$set": bson.M{
+			"participants.$.habit_id":  participant.HabitId,
+			"participants.$.status":    string(participant.Status),
+			"participants.$.joined_at": participant.JoinedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] ChallengeRepository.UpdateParticipant() failed to collection.UpdateOne (_id: %s, user_id: %s) : %v", id, participant.UserId, err)
+		return fmt.Errorf("failed to update participant: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// 参加者の削除（辞退・退出）
+func (r *ChallengeRepository) RemoveParticipant(ctx context.Context, id string, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objectID, "participants.user_id": userId}
+	update := bson.M{"$pull": bson.M{"participants": bson.M{"user_id": userId}}}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] ChallengeRepository.RemoveParticipant() failed to collection.UpdateOne (_id: %s, user_id: %s) : %v", id, userId, err)
+		return fmt.Errorf("failed to remove participant: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// 参加者が紐づけた習慣を外し、招待中に戻す（習慣の完全削除時に使用）
+// NOTE: 参加者は別の習慣で参加し直せる。付与済みの成功ボーナスは取り消さない
+func (r *ChallengeRepository) UnlinkHabit(ctx context.Context, userId string, habitId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"participants": bson.M{"$elemMatch": bson.M{"user_id": userId, "habit_id": habitId}}}
+	update := bson.M{
+		"$set":   bson.M{"participants.$[participant].status": string(challenge.StatusInvited)},
+		"$unset": bson.M{"participants.$[participant].habit_id": ""},
+	}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"participant.user_id": userId, "participant.habit_id": habitId}},
+	})
+
+	_, err := r.collection.UpdateMany(timeoutCtx, filter, update, opts)
+	if err != nil {
+		log.Printf("[ERROR] ChallengeRepository.UnlinkHabit() failed to collection.UpdateMany (user_id: %s, habit_id: %s) : %v", userId, habitId, err)
+		return fmt.Errorf("failed to unlink habit from challenges: %w", err)
+	}
+
+	return nil
+}
+
+// 参加者の成功（ボーナス付与済み）を記録・取り消しする
+// すでに同じ状態の場合はfalseを返す（ボーナスを二重に付与・取り消ししないため）
+func (r *ChallengeRepository) MarkCompleted(ctx context.Context, id string, userId string, completed bool) (bool, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, common.ErrNotFound
+	}
+
+	filter := bson.M{
+		"_id": objectID,
+		"participants": bson.M{"$elemMatch": bson.M{
+			"user_id":      userId,
+			"completed_at": bson.M{"$exists": !completed},
+		}},
+	}
+	update := bson.M{"$set": bson.M{"participants.$.completed_at": time.Now()}}
+	if !completed {
+		update = bson.M{"$unset": bson.M{"participants.$.completed_at": ""}}
+	}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] ChallengeRepository.MarkCompleted() failed to collection.UpdateOne (_id: %s, user_id: %s, completed: %t) : %v", id, userId, completed, err)
+		return false, fmt.Errorf("failed to mark challenge completed: %w", err)
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// 作成したチャレンジの削除（それ以外のチャレンジはErrNotFound）
+func (r *ChallengeRepository) Delete(ctx context.Context, ownerId string, id string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	result, err := r.collection.DeleteOne(timeoutCtx, bson.M{"_id": objectID, "owner_id": ownerId})
+	if err != nil {
+		log.Printf("[ERROR] ChallengeRepository.Delete() failed to collection.DeleteOne (_id: %s, owner_id: %s) : %v", id, ownerId, err)
+		return fmt.Errorf("failed to delete challenge: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// ユーザーが作成したチャレンジを削除し、他のチャレンジからも退出する（アカウント削除時に使用）
+func (r *ChallengeRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"owner_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] ChallengeRepository.DeleteAll() failed to collection.DeleteMany (owner_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete challenges: %w", err)
+	}
+
+	filter := bson.M{"participants.user_id": userId}
+	update := bson.M{"$pull": bson.M{"participants": bson.M{"user_id": userId}}}
+	_, err = r.collection.UpdateMany(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] ChallengeRepository.DeleteAll() failed to collection.UpdateMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to remove participant: %w", err)
+	}
+
+	return nil
+}
+
+// 更新対象が見つからなかった場合のエラー
+// チャレンジが存在する場合はexistsErrを、存在しない場合はErrNotFoundを返す
+func (r *ChallengeRepository) existenceError(ctx context.Context, objectID primitive.ObjectID, existsErr error) error {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		log.Printf("[ERROR] ChallengeRepository.existenceError() failed to collection.CountDocuments (_id: %s) : %v", objectID.Hex(), err)
+		return fmt.Errorf("failed to check challenge existence: %w", err)
+	}
+	if count > 0 {
+		return existsErr
+	}
+	return common.ErrNotFound
+}
+
+// ドメインモデルをDBモデルに変換
+func convertToParticipantDB(participant *challenge.Participant) participantDB {
+	return participantDB{
+		UserId:      participant.UserId,
+		HabitId:     participant.HabitId,
+		Status:      string(participant.Status),
+		InvitedBy:   participant.InvitedBy,
+		JoinedAt:    participant.JoinedAt,
+		CompletedAt: participant.CompletedAt,
+	}
+}
+
+// DBモデルをドメインモデルに変換
+func convertToChallenge(challengeDB *challengeDB) *challenge.Challenge {
+	participants := make([]*challenge.Participant, 0, len(challengeDB.Participants))
+	for _, participantDB := range challengeDB.Participants {
+		participants = append(participants, &challenge.Participant{
+			UserId:      participantDB.UserId,
+			HabitId:     participantDB.HabitId,
+			Status:      challenge.ParticipantStatus(participantDB.Status),
+			InvitedBy:   participantDB.InvitedBy,
+			JoinedAt:    participantDB.JoinedAt,
+			CompletedAt: participantDB.CompletedAt,
+		})
+	}
+
+	return &challenge.Challenge{
+		Id:           challengeDB.ID.Hex(),
+		OwnerId:      challengeDB.OwnerId,
+		Name:         challengeDB.Name,
+		StartDate:    challengeDB.StartDate,
+		EndDate:      challengeDB.EndDate,
+		TargetDays:   challengeDB.TargetDays,
+		BonusPoints:  challengeDB.BonusPoints,
+		Participants: participants,
+		CreatedAt:    challengeDB.CreatedAt,
+	}
+}
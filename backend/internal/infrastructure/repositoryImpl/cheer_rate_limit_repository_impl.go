@@ -0,0 +1,57 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CheerRateLimitRepository はMongoDBのcheer_rate_limitsコレクションにアクセスします
+// NOTE: ユーザー・期間ごとに応援の件数を数えるドキュメントを1件持つ（期限切れのドキュメントはTTLインデックスで削除する）
+type CheerRateLimitRepository struct {
+	collection *mongo.Collection
+}
+
+// NewCheerRateLimitRepository は新しいCheerRateLimitRepositoryインスタンスを作成します
+func NewCheerRateLimitRepository(collection *mongo.Collection) repository.CheerRateLimitRepository {
+	return &CheerRateLimitRepository{
+		collection: collection,
+	}
+}
+
+// 期間内の件数を1つ増やす（上限に達している場合は増やさずにfalseを返す）
+// NOTE: 件数が上限未満の場合のみ一致するフィルタで加算するため、同時リクエストでも上限を超えない
+// 上限に達している場合は、upsertが (user_id, window) のユニークインデックスで重複エラーになる
+func (r *CheerRateLimitRepository) Increment(ctx context.Context, userId string, window time.Time, expiresAt time.Time, limit int) (bool, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userId, "window": window, "count": bson.M{"$lt": limit}}
+	update := bson.M{
+		"$inc":         bson.M{"count": 1},
+		"$setOnInsert": bson.M{"expires_at": expiresAt},
+	}
+
+	_, err := r.collection.UpdateOne(timeoutCtx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		log.Printf("[ERROR] CheerRateLimitRepository.Increment() failed to collection.UpdateOne (user_id: %s, window: %v) : %v", userId, window, err)
+		return false, fmt.Errorf("failed to increment cheer count: %w", err)
+	}
+
+	return true, nil
+}
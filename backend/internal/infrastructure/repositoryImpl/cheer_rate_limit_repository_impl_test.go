@@ -0,0 +1,49 @@
+package repositoryImpl
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 同時に加算しても、期間内に許可されるのは上限の件数まで
+func TestCheerRateLimitRepositoryIncrement(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCheerRateLimitRepository(newTestDatabase(t).Collection("cheer_rate_limits"))
+
+	const limit, requests = 5, 20
+	window := time.Now().Truncate(time.Minute)
+
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := repo.Increment(ctx, "user-1", window, window.Add(time.Minute), limit)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if ok {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := allowed.Load(); got != limit {
+		t.Errorf("allowed %d requests, want %d", got, limit)
+	}
+
+	// 次の期間・別のユーザーは数え直す
+	for _, tt := range []struct {
+		userId string
+		window time.Time
+	}{{"user-1", window.Add(time.Minute)}, {"user-2", window}} {
+		if ok, err := repo.Increment(ctx, tt.userId, tt.window, tt.window.Add(time.Minute), limit); err != nil || !ok {
+			t.Errorf("%s at %v: allowed %v, err %v (want true, nil)", tt.userId, tt.window, ok, err)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/feed"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DBに保存するための内部モデル
+type cheerDB struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	ItemId      string             `bson:"item_id"`
+	ItemOwnerId string             `bson:"item_owner_id"`
+	HabitId     string             `bson:"habit_id"`
+	Date        string             `bson:"date"`
+	UserId      string             `bson:"user_id"`
+	Kind        string             `bson:"kind"`
+	Emoji       string             `bson:"emoji,omitempty"`
+	Body        string             `bson:"body,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at"`
+}
+
+// CheerRepository はMongoDBのcheersコレクションにアクセスします
+// NOTE: リアクションはフィード・ユーザー・絵文字の組ごとに1件（ユニークインデックス）
+type CheerRepository struct {
+	collection *mongo.Collection
+}
+
+// NewCheerRepository は新しいCheerRepositoryインスタンスを作成します
+func NewCheerRepository(collection *mongo.Collection) repository.CheerRepository {
+	return &CheerRepository{
+		collection: collection,
+	}
+}
+
+// 応援したか、フィードの持ち主である応援を取得（それ以外の応援はErrNotFound）
+func (r *CheerRepository) Find(ctx context.Context, userId string, id string) (*feed.Cheer, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	var cheerDB cheerDB
+	err = r.collection.FindOne(timeoutCtx, cheerOwnerFilter(objectID, userId)).Decode(&cheerDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, common.ErrNotFound
+		}
+		log.Printf("[ERROR] CheerRepository.Find() failed to collection.FindOne (_id: %s, user_id: %s) : %v", id, userId, err)
+		return nil, fmt.Errorf("failed to find cheer: %w", err)
+	}
+
+	return convertToCheer(&cheerDB), nil
+}
+
+// 指定したフィードへの応援を古い順に取得
+func (r *CheerRepository) FetchByItems(ctx context.Context, itemIds []string) ([]*feed.Cheer, error) {
+	if len(itemIds) == 0 {
+		return make([]*feed.Cheer, 0), nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(timeoutCtx, bson.M{"item_id": bson.M{"$in": itemIds}}, opts)
+	if err != nil {
+		log.Printf("[ERROR] CheerRepository.FetchByItems() failed to collection.Find (item_ids: %v): %v", itemIds, err)
+		return nil, fmt.Errorf("failed to fetch cheers: %w", err)
+	}
+
+	var cheerDBs []cheerDB
+	if err = cursor.All(timeoutCtx, &cheerDBs); err != nil {
+		log.Printf("[ERROR] CheerRepository.FetchByItems() failed to cursor.All : %v", err)
+		return nil, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	cheers := make([]*feed.Cheer, 0, len(cheerDBs))
+	for _, cheerDB := range cheerDBs {
+		cheers = append(cheers, convertToCheer(&cheerDB))
+	}
+
+	return cheers, nil
+}
+
+// 応援の登録（同じ絵文字でリアクション済みの場合はErrAlreadyExists）
+func (r *CheerRepository) Register(ctx context.Context, cheer *feed.Cheer) (*feed.Cheer, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if cheer.CreatedAt.IsZero() {
+		cheer.CreatedAt = time.Now()
+	}
+
+	cheerDB := cheerDB{
+		ItemId:      cheer.ItemId,
+		ItemOwnerId: cheer.ItemOwnerId,
+		HabitId:     cheer.HabitId,
+		Date:        cheer.Date,
+		UserId:      cheer.UserId,
+		Kind:        string(cheer.Kind),
+		Emoji:       string(cheer.Emoji),
+		Body:        cheer.Body,
+		CreatedAt:   cheer.CreatedAt,
+	}
+
+	result, err := r.collection.InsertOne(timeoutCtx, cheerDB)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, common.ErrAlreadyExists
+		}
+		log.Printf("[ERROR] CheerRepository.Register() failed to collection.InsertOne (data: %+v) : %v", cheerDB, err)
+		return nil, fmt.Errorf("failed to register cheer: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		cheer.Id = oid.Hex()
+	}
+
+	return cheer, nil
+}
+
+// 応援の削除（応援したユーザーと、フィードの持ち主のみ）
+func (r *CheerRepository) Delete(ctx context.Context, userId string, id string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	result, err := r.collection.DeleteOne(timeoutCtx, cheerOwnerFilter(objectID, userId))
+	if err != nil {
+		log.Printf("[ERROR] CheerRepository.Delete() failed to collection.DeleteOne (_id: %s, user_id: %s) : %v", id, userId, err)
+		return fmt.Errorf("failed to delete cheer: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// リアクションの取り消し（リアクションしていない場合は何もしない）
+func (r *CheerRepository) DeleteReaction(ctx context.Context, itemId string, userId string, emoji feed.Emoji) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"item_id": itemId, "user_id": userId, "kind": string(feed.KindReaction), "emoji": string(emoji)}
+	_, err := r.collection.DeleteOne(timeoutCtx, filter)
+	if err != nil {
+		log.Printf("[ERROR] CheerRepository.DeleteReaction() failed to collection.DeleteOne (filter: %+v) : %v", filter, err)
+		return fmt.Errorf("failed to delete reaction: %w", err)
+	}
+
+	return nil
+}
+
+// 完了の取り消し時に、そのフィードへの応援を全て削除
+func (r *CheerRepository) DeleteByCompletion(ctx context.Context, ownerId string, habitId string, date string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"item_owner_id": ownerId, "habit_id": habitId, "date": date})
+	if err != nil {
+		log.Printf("[ERROR] CheerRepository.DeleteByCompletion() failed to collection.DeleteMany (item_owner_id: %s, habit_id: %s, date: %s) : %v", ownerId, habitId, date, err)
+		return fmt.Errorf("failed to delete cheers: %w", err)
+	}
+
+	return nil
+}
+
+// 習慣の完全削除時に、その習慣のフィードへの応援を全て削除
+func (r *CheerRepository) DeleteByHabit(ctx context.Context, ownerId string, habitId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"item_owner_id": ownerId, "habit_id": habitId})
+	if err != nil {
+		log.Printf("[ERROR] CheerRepository.DeleteByHabit() failed to collection.DeleteMany (item_owner_id: %s, habit_id: %s) : %v", ownerId, habitId, err)
+		return fmt.Errorf("failed to delete cheers: %w", err)
+	}
+
+	return nil
+}
+
+// ユーザーが送った・受け取った応援を全て削除（アカウント削除時に使用）
+func (r *CheerRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"$or": []bson.M{{"user_id": userId}, {"item_owner_id": userId}}}
+	_, err := r.collection.DeleteMany(timeoutCtx, filter)
+	if err != nil {
+		log.Printf("[ERROR] CheerRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete cheers: %w", err)
+	}
+
+	return nil
+}
+
+// userIdが応援したか、フィードの持ち主である応援のフィルター
+func cheerOwnerFilter(id primitive.ObjectID, userId string) bson.M {
+	return bson.M{"_id": id, "$or": []bson.M{{"user_id": userId}, {"item_owner_id": userId}}}
+}
+
+// DBモデルをドメインモデルに変換
+func convertToCheer(cheerDB *cheerDB) *feed.Cheer {
+	return &feed.Cheer{
+		Id:          cheerDB.ID.Hex(),
+		ItemId:      cheerDB.ItemId,
+		ItemOwnerId: cheerDB.ItemOwnerId,
+		HabitId:     cheerDB.HabitId,
+		Date:        cheerDB.Date,
+		UserId:      cheerDB.UserId,
+		Kind:        feed.Kind(cheerDB.Kind),
+		Emoji:       feed.Emoji(cheerDB.Emoji),
+		Body:        cheerDB.Body,
+		CreatedAt:   cheerDB.CreatedAt,
+	}
+}
@@ -17,18 +17,23 @@ import (
 
 	"backend/internal/domain/common"
 	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
 	"backend/internal/domain/repository"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // DBに保存するための内部モデル
 type habitStatusDB struct {
-	HabitId   string `bson:"habit_id"`
-	HabitName string `bson:"habit_name"`
-	IsDone    bool   `bson:"is_done"`
+	HabitId      string     `bson:"habit_id"`
+	HabitName    string     `bson:"habit_name"`
+	IsDone       bool       `bson:"is_done"`
+	WeeklyTarget int        `bson:"weekly_target,omitempty"`
+	Measure      *measureDB `bson:"measure,omitempty"`
+	Value        float64    `bson:"value,omitempty"`
 }
 type dailyTrackDB struct {
 	ID            primitive.ObjectID `bson:"_id,omitempty"`
@@ -59,7 +64,7 @@ func (r *DailyTrackRepository) FindDailyTrack(ctx context.Context, userId string
 		if err == mongo.ErrNoDocuments {
 			return nil, common.ErrNotFound
 		}
-		log.Printf("[ERROR] DailyTrackRepository.FindDailyTrack() failed to collection.FindOne (user_id: %s, date: %s): %w", userId, targetDate, err)
+		log.Printf("[ERROR] DailyTrackRepository.FindDailyTrack() failed to collection.FindOne (user_id: %s, date: %s): %v", userId, targetDate, err)
 		return nil, fmt.Errorf("failed to find daily_track: %w", err)
 	}
 
@@ -68,6 +73,121 @@ func (r *DailyTrackRepository) FindDailyTrack(ctx context.Context, userId string
 	return dailyTrack, nil
 }
 
+// 期間内（fromDate〜toDate、両端含む）のdaily_trackを日付の昇順で取得
+func (r *DailyTrackRepository) FindDailyTracks(ctx context.Context, userId string, fromDate string, toDate string) ([]*daily_track.DailyTrack, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// NOTE: dateはYYYY-MM-DD形式の文字列なので文字列比較で範囲検索できる
+	filter := bson.M{
+		"user_id": userId,
+		"date":    bson.M{"$gte": fromDate, "$lte": toDate},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+
+	cursor, err := r.collection.Find(timeoutCtx, filter, opts)
+	if err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.FindDailyTracks() failed to collection.Find (user_id: %s, from: %s, to: %s): %v", userId, fromDate, toDate, err)
+		return nil, fmt.Errorf("failed to find daily_tracks: %w", err)
+	}
+
+	var dailyTrackDBs []dailyTrackDB
+	if err = cursor.All(timeoutCtx, &dailyTrackDBs); err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.FindDailyTracks() failed to cursor.All : %v", err)
+		return nil, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	var dailyTracks []*daily_track.DailyTrack
+	for _, dailyTrackDB := range dailyTrackDBs {
+		dailyTracks = append(dailyTracks, convertToDailyTrack(&dailyTrackDB))
+	}
+
+	return dailyTracks, nil
+}
+
+// 指定日より前に、いずれかの習慣を含むdaily_trackがあるかどうか
+func (r *DailyTrackRepository) ExistsHabitStatusBefore(ctx context.Context, userId string, habitIds []string, beforeDate string) (bool, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"user_id":                 userId,
+		"date":                    bson.M{"$lt": beforeDate},
+		"habit_statuses.habit_id": bson.M{"$in": habitIds},
+	}
+	count, err := r.collection.CountDocuments(timeoutCtx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.ExistsHabitStatusBefore() failed to collection.CountDocuments (user_id: %s, before: %s) : %v", userId, beforeDate, err)
+		return false, fmt.Errorf("failed to count daily tracks: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// 指定日までに完了した習慣の延べ数
+// NOTE: daily_trackをデコードせずに集計する
+func (r *DailyTrackRepository) CountDone(ctx context.Context, userId string, toDate string) (int, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userId, "date": bson.M{"$lte": toDate}}}},
+		{{Key: "$unwind", Value: "$habit_statuses"}},
+		{{Key: "$match", Value: bson.M{"habit_statuses.is_done": true}}},
+		{{Key: "$count", Value: "total"}},
+	}
+
+	cursor, err := r.collection.Aggregate(timeoutCtx, pipeline)
+	if err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.CountDone() failed to collection.Aggregate (user_id: %s, to: %s) : %v", userId, toDate, err)
+		return 0, fmt.Errorf("failed to count done habits: %w", err)
+	}
+
+	var results []struct {
+		Total int `bson:"total"`
+	}
+	if err = cursor.All(timeoutCtx, &results); err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.CountDone() failed to cursor.All : %v", err)
+		return 0, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	// 完了した習慣が一件もない場合は0
+	if len(results) == 0 {
+		return 0, nil
+	}
+
+	return results[0].Total, nil
+}
+
+// ユーザーの全てのdaily_trackを日付順に1件ずつfnに渡す（エクスポートで使用）
+// NOTE: 全件をメモリに載せないよう、カーソルから1件ずつデコードする（書き出しに時間がかかるため、タイムアウトは呼び出し元のctxに従う）
+func (r *DailyTrackRepository) EachDailyTrack(ctx context.Context, userId string, fn func(dailyTrack *daily_track.DailyTrack) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userId}, opts)
+	if err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.EachDailyTrack() failed to collection.Find (user_id: %s): %v", userId, err)
+		return fmt.Errorf("failed to find daily_tracks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var dailyTrackDB dailyTrackDB
+		if err := cursor.Decode(&dailyTrackDB); err != nil {
+			log.Printf("[ERROR] DailyTrackRepository.EachDailyTrack() failed to cursor.Decode : %v", err)
+			return fmt.Errorf("failed to decode document from cursor: %w", err)
+		}
+		if err := fn(convertToDailyTrack(&dailyTrackDB)); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.EachDailyTrack() failed to cursor.Next : %v", err)
+		return fmt.Errorf("failed to iterate cursor: %w", err)
+	}
+
+	return nil
+}
+
 func (r *DailyTrackRepository) RegisterDailyTrack(ctx context.Context, dailyTrack *daily_track.DailyTrack) (*daily_track.DailyTrack, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -79,7 +199,7 @@ func (r *DailyTrackRepository) RegisterDailyTrack(ctx context.Context, dailyTrac
 		return nil, common.ErrAlreadyExists
 	}
 	if err != mongo.ErrNoDocuments {
-		log.Printf("[ERROR] DailyTrackRepository.RegisterDailyTrack() failed to collection.FindOne (user_id: %s, date: %s): %w", dailyTrack.UserId, dailyTrack.Date, err)
+		log.Printf("[ERROR] DailyTrackRepository.RegisterDailyTrack() failed to collection.FindOne (user_id: %s, date: %s): %v", dailyTrack.UserId, dailyTrack.Date, err)
 		return nil, fmt.Errorf("failed to find daily_track: %w", err)
 	}
 
@@ -87,7 +207,7 @@ func (r *DailyTrackRepository) RegisterDailyTrack(ctx context.Context, dailyTrac
 	result, err := r.collection.InsertOne(timeoutCtx, dailyTrackDB)
 
 	if err != nil {
-		log.Printf("[ERROR] DailyTrackRepository.RegisterDailyTrack() failed to collection.InsertOne (data: %+v) : %w", dailyTrackDB, err)
+		log.Printf("[ERROR] DailyTrackRepository.RegisterDailyTrack() failed to collection.InsertOne (data: %+v) : %v", dailyTrackDB, err)
 		return nil, fmt.Errorf("failed to register daily_track: %w", err)
 	}
 
@@ -98,6 +218,36 @@ func (r *DailyTrackRepository) RegisterDailyTrack(ctx context.Context, dailyTrac
 	return dailyTrack, nil
 }
 
+// daily_trackをまとめて登録（インポート時に使用）
+// NOTE: 登録済みの日付のチェックは呼び出し側で行う
+func (r *DailyTrackRepository) RegisterDailyTracks(ctx context.Context, dailyTracks []*daily_track.DailyTrack) error {
+	if len(dailyTracks) == 0 {
+		return nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	documents := make([]interface{}, 0, len(dailyTracks))
+	for _, dailyTrack := range dailyTracks {
+		documents = append(documents, convertToDailyTrackDBWithoutId(dailyTrack))
+	}
+
+	result, err := r.collection.InsertMany(timeoutCtx, documents)
+	if err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.RegisterDailyTracks() failed to collection.InsertMany (count: %d) : %v", len(documents), err)
+		return fmt.Errorf("failed to register daily_tracks: %w", err)
+	}
+
+	for i, insertedID := range result.InsertedIDs {
+		if oid, ok := insertedID.(primitive.ObjectID); ok {
+			dailyTracks[i].Id = oid.Hex()
+		}
+	}
+
+	return nil
+}
+
 func (r *DailyTrackRepository) UpdateHabitStatuses(ctx context.Context, dailyTrack *daily_track.DailyTrack) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -105,15 +255,15 @@ func (r *DailyTrackRepository) UpdateHabitStatuses(ctx context.Context, dailyTra
 	// ID変換
 	objectID, err := primitive.ObjectIDFromHex(dailyTrack.Id)
 	if err != nil {
-		log.Printf("[ERROR] DailyTrackRepository.UpdateHabitStatuses() failed to primitive.ObjectIDFromHex (id: %s) : %w", dailyTrack.Id, err)
+		log.Printf("[ERROR] DailyTrackRepository.UpdateHabitStatuses() failed to primitive.ObjectIDFromHex (id: %s) : %v", dailyTrack.Id, err)
 		return fmt.Errorf("invalid ID: %w", err)
 	}
 
 	// DBモデルに変換
 	dailyTrackDB := convertToDailyTrackDBWithoutId(dailyTrack)
 
-	// 更新対象を特定するフィルタ
-	filter := bson.M{"_id": objectID}
+	// 更新対象を特定するフィルタ（所有者で絞り込む）
+	filter := bson.M{"_id": objectID, "user_id": dailyTrack.UserId}
 
 	// 更新内容
 	update := bson.M{
@@ -127,26 +277,185 @@ func (r *DailyTrackRepository) UpdateHabitStatuses(ctx context.Context, dailyTra
 	result, err = r.collection.UpdateOne(timeoutCtx, filter, update)
 
 	if err != nil {
-		log.Printf("[ERROR] DailyTrackRepository.UpdateHabitStatuses() failed to collection.UpdateOne (_id: %s, statuses: %+v) : %w", dailyTrack.Id, dailyTrackDB.HabitStatuses, err)
+		log.Printf("[ERROR] DailyTrackRepository.UpdateHabitStatuses() failed to collection.UpdateOne (_id: %s, statuses: %+v) : %v", dailyTrack.Id, dailyTrackDB.HabitStatuses, err)
 		return fmt.Errorf("failed to update daily_track: %w", err)
 	}
 
 	if result.MatchedCount == 0 {
-		log.Printf("[ERROR] DailyTrackRepository.UpdateHabitStatuses() failed to collection.UpdateOne target not found (_id: %s)", dailyTrack.Id)
+		log.Printf("[ERROR] DailyTrackRepository.UpdateHabitStatuses() failed to collection.UpdateOne target not found (_id: %s, user_id: %s)", dailyTrack.Id, dailyTrack.UserId)
 		return common.ErrNotFound
 	}
 
 	return nil
 }
 
+// 指定した習慣の完了状態を更新する
+// 完了状態が実際に変化した場合のみtrueを返す（同じ状態への更新は何もしない）
+// NOTE: フィルタに現在の状態を含めることで、同時リクエストでも変化を検知できるのは一度だけになる
+func (r *DailyTrackRepository) UpdateHabitDone(ctx context.Context, userId string, dailyTrackId string, habitId string, isDone bool) (bool, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(dailyTrackId)
+	if err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.UpdateHabitDone() failed to primitive.ObjectIDFromHex (id: %s) : %v", dailyTrackId, err)
+		return false, fmt.Errorf("invalid ID: %w", err)
+	}
+
+	// 更新対象を特定するフィルタ（現在の状態が更新後の状態と異なるものだけ）
+	filter := bson.M{
+		"_id":     objectID,
+		"user_id": userId,
+		"habit_statuses": bson.M{
+			"$elemMatch": bson.M{"habit_id": habitId, "is_done": !isDone},
+		},
+	}
+
+	// 更新内容
+	set := bson.M{"habit_statuses.$.is_done": isDone}
+	if !isDone {
+		// 未完了に戻す場合は記録量もリセットする
+		set["habit_statuses.$.value"] = 0
+	}
+	update := bson.M{"$set": set}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.UpdateHabitDone() failed to collection.UpdateOne (_id: %s, habit_id: %s, is_done: %t) : %v", dailyTrackId, habitId, isDone, err)
+		return false, fmt.Errorf("failed to update habit done: %w", err)
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// 指定した習慣に記録量を集計し、目標に達した場合は完了にする
+// 更新後のdaily_trackと、今回の記録で未完了から完了に変わった場合にtrueを返す
+// NOTE: 完了への更新はフィルタに未完了であることを含めるため、同時リクエストでも完了に変わるのは一度だけになる
+func (r *DailyTrackRepository) LogHabitAmount(ctx context.Context, userId string, dailyTrackId string, habitId string, amount float64, measure *habit.Measure) (*daily_track.DailyTrack, bool, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(dailyTrackId)
+	if err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.LogHabitAmount() failed to primitive.ObjectIDFromHex (id: %s) : %v", dailyTrackId, err)
+		return nil, false, fmt.Errorf("invalid ID: %w", err)
+	}
+
+	// 記録量の集計（合計または最大値）
+	operator := "$inc"
+	if measure.Aggregation == habit.AggregationMax {
+		operator = "$max"
+	}
+	filter := bson.M{
+		"_id":            objectID,
+		"user_id":        userId,
+		"habit_statuses": bson.M{"$elemMatch": bson.M{"habit_id": habitId}},
+	}
+	update := bson.M{operator: bson.M{"habit_statuses.$.value": amount}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var dailyTrackDB dailyTrackDB
+	err = r.collection.FindOneAndUpdate(timeoutCtx, filter, update, opts).Decode(&dailyTrackDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, false, common.ErrNotFound
+		}
+		log.Printf("[ERROR] DailyTrackRepository.LogHabitAmount() failed to collection.FindOneAndUpdate (_id: %s, habit_id: %s, amount: %v) : %v", dailyTrackId, habitId, amount, err)
+		return nil, false, fmt.Errorf("failed to log habit amount: %w", err)
+	}
+
+	// 目標に達していて未完了の場合のみ完了にする
+	filter["habit_statuses"] = bson.M{
+		"$elemMatch": bson.M{"habit_id": habitId, "is_done": false, "value": bson.M{"$gte": measure.Target}},
+	}
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, bson.M{"$set": bson.M{"habit_statuses.$.is_done": true}})
+	if err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.LogHabitAmount() failed to collection.UpdateOne (_id: %s, habit_id: %s) : %v", dailyTrackId, habitId, err)
+		return nil, false, fmt.Errorf("failed to update habit done: %w", err)
+	}
+
+	dailyTrack := convertToDailyTrack(&dailyTrackDB)
+	reached := result.ModifiedCount > 0
+	if reached {
+		for _, habitStatus := range dailyTrack.HabitStatuses {
+			if habitStatus.HabitId == habitId {
+				habitStatus.IsDone = true
+			}
+		}
+	}
+
+	return dailyTrack, reached, nil
+}
+
+// fromDate以降のdaily_trackに含まれる習慣名を更新する
+// NOTE: fromDateより前のdaily_trackは当時の名前を残す
+func (r *DailyTrackRepository) RenameHabit(ctx context.Context, userId string, habitId string, habitName string, fromDate string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"user_id":                 userId,
+		"date":                    bson.M{"$gte": fromDate},
+		"habit_statuses.habit_id": habitId,
+	}
+	update := bson.M{"$set": bson.M{"habit_statuses.$[status].habit_name": habitName}}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"status.habit_id": habitId}},
+	})
+
+	_, err := r.collection.UpdateMany(timeoutCtx, filter, update, opts)
+	if err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.RenameHabit() failed to collection.UpdateMany (user_id: %s, habit_id: %s, from: %s) : %v", userId, habitId, fromDate, err)
+		return fmt.Errorf("failed to rename habit in daily_track: %w", err)
+	}
+
+	return nil
+}
+
+// 全てのdaily_trackから習慣のステータスを取り除く
+func (r *DailyTrackRepository) RemoveHabit(ctx context.Context, userId string, habitId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userId, "habit_statuses.habit_id": habitId}
+	update := bson.M{"$pull": bson.M{"habit_statuses": bson.M{"habit_id": habitId}}}
+
+	_, err := r.collection.UpdateMany(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.RemoveHabit() failed to collection.UpdateMany (user_id: %s, habit_id: %s) : %v", userId, habitId, err)
+		return fmt.Errorf("failed to remove habit from daily_track: %w", err)
+	}
+
+	return nil
+}
+
+// ユーザーのdaily_trackを全て削除（アカウント削除時に使用）
+func (r *DailyTrackRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] DailyTrackRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete daily_track: %w", err)
+	}
+
+	return nil
+}
+
 // DBモデルをドメインモデルに変換
 func convertToDailyTrack(dailyTrackDB *dailyTrackDB) *daily_track.DailyTrack {
 	var habitStatuses []*daily_track.HabitStatus
 	for _, habitStatusDB := range dailyTrackDB.HabitStatuses {
 		habitStatus := &daily_track.HabitStatus{
-			HabitId:   habitStatusDB.HabitId,
-			HabitName: habitStatusDB.HabitName,
-			IsDone:    habitStatusDB.IsDone,
+			HabitId:      habitStatusDB.HabitId,
+			HabitName:    habitStatusDB.HabitName,
+			IsDone:       habitStatusDB.IsDone,
+			WeeklyTarget: habitStatusDB.WeeklyTarget,
+			Measure:      convertToMeasure(habitStatusDB.Measure),
+			Value:        habitStatusDB.Value,
 		}
 		habitStatuses = append(habitStatuses, habitStatus)
 	}
@@ -165,9 +474,12 @@ func convertToDailyTrackDBWithoutId(dailyTrack *daily_track.DailyTrack) *dailyTr
 	var habitStatusesDB []habitStatusDB
 	for _, habitStatus := range dailyTrack.HabitStatuses {
 		habitStatus := habitStatusDB{
-			HabitId:   habitStatus.HabitId,
-			HabitName: habitStatus.HabitName,
-			IsDone:    habitStatus.IsDone,
+			HabitId:      habitStatus.HabitId,
+			HabitName:    habitStatus.HabitName,
+			IsDone:       habitStatus.IsDone,
+			WeeklyTarget: habitStatus.WeeklyTarget,
+			Measure:      convertToMeasureDB(habitStatus.Measure),
+			Value:        habitStatus.Value,
 		}
 		habitStatusesDB = append(habitStatusesDB, habitStatus)
 	}
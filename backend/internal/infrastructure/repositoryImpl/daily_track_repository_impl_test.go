@@ -0,0 +1,68 @@
+package repositoryImpl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
+)
+
+// 目標に達した記録のみ完了に変わり、既に完了している場合はfalseを返す（ポイントの二重付与を防ぐ）
+func TestDailyTrackRepositoryLogHabitAmount(t *testing.T) {
+	ctx := context.Background()
+	repo := NewDailyTrackRepository(newTestDatabase(t).Collection("daily_track"))
+
+	tests := []struct {
+		name        string
+		aggregation habit.AggregationType
+		amounts     []float64
+		wantValue   float64
+		wantReached []bool
+	}{
+		{"sum", habit.AggregationSum, []float64{6, 6, 6}, 18, []bool{false, true, false}},
+		{"max", habit.AggregationMax, []float64{12, 8, 15}, 15, []bool{true, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			measure := &habit.Measure{Unit: "分", Target: 10, Aggregation: tt.aggregation}
+			registered, err := repo.RegisterDailyTrack(ctx, &daily_track.DailyTrack{
+				UserId:        "user-" + tt.name,
+				Date:          "2024-01-01",
+				HabitStatuses: []*daily_track.HabitStatus{{HabitId: "habit-1", HabitName: "読書", Measure: measure}},
+			})
+			mustRegister(t, err)
+
+			var updated *daily_track.DailyTrack
+			for i, amount := range tt.amounts {
+				var reached bool
+				updated, reached, err = repo.LogHabitAmount(ctx, registered.UserId, registered.Id, "habit-1", amount, measure)
+				if err != nil {
+					t.Fatalf("failed to log amount: %v", err)
+				}
+				if reached != tt.wantReached[i] {
+					t.Errorf("log %d: reached = %v, want %v", i, reached, tt.wantReached[i])
+				}
+			}
+
+			status := updated.HabitStatuses[0]
+			if status.Value != tt.wantValue || !status.IsDone {
+				t.Errorf("value = %v, is_done = %v, want %v, true", status.Value, status.IsDone, tt.wantValue)
+			}
+		})
+	}
+
+	// 他のユーザーのdaily_trackには記録できない
+	registered, err := repo.RegisterDailyTrack(ctx, &daily_track.DailyTrack{
+		UserId:        "owner",
+		Date:          "2024-01-01",
+		HabitStatuses: []*daily_track.HabitStatus{{HabitId: "habit-1", HabitName: "読書", Measure: &habit.Measure{Unit: "分", Target: 10, Aggregation: habit.AggregationSum}}},
+	})
+	mustRegister(t, err)
+	if _, _, err = repo.LogHabitAmount(ctx, "stranger", registered.Id, "habit-1", 10, registered.HabitStatuses[0].Measure); !errors.Is(err, common.ErrNotFound) {
+		t.Errorf("got error %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,201 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/feed"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DBに保存するための内部モデル
+type feedItemDB struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserId    string             `bson:"user_id"`
+	HabitId   string             `bson:"habit_id"`
+	HabitName string             `bson:"habit_name"`
+	Date      string             `bson:"date"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+// FeedItemRepository はMongoDBのfeed_itemsコレクションにアクセスします
+// NOTE: 習慣・日付ごとに1件（応援はcheersコレクションに保存する）
+type FeedItemRepository struct {
+	collection *mongo.Collection
+}
+
+// NewFeedItemRepository は新しいFeedItemRepositoryインスタンスを作成します
+func NewFeedItemRepository(collection *mongo.Collection) repository.FeedItemRepository {
+	return &FeedItemRepository{
+		collection: collection,
+	}
+}
+
+// 閲覧できるフィードの1件を取得（それ以外のフィードはErrNotFound）
+// sharedHabitIdsはFetchFeedと同じく、フレンドのユーザーIDごとの公開している習慣ID
+func (r *FeedItemRepository) Find(ctx context.Context, userId string, sharedHabitIds map[string][]string, id string) (*feed.Item, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	var itemDB feedItemDB
+	filter := visibleFeedFilter(userId, sharedHabitIds)
+	filter["_id"] = objectID
+	err = r.collection.FindOne(timeoutCtx, filter).Decode(&itemDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, common.ErrNotFound
+		}
+		log.Printf("[ERROR] FeedItemRepository.Find() failed to collection.FindOne (_id: %s, user_id: %s) : %v", id, userId, err)
+		return nil, fmt.Errorf("failed to find feed item: %w", err)
+	}
+
+	return convertToFeedItem(&itemDB), nil
+}
+
+// 自分の全ての完了と、フレンドが公開している習慣の完了を新しい順に取得し、全件数とあわせて返す
+// sharedHabitIdsはフレンドのユーザーIDごとの公開している習慣ID
+func (r *FeedItemRepository) FetchFeed(ctx context.Context, userId string, sharedHabitIds map[string][]string, offset int, limit int) ([]*feed.Item, int64, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := visibleFeedFilter(userId, sharedHabitIds)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(timeoutCtx, filter, opts)
+	if err != nil {
+		log.Printf("[ERROR] FeedItemRepository.FetchFeed() failed to collection.Find (user_id: %s): %v", userId, err)
+		return nil, 0, fmt.Errorf("failed to fetch feed items: %w", err)
+	}
+
+	var itemDBs []feedItemDB
+	if err = cursor.All(timeoutCtx, &itemDBs); err != nil {
+		log.Printf("[ERROR] FeedItemRepository.FetchFeed() failed to cursor.All : %v", err)
+		return nil, 0, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	total, err := r.collection.CountDocuments(timeoutCtx, filter)
+	if err != nil {
+		log.Printf("[ERROR] FeedItemRepository.FetchFeed() failed to collection.CountDocuments (user_id: %s): %v", userId, err)
+		return nil, 0, fmt.Errorf("failed to count feed items: %w", err)
+	}
+
+	items := make([]*feed.Item, 0, len(itemDBs))
+	for _, itemDB := range itemDBs {
+		items = append(items, convertToFeedItem(&itemDB))
+	}
+
+	return items, total, nil
+}
+
+// 習慣の完了をフィードに登録（同じ習慣・日付の登録済みのものがあればそれを返す）
+func (r *FeedItemRepository) Register(ctx context.Context, item *feed.Item) (*feed.Item, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now()
+	}
+
+	filter := bson.M{"user_id": item.UserId, "habit_id": item.HabitId, "date": item.Date}
+	update := bson.M{"$setOnInsert": bson.M{"habit_name": item.HabitName, "created_at": item.CreatedAt}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var itemDB feedItemDB
+	err := r.collection.FindOneAndUpdate(timeoutCtx, filter, update, opts).Decode(&itemDB)
+	if err != nil {
+		log.Printf("[ERROR] FeedItemRepository.Register() failed to collection.FindOneAndUpdate (filter: %+v) : %v", filter, err)
+		return nil, fmt.Errorf("failed to register feed item: %w", err)
+	}
+
+	return convertToFeedItem(&itemDB), nil
+}
+
+// 完了の取り消し時に該当する1件を削除
+func (r *FeedItemRepository) DeleteByCompletion(ctx context.Context, userId string, habitId string, date string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId, "habit_id": habitId, "date": date})
+	if err != nil {
+		log.Printf("[ERROR] FeedItemRepository.DeleteByCompletion() failed to collection.DeleteMany (user_id: %s, habit_id: %s, date: %s) : %v", userId, habitId, date, err)
+		return fmt.Errorf("failed to delete feed items: %w", err)
+	}
+
+	return nil
+}
+
+// 習慣の完全削除時に、その習慣のフィードを全て削除
+func (r *FeedItemRepository) DeleteByHabit(ctx context.Context, userId string, habitId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId, "habit_id": habitId})
+	if err != nil {
+		log.Printf("[ERROR] FeedItemRepository.DeleteByHabit() failed to collection.DeleteMany (user_id: %s, habit_id: %s) : %v", userId, habitId, err)
+		return fmt.Errorf("failed to delete feed items: %w", err)
+	}
+
+	return nil
+}
+
+// ユーザーのフィードを全て削除（アカウント削除時に使用）
+func (r *FeedItemRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] FeedItemRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete feed items: %w", err)
+	}
+
+	return nil
+}
+
+// 自分の完了と、フレンドが公開している習慣の完了のフィルター
+func visibleFeedFilter(userId string, sharedHabitIds map[string][]string) bson.M {
+	conditions := []bson.M{{"user_id": userId}}
+	for friendId, habitIds := range sharedHabitIds {
+		if len(habitIds) == 0 {
+			continue
+		}
+		conditions = append(conditions, bson.M{"user_id": friendId, "habit_id": bson.M{"$in": habitIds}})
+	}
+	return bson.M{"$or": conditions}
+}
+
+// DBモデルをドメインモデルに変換
+// NOTE: ユーザーネーム・応援はサービス側で設定する
+func convertToFeedItem(itemDB *feedItemDB) *feed.Item {
+	return &feed.Item{
+		Id:        itemDB.ID.Hex(),
+		UserId:    itemDB.UserId,
+		HabitId:   itemDB.HabitId,
+		HabitName: itemDB.HabitName,
+		Date:      itemDB.Date,
+		Reactions: make([]*feed.ReactionCount, 0),
+		Comments:  make([]*feed.Comment, 0),
+		CreatedAt: itemDB.CreatedAt,
+	}
+}
@@ -0,0 +1,220 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/friend"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DBに保存するための内部モデル
+type friendshipDB struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	PairKey     string             `bson:"pair_key"` // 2人の組で一意（ユニークインデックス）
+	RequesterId string             `bson:"requester_id"`
+	AddresseeId string             `bson:"addressee_id"`
+	Status      string             `bson:"status"`
+	BlockedBy   string             `bson:"blocked_by,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+}
+
+// FriendshipRepository はMongoDBのfriendshipsコレクションにアクセスします
+type FriendshipRepository struct {
+	collection *mongo.Collection
+}
+
+// NewFriendshipRepository は新しいFriendshipRepositoryインスタンスを作成します
+func NewFriendshipRepository(collection *mongo.Collection) repository.FriendshipRepository {
+	return &FriendshipRepository{
+		collection: collection,
+	}
+}
+
+// 2人の関係を取得（申請の向きは問わない）
+func (r *FriendshipRepository) Find(ctx context.Context, userId string, otherUserId string) (*friend.Friendship, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	pairKey := friend.PairKey(userId, otherUserId)
+
+	var friendshipDB friendshipDB
+	err := r.collection.FindOne(timeoutCtx, bson.M{"pair_key": pairKey}).Decode(&friendshipDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, common.ErrNotFound
+		}
+		log.Printf("[ERROR] FriendshipRepository.Find() failed to collection.FindOne (pair_key: %s) : %v", pairKey, err)
+		return nil, fmt.Errorf("failed to find friendship: %w", err)
+	}
+
+	return convertToFriendship(&friendshipDB), nil
+}
+
+// ユーザーの関係を全件取得（新しい順）
+func (r *FriendshipRepository) FetchAll(ctx context.Context, userId string) ([]*friend.Friendship, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"$or": []bson.M{{"requester_id": userId}, {"addressee_id": userId}}}
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(timeoutCtx, filter, opts)
+	if err != nil {
+		log.Printf("[ERROR] FriendshipRepository.FetchAll() failed to collection.Find (user_id: %s): %v", userId, err)
+		return nil, fmt.Errorf("failed to fetch friendships: %w", err)
+	}
+
+	var friendshipDBs []friendshipDB
+	if err = cursor.All(timeoutCtx, &friendshipDBs); err != nil {
+		log.Printf("[ERROR] FriendshipRepository.FetchAll() failed to cursor.All : %v", err)
+		return nil, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	friendships := make([]*friend.Friendship, 0, len(friendshipDBs))
+	for _, friendshipDB := range friendshipDBs {
+		friendships = append(friendships, convertToFriendship(&friendshipDB))
+	}
+
+	return friendships, nil
+}
+
+// 関係の登録（すでに関係がある場合はErrAlreadyExists）
+// NOTE: pair_keyのユニークインデックスにより、同時に申請しても重複して登録されない
+func (r *FriendshipRepository) Register(ctx context.Context, friendship *friend.Friendship) (*friend.Friendship, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	friendship.CreatedAt, friendship.UpdatedAt = now, now
+
+	friendshipDB := friendshipDB{
+		PairKey:     friend.PairKey(friendship.RequesterId, friendship.AddresseeId),
+		RequesterId: friendship.RequesterId,
+		AddresseeId: friendship.AddresseeId,
+		Status:      string(friendship.Status),
+		BlockedBy:   friendship.BlockedBy,
+		CreatedAt:   friendship.CreatedAt,
+		UpdatedAt:   friendship.UpdatedAt,
+	}
+
+	result, err := r.collection.InsertOne(timeoutCtx, friendshipDB)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, common.ErrAlreadyExists
+		}
+		log.Printf("[ERROR] FriendshipRepository.Register() failed to collection.InsertOne (data: %+v) : %v", friendshipDB, err)
+		return nil, fmt.Errorf("failed to register friendship: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		friendship.Id = oid.Hex()
+	}
+
+	return friendship, nil
+}
+
+// 関係の更新（申請の向き・状態）
+// userIdが当事者でない関係はErrNotFound
+func (r *FriendshipRepository) Update(ctx context.Context, userId string, friendship *friend.Friendship) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(friendship.Id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	friendship.UpdatedAt = time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"requester_id": friendship.RequesterId,
+			"addressee_id": friendship.AddresseeId,
+			"status":       string(friendship.Status),
+			"blocked_by":   friendship.BlockedBy,
+			"updated_at":   friendship.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, friendshipMemberFilter(objectID, userId), update)
+	if err != nil {
+		log.Printf("[ERROR] FriendshipRepository.Update() failed to collection.UpdateOne (_id: %s, user_id: %s, data: %+v) : %v", friendship.Id, userId, update, err)
+		return fmt.Errorf("failed to update friendship: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// 関係の削除（userIdが当事者でない関係はErrNotFound）
+func (r *FriendshipRepository) Delete(ctx context.Context, userId string, id string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	result, err := r.collection.DeleteOne(timeoutCtx, friendshipMemberFilter(objectID, userId))
+	if err != nil {
+		log.Printf("[ERROR] FriendshipRepository.Delete() failed to collection.DeleteOne (_id: %s, user_id: %s) : %v", id, userId, err)
+		return fmt.Errorf("failed to delete friendship: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// ユーザーの関係を全て削除（アカウント削除時に使用）
+func (r *FriendshipRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"$or": []bson.M{{"requester_id": userId}, {"addressee_id": userId}}}
+	_, err := r.collection.DeleteMany(timeoutCtx, filter)
+	if err != nil {
+		log.Printf("[ERROR] FriendshipRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete friendships: %w", err)
+	}
+
+	return nil
+}
+
+// userIdが当事者である関係のフィルター
+func friendshipMemberFilter(id primitive.ObjectID, userId string) bson.M {
+	return bson.M{"_id": id, "$or": []bson.M{{"requester_id": userId}, {"addressee_id": userId}}}
+}
+
+// DBモデルをドメインモデルに変換
+func convertToFriendship(friendshipDB *friendshipDB) *friend.Friendship {
+	return &friend.Friendship{
+		Id:          friendshipDB.ID.Hex(),
+		RequesterId: friendshipDB.RequesterId,
+		AddresseeId: friendshipDB.AddresseeId,
+		Status:      friend.Status(friendshipDB.Status),
+		BlockedBy:   friendshipDB.BlockedBy,
+		CreatedAt:   friendshipDB.CreatedAt,
+		UpdatedAt:   friendshipDB.UpdatedAt,
+	}
+}
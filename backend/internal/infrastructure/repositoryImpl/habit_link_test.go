@@ -0,0 +1,61 @@
+package repositoryImpl
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"backend/internal/domain/model/challenge"
+	"backend/internal/domain/model/friend"
+)
+
+// 習慣の完全削除時に、公開設定とグループチャレンジの紐づけから習慣を外す（他のユーザーの設定は変更しない）
+func TestRemoveHabitLinks(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+
+	shareRepo := NewHabitShareRepository(db.Collection("habit_shares"))
+	_, err := shareRepo.Update(ctx, &friend.Share{UserId: "owner", FriendId: "friend", HabitIds: []string{"habit-1", "habit-2"}})
+	mustRegister(t, err)
+	_, err = shareRepo.Update(ctx, &friend.Share{UserId: "friend", FriendId: "owner", HabitIds: []string{"habit-1"}})
+	mustRegister(t, err)
+
+	if err = shareRepo.RemoveHabit(ctx, "owner", "habit-1"); err != nil {
+		t.Fatalf("failed to remove habit from shares: %v", err)
+	}
+	for _, tt := range []struct {
+		userId, friendId string
+		want             []string
+	}{{"owner", "friend", []string{"habit-2"}}, {"friend", "owner", []string{"habit-1"}}} {
+		share, err := shareRepo.Find(ctx, tt.userId, tt.friendId)
+		if err != nil {
+			t.Fatalf("failed to find share: %v", err)
+		}
+		if !slices.Equal(share.HabitIds, tt.want) {
+			t.Errorf("%s -> %s: habit_ids = %v, want %v", tt.userId, tt.friendId, share.HabitIds, tt.want)
+		}
+	}
+
+	challengeRepo := NewChallengeRepository(db.Collection("challenges"))
+	target := newTestChallenge("owner")
+	target.Participants = []*challenge.Participant{
+		{UserId: "owner", HabitId: "habit-1", Status: challenge.StatusJoined},
+		{UserId: "friend", HabitId: "habit-1", Status: challenge.StatusJoined},
+	}
+	registered, err := challengeRepo.Register(ctx, target)
+	mustRegister(t, err)
+
+	if err = challengeRepo.UnlinkHabit(ctx, "owner", "habit-1"); err != nil {
+		t.Fatalf("failed to unlink habit: %v", err)
+	}
+	found, err := challengeRepo.Find(ctx, "owner", registered.Id)
+	if err != nil {
+		t.Fatalf("failed to find challenge: %v", err)
+	}
+	if owner := found.FindParticipant("owner"); owner.HabitId != "" || owner.Status != challenge.StatusInvited {
+		t.Errorf("owner = %+v, want invited without habit", owner)
+	}
+	if friend := found.FindParticipant("friend"); friend.HabitId != "habit-1" || friend.Status != challenge.StatusJoined {
+		t.Errorf("friend = %+v, want unchanged", friend)
+	}
+}
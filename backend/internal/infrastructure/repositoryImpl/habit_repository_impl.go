@@ -21,13 +21,41 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // DBに保存するための内部モデル
 type habitDB struct {
-	ID     primitive.ObjectID `bson:"_id,omitempty"`
-	UserId string             `bson:"user_id"`
-	Name   string             `bson:"name"`
+	ID       primitive.ObjectID `bson:"_id,omitempty"`
+	UserId   string             `bson:"user_id"`
+	Name     string             `bson:"name"`
+	Schedule *scheduleDB        `bson:"schedule,omitempty"`
+	Measure  *measureDB         `bson:"measure,omitempty"`
+	Scoring  *scoringDB         `bson:"scoring,omitempty"`
+
+	SortOrder int `bson:"sort_order"`
+
+	Status      string     `bson:"status,omitempty"`
+	PausedUntil string     `bson:"paused_until,omitempty"`
+	ArchivedAt  *time.Time `bson:"archived_at,omitempty"`
+
+	LongestStreak *int `bson:"longest_streak,omitempty"`
+}
+type scheduleDB struct {
+	Type         string `bson:"type"`
+	Weekdays     []int  `bson:"weekdays,omitempty"`
+	IntervalDays int    `bson:"interval_days,omitempty"`
+	TimesPerWeek int    `bson:"times_per_week,omitempty"`
+	StartDate    string `bson:"start_date,omitempty"`
+}
+type measureDB struct {
+	Unit        string  `bson:"unit"`
+	Target      float64 `bson:"target"`
+	Aggregation string  `bson:"aggregation"`
+}
+type scoringDB struct {
+	Difficulty string `bson:"difficulty"`
+	Points     int    `bson:"points,omitempty"`
 }
 
 // HabitRepository はMongoDBのusersコレクションにアクセスします
@@ -47,10 +75,11 @@ func (r *HabitRepository) FetchAll(ctx context.Context, userId string) ([]*habit
 	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	// Find()で全件取得
-	cursor, err := r.collection.Find(timeoutCtx, bson.M{"user_id": userId})
+	// Find()で全件取得（表示順）
+	opts := options.Find().SetSort(bson.D{{Key: "sort_order", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(timeoutCtx, bson.M{"user_id": userId}, opts)
 	if err != nil {
-		log.Printf("[ERROR] HabitRepository.FetchAll() failed to collection.Find (user_id: %s): %w", userId, err)
+		log.Printf("[ERROR] HabitRepository.FetchAll() failed to collection.Find (user_id: %s): %v", userId, err)
 
 		// NOTE: nilスライスは要素が一つもない有効なスライスと認識される
 		return nil, fmt.Errorf("failed to habit fetch all: %w", err)
@@ -60,7 +89,7 @@ func (r *HabitRepository) FetchAll(ctx context.Context, userId string) ([]*habit
 	// 結果を格納するスライス
 	var habitDBs []habitDB
 	if err = cursor.All(timeoutCtx, &habitDBs); err != nil {
-		log.Printf("[ERROR] HabitRepository.FetchAll() failed to cursor.All : %w", err)
+		log.Printf("[ERROR] HabitRepository.FetchAll() failed to cursor.All : %v", err)
 		return nil, fmt.Errorf("failed to decode documents from cursor: %w", err)
 	}
 
@@ -73,6 +102,30 @@ func (r *HabitRepository) FetchAll(ctx context.Context, userId string) ([]*habit
 	return habits, nil
 }
 
+// 習慣取得
+func (r *HabitRepository) Find(ctx context.Context, userId string, id string) (*habit.Habit, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// MongoDBの_idはObjectID型で保存される
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	var habitDB habitDB
+	err = r.collection.FindOne(timeoutCtx, bson.M{"_id": objectID, "user_id": userId}).Decode(&habitDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, common.ErrNotFound
+		}
+		log.Printf("[ERROR] HabitRepository.Find() failed to collection.FindOne (_id: %s, user_id: %s) : %v", id, userId, err)
+		return nil, fmt.Errorf("failed to find habit: %w", err)
+	}
+
+	return convertToHabit(&habitDB), nil
+}
+
 // 習慣登録
 func (r *HabitRepository) Register(ctx context.Context, habit *habit.Habit) (*habit.Habit, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -87,21 +140,40 @@ func (r *HabitRepository) Register(ctx context.Context, habit *habit.Habit) (*ha
 		return nil, common.ErrAlreadyExists
 	}
 	if err != mongo.ErrNoDocuments {
-		log.Printf("[ERROR] HabitRepository.Register() failed to collection.FindOne (name: %s) : %w", habit.Name, err)
+		log.Printf("[ERROR] HabitRepository.Register() failed to collection.FindOne (name: %s) : %v", habit.Name, err)
 		return nil, fmt.Errorf("failed to check for existing habit: %w", err)
 	}
 
+	// 表示順は末尾にする
+	var lastHabitDB habitDB
+	sortOrder := 0
+	opts := options.FindOne().SetSort(bson.D{{Key: "sort_order", Value: -1}})
+	err = r.collection.FindOne(timeoutCtx, bson.M{"user_id": habit.UserId}, opts).Decode(&lastHabitDB)
+	if err == nil {
+		sortOrder = lastHabitDB.SortOrder + 1
+	}
+	if err != nil && err != mongo.ErrNoDocuments {
+		log.Printf("[ERROR] HabitRepository.Register() failed to collection.FindOne (user_id: %s) : %v", habit.UserId, err)
+		return nil, fmt.Errorf("failed to find last habit: %w", err)
+	}
+	habit.SortOrder = sortOrder
+
 	// DBに保存するためのモデルに変換
 	habitDB := habitDB{
-		UserId: habit.UserId,
-		Name:   habit.Name,
+		UserId:    habit.UserId,
+		Name:      habit.Name,
+		Schedule:  convertToScheduleDB(&habit.Schedule),
+		Measure:   convertToMeasureDB(habit.Measure),
+		Scoring:   convertToScoringDB(&habit.Scoring),
+		SortOrder: habit.SortOrder,
+		Status:    string(habit.Status),
 	}
 
 	// 新規登録
 	result, err := r.collection.InsertOne(timeoutCtx, habitDB)
 
 	if err != nil {
-		log.Printf("[ERROR] HabitRepository.Register() failed to collection.InsertOne (data: %+v) : %w", habitDB, err)
+		log.Printf("[ERROR] HabitRepository.Register() failed to collection.InsertOne (data: %+v) : %v", habitDB, err)
 		return nil, fmt.Errorf("failed to register habit: %w", err)
 	}
 
@@ -112,8 +184,112 @@ func (r *HabitRepository) Register(ctx context.Context, habit *habit.Habit) (*ha
 	return habit, nil
 }
 
+// 習慣更新（名前・表示順・状態・ポイントの設定）
+func (r *HabitRepository) Update(ctx context.Context, habit *habit.Habit) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(habit.Id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	// nameの重複チェック（自分自身は除く）
+	var existingHabitDB habitDB
+	err = r.collection.FindOne(timeoutCtx, bson.M{"user_id": habit.UserId, "name": habit.Name, "_id": bson.M{"$ne": objectID}}).Decode(&existingHabitDB)
+	if err == nil {
+		return common.ErrAlreadyExists
+	}
+	if err != mongo.ErrNoDocuments {
+		log.Printf("[ERROR] HabitRepository.Update() failed to collection.FindOne (name: %s) : %v", habit.Name, err)
+		return fmt.Errorf("failed to check for existing habit: %w", err)
+	}
+
+	// 更新対象を特定するフィルタ
+	filter := bson.M{"_id": objectID, "user_id": habit.UserId}
+
+	// 更新内容
+	update := bson.M{
+		"$set": bson.M{
+			"name":         habit.Name,
+			"sort_order":   habit.SortOrder,
+			"status":       string(habit.Status),
+			"paused_until": habit.PausedUntil,
+			"archived_at":  habit.ArchivedAt,
+			"scoring":      convertToScoringDB(&habit.Scoring),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] HabitRepository.Update() failed to collection.UpdateOne (_id: %s, data: %+v) : %v", habit.Id, update, err)
+		return fmt.Errorf("failed to update habit: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// 表示順の一括更新（habitIdsの並び順にする）
+func (r *HabitRepository) UpdateSortOrders(ctx context.Context, userId string, habitIds []string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	for sortOrder, id := range habitIds {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return common.ErrNotFound
+		}
+
+		filter := bson.M{"_id": objectID, "user_id": userId}
+		update := bson.M{"$set": bson.M{"sort_order": sortOrder}}
+
+		result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+		if err != nil {
+			log.Printf("[ERROR] HabitRepository.UpdateSortOrders() failed to collection.UpdateOne (_id: %s, sort_order: %d) : %v", id, sortOrder, err)
+			return fmt.Errorf("failed to update sort order: %w", err)
+		}
+
+		if result.MatchedCount == 0 {
+			return common.ErrNotFound
+		}
+	}
+
+	return nil
+}
+
+// 最長の連続達成数の記録を更新（記録より短い場合は変更しない）
+func (r *HabitRepository) UpdateLongestStreak(ctx context.Context, userId string, id string, longest int) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objectID, "user_id": userId}
+	update := bson.M{"$max": bson.M{"longest_streak": longest}}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] HabitRepository.UpdateLongestStreak() failed to collection.UpdateOne (_id: %s, longest_streak: %d) : %v", id, longest, err)
+		return fmt.Errorf("failed to update longest streak: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
 // 習慣削除
-func (r *HabitRepository) Delete(ctx context.Context, id string) error {
+func (r *HabitRepository) Delete(ctx context.Context, userId string, id string) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
@@ -123,13 +299,13 @@ func (r *HabitRepository) Delete(ctx context.Context, id string) error {
 	// MongoDBの_idはObjectID型で保存される
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		log.Printf("[ERROR] HabitRepository.Delete() failed to primitive.ObjectIDFromHex (id: %s) : %w", id, err)
+		log.Printf("[ERROR] HabitRepository.Delete() failed to primitive.ObjectIDFromHex (id: %s) : %v", id, err)
 		return fmt.Errorf("invalid ID: %w", err)
 	}
 
-	result, err = r.collection.DeleteOne(timeoutCtx, bson.M{"_id": objectID})
+	result, err = r.collection.DeleteOne(timeoutCtx, bson.M{"_id": objectID, "user_id": userId})
 	if err != nil {
-		log.Printf("[ERROR] HabitRepository.Delete() failed to collection.DeleteOne (_id: %s) : %w", id, err)
+		log.Printf("[ERROR] HabitRepository.Delete() failed to collection.DeleteOne (_id: %s, user_id: %s) : %v", id, userId, err)
 		return fmt.Errorf("failed to delete habit: %w", err)
 	}
 
@@ -140,11 +316,135 @@ func (r *HabitRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// ユーザーの習慣を全て削除（アカウント削除時に使用）
+func (r *HabitRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] HabitRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete habits: %w", err)
+	}
+
+	return nil
+}
+
 // DBモデルをドメインモデルに変換
 func convertToHabit(habitDB *habitDB) *habit.Habit {
 	return &habit.Habit{
-		Id:     habitDB.ID.Hex(),
-		UserId: habitDB.UserId,
-		Name:   habitDB.Name,
+		Id:       habitDB.ID.Hex(),
+		UserId:   habitDB.UserId,
+		Name:     habitDB.Name,
+		Schedule: convertToSchedule(habitDB.Schedule),
+		Measure:  convertToMeasure(habitDB.Measure),
+		Scoring:  convertToScoring(habitDB.Scoring),
+
+		SortOrder: habitDB.SortOrder,
+
+		Status:      convertToHabitStatus(habitDB.Status),
+		PausedUntil: habitDB.PausedUntil,
+		ArchivedAt:  habitDB.ArchivedAt,
+
+		LongestStreak: habitDB.LongestStreak,
+	}
+}
+
+// DBモデルをドメインモデルに変換
+// NOTE: 状態の導入前に登録された習慣は有効とみなす
+func convertToHabitStatus(status string) habit.Status {
+	if status == "" {
+		return habit.StatusActive
+	}
+	return habit.Status(status)
+}
+
+// DBモデルをドメインモデルに変換
+// NOTE: スケジュール導入前に登録された習慣は毎日実施とみなす
+func convertToSchedule(scheduleDB *scheduleDB) habit.Schedule {
+	if scheduleDB == nil {
+		return habit.DefaultSchedule()
+	}
+
+	var weekdays []time.Weekday
+	for _, weekday := range scheduleDB.Weekdays {
+		weekdays = append(weekdays, time.Weekday(weekday))
+	}
+
+	return habit.Schedule{
+		Type:         habit.ScheduleType(scheduleDB.Type),
+		Weekdays:     weekdays,
+		IntervalDays: scheduleDB.IntervalDays,
+		TimesPerWeek: scheduleDB.TimesPerWeek,
+		StartDate:    scheduleDB.StartDate,
+	}
+}
+
+// ドメインモデルをDBモデルに変換
+func convertToScheduleDB(schedule *habit.Schedule) *scheduleDB {
+	var weekdays []int
+	for _, weekday := range schedule.Weekdays {
+		weekdays = append(weekdays, int(weekday))
+	}
+
+	return &scheduleDB{
+		Type:         string(schedule.Type),
+		Weekdays:     weekdays,
+		IntervalDays: schedule.IntervalDays,
+		TimesPerWeek: schedule.TimesPerWeek,
+		StartDate:    schedule.StartDate,
+	}
+}
+
+// DBモデルをドメインモデルに変換
+// NOTE: daily_trackのステータスでも使用する
+func convertToMeasure(measureDB *measureDB) *habit.Measure {
+	if measureDB == nil {
+		return nil
+	}
+
+	return &habit.Measure{
+		Unit:        measureDB.Unit,
+		Target:      measureDB.Target,
+		Aggregation: habit.AggregationType(measureDB.Aggregation),
+	}
+}
+
+// ドメインモデルをDBモデルに変換
+// NOTE: daily_trackのステータスでも使用する
+func convertToMeasureDB(measure *habit.Measure) *measureDB {
+	if measure == nil {
+		return nil
+	}
+
+	return &measureDB{
+		Unit:        measure.Unit,
+		Target:      measure.Target,
+		Aggregation: string(measure.Aggregation),
+	}
+}
+
+// DBモデルをドメインモデルに変換
+// NOTE: 難易度の導入前に登録された習慣は普通とみなす
+func convertToScoring(scoringDB *scoringDB) habit.Scoring {
+	if scoringDB == nil || scoringDB.Difficulty == "" {
+		return habit.DefaultScoring()
+	}
+
+	return habit.Scoring{
+		Difficulty: habit.Difficulty(scoringDB.Difficulty),
+		Points:     scoringDB.Points,
+	}
+}
+
+// ドメインモデルをDBモデルに変換
+func convertToScoringDB(scoring *habit.Scoring) *scoringDB {
+	if scoring.Difficulty == "" {
+		return nil
+	}
+
+	return &scoringDB{
+		Difficulty: string(scoring.Difficulty),
+		Points:     scoring.Points,
 	}
 }
@@ -0,0 +1,193 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/friend"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DBに保存するための内部モデル
+type shareDB struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserId    string             `bson:"user_id"`
+	FriendId  string             `bson:"friend_id"`
+	HabitIds  []string           `bson:"habit_ids"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+}
+
+// HabitShareRepository はMongoDBのhabit_sharesコレクションにアクセスします
+// NOTE: 公開するユーザー・公開先のフレンドの組ごとに1件
+type HabitShareRepository struct {
+	collection *mongo.Collection
+}
+
+// NewHabitShareRepository は新しいHabitShareRepositoryインスタンスを作成します
+func NewHabitShareRepository(collection *mongo.Collection) repository.HabitShareRepository {
+	return &HabitShareRepository{
+		collection: collection,
+	}
+}
+
+// フレンドへの公開設定を取得（未設定の場合はErrNotFound）
+func (r *HabitShareRepository) Find(ctx context.Context, userId string, friendId string) (*friend.Share, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var shareDB shareDB
+	err := r.collection.FindOne(timeoutCtx, bson.M{"user_id": userId, "friend_id": friendId}).Decode(&shareDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, common.ErrNotFound
+		}
+		log.Printf("[ERROR] HabitShareRepository.Find() failed to collection.FindOne (user_id: %s, friend_id: %s) : %v", userId, friendId, err)
+		return nil, fmt.Errorf("failed to find habit share: %w", err)
+	}
+
+	return convertToShare(&shareDB), nil
+}
+
+// ユーザーが設定した公開設定を全件取得
+func (r *HabitShareRepository) FetchAll(ctx context.Context, userId string) ([]*friend.Share, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] HabitShareRepository.FetchAll() failed to collection.Find (user_id: %s): %v", userId, err)
+		return nil, fmt.Errorf("failed to fetch habit shares: %w", err)
+	}
+
+	var shareDBs []shareDB
+	if err = cursor.All(timeoutCtx, &shareDBs); err != nil {
+		log.Printf("[ERROR] HabitShareRepository.FetchAll() failed to cursor.All : %v", err)
+		return nil, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	shares := make([]*friend.Share, 0, len(shareDBs))
+	for _, shareDB := range shareDBs {
+		shares = append(shares, convertToShare(&shareDB))
+	}
+
+	return shares, nil
+}
+
+// フレンドがユーザーに向けて設定した公開設定を全件取得
+func (r *HabitShareRepository) FetchSharedWith(ctx context.Context, friendId string) ([]*friend.Share, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(timeoutCtx, bson.M{"friend_id": friendId})
+	if err != nil {
+		log.Printf("[ERROR] HabitShareRepository.FetchSharedWith() failed to collection.Find (friend_id: %s): %v", friendId, err)
+		return nil, fmt.Errorf("failed to fetch habit shares: %w", err)
+	}
+
+	var shareDBs []shareDB
+	if err = cursor.All(timeoutCtx, &shareDBs); err != nil {
+		log.Printf("[ERROR] HabitShareRepository.FetchSharedWith() failed to cursor.All : %v", err)
+		return nil, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	shares := make([]*friend.Share, 0, len(shareDBs))
+	for _, shareDB := range shareDBs {
+		shares = append(shares, convertToShare(&shareDB))
+	}
+
+	return shares, nil
+}
+
+// 公開する習慣を置き換える（未設定の場合は作成）
+func (r *HabitShareRepository) Update(ctx context.Context, share *friend.Share) (*friend.Share, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": share.UserId, "friend_id": share.FriendId}
+	update := bson.M{"$set": bson.M{"habit_ids": share.HabitIds, "updated_at": time.Now()}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var shareDB shareDB
+	err := r.collection.FindOneAndUpdate(timeoutCtx, filter, update, opts).Decode(&shareDB)
+	if err != nil {
+		log.Printf("[ERROR] HabitShareRepository.Update() failed to collection.FindOneAndUpdate (filter: %+v, habit_ids: %v) : %v", filter, share.HabitIds, err)
+		return nil, fmt.Errorf("failed to update habit share: %w", err)
+	}
+
+	return convertToShare(&shareDB), nil
+}
+
+// ユーザーの全ての公開設定から習慣を外す（習慣の完全削除時に使用）
+func (r *HabitShareRepository) RemoveHabit(ctx context.Context, userId string, habitId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateMany(timeoutCtx, bson.M{"user_id": userId, "habit_ids": habitId}, bson.M{"$pull": bson.M{"habit_ids": habitId}})
+	if err != nil {
+		log.Printf("[ERROR] HabitShareRepository.RemoveHabit() failed to collection.UpdateMany (user_id: %s, habit_id: %s) : %v", userId, habitId, err)
+		return fmt.Errorf("failed to remove habit from habit shares: %w", err)
+	}
+
+	return nil
+}
+
+// 2人の間の公開設定を双方向とも削除（フレンド解除・ブロック時に使用）
+func (r *HabitShareRepository) DeleteBetween(ctx context.Context, userId string, friendId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"$or": []bson.M{
+		{"user_id": userId, "friend_id": friendId},
+		{"user_id": friendId, "friend_id": userId},
+	}}
+	_, err := r.collection.DeleteMany(timeoutCtx, filter)
+	if err != nil {
+		log.Printf("[ERROR] HabitShareRepository.DeleteBetween() failed to collection.DeleteMany (user_id: %s, friend_id: %s) : %v", userId, friendId, err)
+		return fmt.Errorf("failed to delete habit shares: %w", err)
+	}
+
+	return nil
+}
+
+// ユーザーが設定した・ユーザーに向けた公開設定を全て削除（アカウント削除時に使用）
+func (r *HabitShareRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"$or": []bson.M{{"user_id": userId}, {"friend_id": userId}}}
+	_, err := r.collection.DeleteMany(timeoutCtx, filter)
+	if err != nil {
+		log.Printf("[ERROR] HabitShareRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete habit shares: %w", err)
+	}
+
+	return nil
+}
+
+// DBモデルをドメインモデルに変換
+func convertToShare(shareDB *shareDB) *friend.Share {
+	habitIds := shareDB.HabitIds
+	if habitIds == nil {
+		habitIds = make([]string, 0)
+	}
+	return &friend.Share{
+		Id:        shareDB.ID.Hex(),
+		UserId:    shareDB.UserId,
+		FriendId:  shareDB.FriendId,
+		HabitIds:  habitIds,
+		UpdatedAt: shareDB.UpdatedAt,
+	}
+}
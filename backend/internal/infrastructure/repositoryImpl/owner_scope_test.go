@@ -0,0 +1,167 @@
+package repositoryImpl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/challenge"
+	"backend/internal/domain/model/feed"
+	"backend/internal/domain/model/friend"
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/reminder"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// idを指定する操作は、他のユーザーのドキュメントに対しては存在しない場合と同じくErrNotFoundになる
+func TestOwnerScopedFilters(t *testing.T) {
+	const (
+		ownerId    = "owner"
+		friendId   = "friend"
+		strangerId = "stranger"
+	)
+
+	tests := []struct {
+		name string
+		// ownerIdのドキュメントを登録し、userIdで操作する関数を返す
+		setup func(t *testing.T, ctx context.Context, db *mongo.Database) func(userId string) error
+	}{
+		{"HabitRepository.Find", func(t *testing.T, ctx context.Context, db *mongo.Database) func(string) error {
+			repo := NewHabitRepository(db.Collection("habits"))
+			registered, err := repo.Register(ctx, &habit.Habit{UserId: ownerId, Name: "読書", Schedule: habit.DefaultSchedule()})
+			mustRegister(t, err)
+			return func(userId string) error {
+				_, err := repo.Find(ctx, userId, registered.Id)
+				return err
+			}
+		}},
+		{"HabitRepository.Delete", func(t *testing.T, ctx context.Context, db *mongo.Database) func(string) error {
+			repo := NewHabitRepository(db.Collection("habits"))
+			registered, err := repo.Register(ctx, &habit.Habit{UserId: ownerId, Name: "読書", Schedule: habit.DefaultSchedule()})
+			mustRegister(t, err)
+			return func(userId string) error {
+				return repo.Delete(ctx, userId, registered.Id)
+			}
+		}},
+		{"ChallengeRepository.Find", func(t *testing.T, ctx context.Context, db *mongo.Database) func(string) error {
+			repo := NewChallengeRepository(db.Collection("challenges"))
+			registered, err := repo.Register(ctx, newTestChallenge(ownerId))
+			mustRegister(t, err)
+			return func(userId string) error {
+				_, err := repo.Find(ctx, userId, registered.Id)
+				return err
+			}
+		}},
+		{"ChallengeRepository.Delete", func(t *testing.T, ctx context.Context, db *mongo.Database) func(string) error {
+			repo := NewChallengeRepository(db.Collection("challenges"))
+			registered, err := repo.Register(ctx, newTestChallenge(ownerId))
+			mustRegister(t, err)
+			return func(userId string) error {
+				return repo.Delete(ctx, userId, registered.Id)
+			}
+		}},
+		{"CheerRepository.Find", func(t *testing.T, ctx context.Context, db *mongo.Database) func(string) error {
+			repo := NewCheerRepository(db.Collection("cheers"))
+			registered, err := repo.Register(ctx, newTestComment(ownerId))
+			mustRegister(t, err)
+			return func(userId string) error {
+				_, err := repo.Find(ctx, userId, registered.Id)
+				return err
+			}
+		}},
+		{"CheerRepository.Delete", func(t *testing.T, ctx context.Context, db *mongo.Database) func(string) error {
+			repo := NewCheerRepository(db.Collection("cheers"))
+			registered, err := repo.Register(ctx, newTestComment(ownerId))
+			mustRegister(t, err)
+			return func(userId string) error {
+				return repo.Delete(ctx, userId, registered.Id)
+			}
+		}},
+		{"FeedItemRepository.Find", func(t *testing.T, ctx context.Context, db *mongo.Database) func(string) error {
+			repo := NewFeedItemRepository(db.Collection("feed_items"))
+			registered, err := repo.Register(ctx, &feed.Item{UserId: ownerId, HabitId: "habit-1", HabitName: "読書", Date: "2024-01-01"})
+			mustRegister(t, err)
+			return func(userId string) error {
+				_, err := repo.Find(ctx, userId, nil, registered.Id)
+				return err
+			}
+		}},
+		{"FriendshipRepository.Update", func(t *testing.T, ctx context.Context, db *mongo.Database) func(string) error {
+			repo := NewFriendshipRepository(db.Collection("friendships"))
+			registered, err := repo.Register(ctx, &friend.Friendship{RequesterId: ownerId, AddresseeId: friendId, Status: friend.StatusPending})
+			mustRegister(t, err)
+			return func(userId string) error {
+				registered.Status = friend.StatusAccepted
+				return repo.Update(ctx, userId, registered)
+			}
+		}},
+		{"FriendshipRepository.Delete", func(t *testing.T, ctx context.Context, db *mongo.Database) func(string) error {
+			repo := NewFriendshipRepository(db.Collection("friendships"))
+			registered, err := repo.Register(ctx, &friend.Friendship{RequesterId: ownerId, AddresseeId: friendId, Status: friend.StatusPending})
+			mustRegister(t, err)
+			return func(userId string) error {
+				return repo.Delete(ctx, userId, registered.Id)
+			}
+		}},
+		{"ReminderRepository.UpdateNextAt", func(t *testing.T, ctx context.Context, db *mongo.Database) func(string) error {
+			repo := NewReminderRepository(db.Collection("reminders"))
+			nextAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			registered, err := repo.ReplaceByHabit(ctx, ownerId, "habit-1", []*reminder.Reminder{{Time: "09:00", NextAt: nextAt}})
+			mustRegister(t, err)
+			// UpdateNextAtは該当なしでもエラーにならないため、更新されたかどうかを確認する
+			return func(userId string) error {
+				if err := repo.UpdateNextAt(ctx, userId, registered[0].Id, nextAt.Add(time.Hour)); err != nil {
+					return err
+				}
+				reminders, err := repo.FetchByUser(ctx, ownerId)
+				if err != nil {
+					return err
+				}
+				if !reminders[0].NextAt.Equal(nextAt.Add(time.Hour)) {
+					return common.ErrNotFound
+				}
+				return nil
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := newTestDatabase(t)
+			call := tt.setup(t, ctx, db)
+
+			if err := call(strangerId); !errors.Is(err, common.ErrNotFound) {
+				t.Fatalf("stranger: got error %v, want ErrNotFound", err)
+			}
+			if err := call(ownerId); err != nil {
+				t.Fatalf("owner: got error %v, want nil", err)
+			}
+		})
+	}
+}
+
+func mustRegister(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("failed to register: %v", err)
+	}
+}
+
+func newTestChallenge(ownerId string) *challenge.Challenge {
+	return &challenge.Challenge{
+		OwnerId:      ownerId,
+		Name:         "30日チャレンジ",
+		StartDate:    "2024-01-01",
+		EndDate:      "2024-01-30",
+		TargetDays:   20,
+		Participants: []*challenge.Participant{{UserId: ownerId, Status: challenge.StatusJoined}},
+	}
+}
+
+func newTestComment(userId string) *feed.Cheer {
+	return &feed.Cheer{ItemId: "item-1", ItemOwnerId: "item-owner", HabitId: "habit-1", Date: "2024-01-01", UserId: userId, Kind: feed.KindComment, Body: "がんばって"}
+}
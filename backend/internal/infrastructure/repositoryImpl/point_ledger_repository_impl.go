@@ -0,0 +1,228 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/model/point"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DBに保存するための内部モデル
+type ledgerEntryDB struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	UserId      string             `bson:"user_id"`
+	Amount      int                `bson:"amount"`
+	Reason      string             `bson:"reason"`
+	HabitId     string             `bson:"habit_id,omitempty"`
+	Date        string             `bson:"date,omitempty"`
+	Rule        string             `bson:"rule,omitempty"`
+	ChallengeId string             `bson:"challenge_id,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at"`
+}
+
+// PointLedgerRepository はMongoDBのpoints_ledgerコレクションにアクセスします
+// NOTE: エントリは追記のみで、更新・削除は行わない
+type PointLedgerRepository struct {
+	collection *mongo.Collection
+}
+
+// NewPointLedgerRepository は新しいPointLedgerRepositoryインスタンスを作成します
+func NewPointLedgerRepository(collection *mongo.Collection) repository.PointLedgerRepository {
+	return &PointLedgerRepository{
+		collection: collection,
+	}
+}
+
+// エントリ追加
+func (r *PointLedgerRepository) Append(ctx context.Context, entry *point.LedgerEntry) (*point.LedgerEntry, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	entryDB := ledgerEntryDB{
+		UserId:      entry.UserId,
+		Amount:      entry.Amount,
+		Reason:      string(entry.Reason),
+		HabitId:     entry.HabitId,
+		Date:        entry.Date,
+		Rule:        entry.Rule,
+		ChallengeId: entry.ChallengeId,
+		CreatedAt:   entry.CreatedAt,
+	}
+
+	result, err := r.collection.InsertOne(timeoutCtx, entryDB)
+	if err != nil {
+		log.Printf("[ERROR] PointLedgerRepository.Append() failed to collection.InsertOne (data: %+v) : %v", entryDB, err)
+		return nil, fmt.Errorf("failed to append ledger entry: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		entry.Id = oid.Hex()
+	}
+
+	return entry, nil
+}
+
+// 履歴取得（新しい順）
+// 取得したエントリと、ユーザーの全エントリ数を返す
+func (r *PointLedgerRepository) FetchHistory(ctx context.Context, userId string, offset int, limit int) ([]*point.LedgerEntry, int64, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userId}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(timeoutCtx, filter, opts)
+	if err != nil {
+		log.Printf("[ERROR] PointLedgerRepository.FetchHistory() failed to collection.Find (user_id: %s): %v", userId, err)
+		return nil, 0, fmt.Errorf("failed to fetch ledger entries: %w", err)
+	}
+
+	var entryDBs []ledgerEntryDB
+	if err = cursor.All(timeoutCtx, &entryDBs); err != nil {
+		log.Printf("[ERROR] PointLedgerRepository.FetchHistory() failed to cursor.All : %v", err)
+		return nil, 0, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	total, err := r.collection.CountDocuments(timeoutCtx, filter)
+	if err != nil {
+		log.Printf("[ERROR] PointLedgerRepository.FetchHistory() failed to collection.CountDocuments (user_id: %s): %v", userId, err)
+		return nil, 0, fmt.Errorf("failed to count ledger entries: %w", err)
+	}
+
+	entries := make([]*point.LedgerEntry, 0, len(entryDBs))
+	for _, entryDB := range entryDBs {
+		entries = append(entries, convertToLedgerEntry(&entryDB))
+	}
+
+	return entries, total, nil
+}
+
+// ユーザーの全エントリの合計（残高）
+func (r *PointLedgerRepository) SumAmount(ctx context.Context, userId string) (int, error) {
+	return r.sumAmount(ctx, "SumAmount", bson.M{"user_id": userId})
+}
+
+// 使用（交換）を除いたエントリの合計（経験値）
+func (r *PointLedgerRepository) SumEarned(ctx context.Context, userId string) (int, error) {
+	return r.sumAmount(ctx, "SumEarned", bson.M{"user_id": userId, "reason": bson.M{"$nin": point.SpendingReasons}})
+}
+
+// 指定した日付・習慣のエントリの合計（その習慣の完了で現在付与されているポイント）
+// NOTE: habitIdが空文字列の場合は、習慣に紐づかないその日のエントリ（全習慣完了のボーナスなど）を対象にする
+func (r *PointLedgerRepository) SumAmountByDate(ctx context.Context, userId string, date string, habitId string) (int, error) {
+	filter := bson.M{"user_id": userId, "date": date, "habit_id": habitId}
+	if habitId == "" {
+		filter["habit_id"] = bson.M{"$exists": false}
+	}
+	return r.sumAmount(ctx, "SumAmountByDate", filter)
+}
+
+// 条件に一致するエントリの合計
+func (r *PointLedgerRepository) sumAmount(ctx context.Context, method string, filter bson.M) (int, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "total": bson.M{"$sum": "$amount"}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(timeoutCtx, pipeline)
+	if err != nil {
+		log.Printf("[ERROR] PointLedgerRepository.%s() failed to collection.Aggregate (filter: %+v): %v", method, filter, err)
+		return 0, fmt.Errorf("failed to sum ledger entries: %w", err)
+	}
+
+	var results []struct {
+		Total int `bson:"total"`
+	}
+	if err = cursor.All(timeoutCtx, &results); err != nil {
+		log.Printf("[ERROR] PointLedgerRepository.%s() failed to cursor.All : %v", method, err)
+		return 0, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	// エントリが一件もない場合は0
+	if len(results) == 0 {
+		return 0, nil
+	}
+
+	return results[0].Total, nil
+}
+
+// ユーザーの全エントリを古い順に1件ずつfnに渡す（エクスポートで使用）
+// NOTE: 全件をメモリに載せないよう、カーソルから1件ずつデコードする（書き出しに時間がかかるため、タイムアウトは呼び出し元のctxに従う）
+func (r *PointLedgerRepository) EachEntry(ctx context.Context, userId string, fn func(entry *point.LedgerEntry) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userId}, opts)
+	if err != nil {
+		log.Printf("[ERROR] PointLedgerRepository.EachEntry() failed to collection.Find (user_id: %s): %v", userId, err)
+		return fmt.Errorf("failed to fetch ledger entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var entryDB ledgerEntryDB
+		if err := cursor.Decode(&entryDB); err != nil {
+			log.Printf("[ERROR] PointLedgerRepository.EachEntry() failed to cursor.Decode : %v", err)
+			return fmt.Errorf("failed to decode document from cursor: %w", err)
+		}
+		if err := fn(convertToLedgerEntry(&entryDB)); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		log.Printf("[ERROR] PointLedgerRepository.EachEntry() failed to cursor.Next : %v", err)
+		return fmt.Errorf("failed to iterate cursor: %w", err)
+	}
+
+	return nil
+}
+
+// ユーザーのポイント台帳のエントリを全て削除（アカウント削除時に使用）
+func (r *PointLedgerRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] PointLedgerRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete ledger entries: %w", err)
+	}
+
+	return nil
+}
+
+// DBモデルをドメインモデルに変換
+func convertToLedgerEntry(entryDB *ledgerEntryDB) *point.LedgerEntry {
+	return &point.LedgerEntry{
+		Id:          entryDB.ID.Hex(),
+		UserId:      entryDB.UserId,
+		Amount:      entryDB.Amount,
+		Reason:      point.Reason(entryDB.Reason),
+		HabitId:     entryDB.HabitId,
+		Date:        entryDB.Date,
+		Rule:        entryDB.Rule,
+		ChallengeId: entryDB.ChallengeId,
+		CreatedAt:   entryDB.CreatedAt,
+	}
+}
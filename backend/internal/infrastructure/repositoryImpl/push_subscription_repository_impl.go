@@ -0,0 +1,153 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/notification"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DBに保存するための内部モデル
+type pushSubscriptionDB struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserId    string             `bson:"user_id"`
+	Endpoint  string             `bson:"endpoint"`
+	P256dh    string             `bson:"p256dh"`
+	Auth      string             `bson:"auth"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+// PushSubscriptionRepository はMongoDBのpush_subscriptionsコレクションにアクセスします
+// NOTE: エンドポイントごとに1件（ユニークインデックス）
+type PushSubscriptionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewPushSubscriptionRepository は新しいPushSubscriptionRepositoryインスタンスを作成します
+func NewPushSubscriptionRepository(collection *mongo.Collection) repository.PushSubscriptionRepository {
+	return &PushSubscriptionRepository{
+		collection: collection,
+	}
+}
+
+// ユーザーの購読を全件取得
+func (r *PushSubscriptionRepository) FetchByUser(ctx context.Context, userId string) ([]*notification.PushSubscription, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] PushSubscriptionRepository.FetchByUser() failed to collection.Find (user_id: %s): %v", userId, err)
+		return nil, fmt.Errorf("failed to fetch push subscriptions: %w", err)
+	}
+
+	var subscriptionDBs []pushSubscriptionDB
+	if err = cursor.All(timeoutCtx, &subscriptionDBs); err != nil {
+		log.Printf("[ERROR] PushSubscriptionRepository.FetchByUser() failed to cursor.All : %v", err)
+		return nil, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	subscriptions := make([]*notification.PushSubscription, 0, len(subscriptionDBs))
+	for _, subscriptionDB := range subscriptionDBs {
+		subscriptions = append(subscriptions, convertToPushSubscription(&subscriptionDB))
+	}
+
+	return subscriptions, nil
+}
+
+// 購読の登録（同じエンドポイントが登録済みの場合は、ユーザーと鍵を置き換える）
+func (r *PushSubscriptionRepository) Register(ctx context.Context, subscription *notification.PushSubscription) (*notification.PushSubscription, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"endpoint": subscription.Endpoint}
+	update := bson.M{
+		"$set": bson.M{
+			"user_id": subscription.UserId,
+			"p256dh":  subscription.P256dh,
+			"auth":    subscription.Auth,
+		},
+		"$setOnInsert": bson.M{"created_at": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var subscriptionDB pushSubscriptionDB
+	err := r.collection.FindOneAndUpdate(timeoutCtx, filter, update, opts).Decode(&subscriptionDB)
+	if err != nil {
+		log.Printf("[ERROR] PushSubscriptionRepository.Register() failed to collection.FindOneAndUpdate (user_id: %s, endpoint: %s) : %v", subscription.UserId, subscription.Endpoint, err)
+		return nil, fmt.Errorf("failed to register push subscription: %w", err)
+	}
+
+	return convertToPushSubscription(&subscriptionDB), nil
+}
+
+// ユーザーの購読を削除（登録されていない場合はErrNotFound）
+func (r *PushSubscriptionRepository) Delete(ctx context.Context, userId string, endpoint string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(timeoutCtx, bson.M{"user_id": userId, "endpoint": endpoint})
+	if err != nil {
+		log.Printf("[ERROR] PushSubscriptionRepository.Delete() failed to collection.DeleteOne (user_id: %s, endpoint: %s) : %v", userId, endpoint, err)
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// 期限切れの購読を削除（プッシュサービスが404・410を返した場合に使用）
+func (r *PushSubscriptionRepository) DeleteByEndpoint(ctx context.Context, endpoint string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteOne(timeoutCtx, bson.M{"endpoint": endpoint})
+	if err != nil {
+		log.Printf("[ERROR] PushSubscriptionRepository.DeleteByEndpoint() failed to collection.DeleteOne (endpoint: %s) : %v", endpoint, err)
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+
+	return nil
+}
+
+// ユーザーの購読を全て削除（アカウント削除時に使用）
+func (r *PushSubscriptionRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] PushSubscriptionRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete push subscriptions: %w", err)
+	}
+
+	return nil
+}
+
+// DBモデルをドメインモデルに変換
+func convertToPushSubscription(subscriptionDB *pushSubscriptionDB) *notification.PushSubscription {
+	return &notification.PushSubscription{
+		Id:        subscriptionDB.ID.Hex(),
+		UserId:    subscriptionDB.UserId,
+		Endpoint:  subscriptionDB.Endpoint,
+		P256dh:    subscriptionDB.P256dh,
+		Auth:      subscriptionDB.Auth,
+		CreatedAt: subscriptionDB.CreatedAt,
+	}
+}
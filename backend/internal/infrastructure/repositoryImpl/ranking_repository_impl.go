@@ -0,0 +1,220 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/ranking"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DBに保存するための内部モデル
+type scoreDB struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	UserId      string             `bson:"user_id"`
+	Board       string             `bson:"board"`
+	Period      string             `bson:"period"`
+	Score       int                `bson:"score"`
+	Hidden      bool               `bson:"hidden"`
+	ActiveUntil string             `bson:"active_until,omitempty"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+}
+
+// RankingRepository はMongoDBのrankingsコレクションにアクセスします
+// NOTE: ユーザー・ランキングの種類・集計期間ごとに1ドキュメントを持ち、完了の度に更新する（集計はしない）
+type RankingRepository struct {
+	collection *mongo.Collection
+}
+
+// NewRankingRepository は新しいRankingRepositoryインスタンスを作成します
+func NewRankingRepository(collection *mongo.Collection) repository.RankingRepository {
+	return &RankingRepository{
+		collection: collection,
+	}
+}
+
+// スコアをアトミックに加算（負の場合は減算）する（未集計の場合は作成）
+func (r *RankingRepository) IncrementScore(ctx context.Context, score *ranking.Score) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": score.UserId, "board": string(score.Board), "period": score.Period}
+	update := bson.M{
+		"$inc": bson.M{"score": score.Score},
+		"$set": bson.M{"hidden": score.Hidden, "updated_at": time.Now()},
+	}
+
+	_, err := r.collection.UpdateOne(timeoutCtx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		log.Printf("[ERROR] RankingRepository.IncrementScore() failed to collection.UpdateOne (filter: %+v, delta: %d) : %v", filter, score.Score, err)
+		return fmt.Errorf("failed to increment score: %w", err)
+	}
+
+	return nil
+}
+
+// スコアを上書きする（未集計の場合は作成）
+func (r *RankingRepository) UpdateScore(ctx context.Context, score *ranking.Score) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": score.UserId, "board": string(score.Board), "period": score.Period}
+	update := bson.M{
+		"$set": bson.M{
+			"score":        score.Score,
+			"hidden":       score.Hidden,
+			"active_until": score.ActiveUntil,
+			"updated_at":   time.Now(),
+		},
+	}
+
+	_, err := r.collection.UpdateOne(timeoutCtx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		log.Printf("[ERROR] RankingRepository.UpdateScore() failed to collection.UpdateOne (filter: %+v, score: %d) : %v", filter, score.Score, err)
+		return fmt.Errorf("failed to update score: %w", err)
+	}
+
+	return nil
+}
+
+// ユーザーのスコアを取得（未集計の場合はErrNotFound）
+func (r *RankingRepository) FindScore(ctx context.Context, userId string, board ranking.Board, period string) (*ranking.Score, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userId, "board": string(board), "period": period}
+
+	var scoreDB scoreDB
+	err := r.collection.FindOne(timeoutCtx, filter).Decode(&scoreDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, common.ErrNotFound
+		}
+		log.Printf("[ERROR] RankingRepository.FindScore() failed to collection.FindOne (filter: %+v) : %v", filter, err)
+		return nil, fmt.Errorf("failed to find score: %w", err)
+	}
+
+	return convertToScore(&scoreDB), nil
+}
+
+// 公開しているユーザーのスコアを高い順に取得
+// NOTE: activeFromを指定した場合は、active_untilがその日以降のスコアのみ対象にする（途切れた連続達成を除く）
+// userIdsを指定した場合は、そのユーザーのスコアのみ対象にする（nilの場合は全ユーザー）
+func (r *RankingRepository) FetchTop(ctx context.Context, board ranking.Board, period string, activeFrom string, userIds []string, offset int, limit int) ([]*ranking.Score, int64, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := rankingFilter(board, period, activeFrom, userIds)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "score", Value: -1}, {Key: "user_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(timeoutCtx, filter, opts)
+	if err != nil {
+		log.Printf("[ERROR] RankingRepository.FetchTop() failed to collection.Find (filter: %+v): %v", filter, err)
+		return nil, 0, fmt.Errorf("failed to fetch scores: %w", err)
+	}
+
+	var scoreDBs []scoreDB
+	if err = cursor.All(timeoutCtx, &scoreDBs); err != nil {
+		log.Printf("[ERROR] RankingRepository.FetchTop() failed to cursor.All : %v", err)
+		return nil, 0, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	total, err := r.collection.CountDocuments(timeoutCtx, filter)
+	if err != nil {
+		log.Printf("[ERROR] RankingRepository.FetchTop() failed to collection.CountDocuments (filter: %+v): %v", filter, err)
+		return nil, 0, fmt.Errorf("failed to count scores: %w", err)
+	}
+
+	scores := make([]*ranking.Score, 0, len(scoreDBs))
+	for _, scoreDB := range scoreDBs {
+		scores = append(scores, convertToScore(&scoreDB))
+	}
+
+	return scores, total, nil
+}
+
+// 指定したスコアより高い公開ユーザーの数（順位の算出に使用）
+func (r *RankingRepository) CountHigher(ctx context.Context, board ranking.Board, period string, activeFrom string, userIds []string, score int) (int64, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := rankingFilter(board, period, activeFrom, userIds)
+	filter["score"] = bson.M{"$gt": score}
+
+	count, err := r.collection.CountDocuments(timeoutCtx, filter)
+	if err != nil {
+		log.Printf("[ERROR] RankingRepository.CountHigher() failed to collection.CountDocuments (filter: %+v): %v", filter, err)
+		return 0, fmt.Errorf("failed to count scores: %w", err)
+	}
+
+	return count, nil
+}
+
+// ユーザーの全スコアの公開設定を更新
+func (r *RankingRepository) UpdateHidden(ctx context.Context, userId string, hidden bool) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateMany(timeoutCtx, bson.M{"user_id": userId}, bson.M{"$set": bson.M{"hidden": hidden}})
+	if err != nil {
+		log.Printf("[ERROR] RankingRepository.UpdateHidden() failed to collection.UpdateMany (user_id: %s, hidden: %t) : %v", userId, hidden, err)
+		return fmt.Errorf("failed to update hidden: %w", err)
+	}
+
+	return nil
+}
+
+// ユーザーのスコアを全て削除（アカウント削除時に使用）
+func (r *RankingRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] RankingRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete scores: %w", err)
+	}
+
+	return nil
+}
+
+// ランキングに表示するスコアの条件
+func rankingFilter(board ranking.Board, period string, activeFrom string, userIds []string) bson.M {
+	filter := bson.M{"board": string(board), "period": period, "hidden": false}
+	if activeFrom != "" {
+		filter["active_until"] = bson.M{"$gte": activeFrom}
+	}
+	if userIds != nil {
+		filter["user_id"] = bson.M{"$in": userIds}
+	}
+	return filter
+}
+
+// DBモデルをドメインモデルに変換
+func convertToScore(scoreDB *scoreDB) *ranking.Score {
+	return &ranking.Score{
+		UserId:      scoreDB.UserId,
+		Board:       ranking.Board(scoreDB.Board),
+		Period:      scoreDB.Period,
+		Score:       scoreDB.Score,
+		Hidden:      scoreDB.Hidden,
+		ActiveUntil: scoreDB.ActiveUntil,
+		UpdatedAt:   scoreDB.UpdatedAt,
+	}
+}
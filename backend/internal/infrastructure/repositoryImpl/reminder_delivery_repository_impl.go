@@ -0,0 +1,85 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/reminder"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DBに保存するための内部モデル
+type reminderDeliveryDB struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	ReminderId string             `bson:"reminder_id"`
+	UserId     string             `bson:"user_id"`
+	Date       string             `bson:"date"`
+	CreatedAt  time.Time          `bson:"created_at"`
+}
+
+// ReminderDeliveryRepository はMongoDBのreminder_deliveriesコレクションにアクセスします
+// NOTE: リマインダー・日付の組ごとに1件（ユニークインデックス）。古い記録はTTLインデックスで自動削除する
+type ReminderDeliveryRepository struct {
+	collection *mongo.Collection
+}
+
+// NewReminderDeliveryRepository は新しいReminderDeliveryRepositoryインスタンスを作成します
+func NewReminderDeliveryRepository(collection *mongo.Collection) repository.ReminderDeliveryRepository {
+	return &ReminderDeliveryRepository{
+		collection: collection,
+	}
+}
+
+// 送信済みの記録を登録（同じリマインダー・日付を送信済みの場合はErrAlreadyExists）
+func (r *ReminderDeliveryRepository) Register(ctx context.Context, delivery *reminder.Delivery) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = time.Now()
+	}
+
+	deliveryDB := reminderDeliveryDB{
+		ReminderId: delivery.ReminderId,
+		UserId:     delivery.UserId,
+		Date:       delivery.Date,
+		CreatedAt:  delivery.CreatedAt,
+	}
+
+	_, err := r.collection.InsertOne(timeoutCtx, deliveryDB)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return common.ErrAlreadyExists
+		}
+		log.Printf("[ERROR] ReminderDeliveryRepository.Register() failed to collection.InsertOne (data: %+v) : %v", deliveryDB, err)
+		return fmt.Errorf("failed to register reminder delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ユーザーの送信済みの記録を全て削除（アカウント削除時に使用）
+func (r *ReminderDeliveryRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] ReminderDeliveryRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete reminder deliveries: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/reminder"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DBに保存するための内部モデル
+type reminderDB struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserId    string             `bson:"user_id"`
+	HabitId   string             `bson:"habit_id"`
+	Time      string             `bson:"time"`
+	NextAt    time.Time          `bson:"next_at"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+// ReminderRepository はMongoDBのremindersコレクションにアクセスします
+type ReminderRepository struct {
+	collection *mongo.Collection
+}
+
+// NewReminderRepository は新しいReminderRepositoryインスタンスを作成します
+func NewReminderRepository(collection *mongo.Collection) repository.ReminderRepository {
+	return &ReminderRepository{
+		collection: collection,
+	}
+}
+
+// 習慣のリマインダーを時刻順に取得
+func (r *ReminderRepository) FetchByHabit(ctx context.Context, userId string, habitId string) ([]*reminder.Reminder, error) {
+	return r.fetch(ctx, "FetchByHabit", bson.M{"user_id": userId, "habit_id": habitId}, options.Find().SetSort(bson.D{{Key: "time", Value: 1}}))
+}
+
+// ユーザーの全てのリマインダーを取得
+func (r *ReminderRepository) FetchByUser(ctx context.Context, userId string) ([]*reminder.Reminder, error) {
+	return r.fetch(ctx, "FetchByUser", bson.M{"user_id": userId}, options.Find())
+}
+
+// 送信日時を過ぎたリマインダーを送信日時の古い順に取得
+func (r *ReminderRepository) FetchDue(ctx context.Context, now time.Time, limit int) ([]*reminder.Reminder, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "next_at", Value: 1}}).
+		SetLimit(int64(limit))
+	return r.fetch(ctx, "FetchDue", bson.M{"next_at": bson.M{"$lte": now}}, opts)
+}
+
+// 習慣のリマインダーを指定したものに置き換える
+func (r *ReminderRepository) ReplaceByHabit(ctx context.Context, userId string, habitId string, reminders []*reminder.Reminder) ([]*reminder.Reminder, error) {
+	if err := r.DeleteByHabit(ctx, userId, habitId); err != nil {
+		return nil, err
+	}
+	if len(reminders) == 0 {
+		return reminders, nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	documents := make([]interface{}, 0, len(reminders))
+	for _, target := range reminders {
+		target.UserId, target.HabitId, target.CreatedAt = userId, habitId, now
+		documents = append(documents, reminderDB{
+			UserId:    target.UserId,
+			HabitId:   target.HabitId,
+			Time:      target.Time,
+			NextAt:    target.NextAt,
+			CreatedAt: target.CreatedAt,
+		})
+	}
+
+	result, err := r.collection.InsertMany(timeoutCtx, documents)
+	if err != nil {
+		log.Printf("[ERROR] ReminderRepository.ReplaceByHabit() failed to collection.InsertMany (user_id: %s, habit_id: %s) : %v", userId, habitId, err)
+		return nil, fmt.Errorf("failed to register reminders: %w", err)
+	}
+
+	for i, insertedID := range result.InsertedIDs {
+		if oid, ok := insertedID.(primitive.ObjectID); ok {
+			reminders[i].Id = oid.Hex()
+		}
+	}
+
+	return reminders, nil
+}
+
+// 送信日時をfromからtoに進める（他の処理が先に進めていた場合はfalse）
+// NOTE: 複数台で実行しても、同じ送信日時のリマインダーを処理するのは1台のみになる
+func (r *ReminderRepository) Advance(ctx context.Context, id string, from time.Time, to time.Time) (bool, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, common.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objectID, "next_at": from}
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, bson.M{"$set": bson.M{"next_at": to}})
+	if err != nil {
+		log.Printf("[ERROR] ReminderRepository.Advance() failed to collection.UpdateOne (_id: %s, from: %v, to: %v) : %v", id, from, to, err)
+		return false, fmt.Errorf("failed to advance reminder: %w", err)
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// 送信日時の更新（タイムゾーンの変更時に使用）
+func (r *ReminderRepository) UpdateNextAt(ctx context.Context, userId string, id string, nextAt time.Time) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	_, err = r.collection.UpdateOne(timeoutCtx, bson.M{"_id": objectID, "user_id": userId}, bson.M{"$set": bson.M{"next_at": nextAt}})
+	if err != nil {
+		log.Printf("[ERROR] ReminderRepository.UpdateNextAt() failed to collection.UpdateOne (_id: %s, user_id: %s, next_at: %v) : %v", id, userId, nextAt, err)
+		return fmt.Errorf("failed to update reminder: %w", err)
+	}
+
+	return nil
+}
+
+// 習慣のリマインダーを全て削除
+func (r *ReminderRepository) DeleteByHabit(ctx context.Context, userId string, habitId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId, "habit_id": habitId})
+	if err != nil {
+		log.Printf("[ERROR] ReminderRepository.DeleteByHabit() failed to collection.DeleteMany (user_id: %s, habit_id: %s) : %v", userId, habitId, err)
+		return fmt.Errorf("failed to delete reminders: %w", err)
+	}
+
+	return nil
+}
+
+// ユーザーのリマインダーを全て削除（アカウント削除時に使用）
+func (r *ReminderRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] ReminderRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete reminders: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReminderRepository) fetch(ctx context.Context, method string, filter bson.M, opts *options.FindOptions) ([]*reminder.Reminder, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(timeoutCtx, filter, opts)
+	if err != nil {
+		log.Printf("[ERROR] ReminderRepository.%s() failed to collection.Find (filter: %+v): %v", method, filter, err)
+		return nil, fmt.Errorf("failed to fetch reminders: %w", err)
+	}
+
+	var reminderDBs []reminderDB
+	if err = cursor.All(timeoutCtx, &reminderDBs); err != nil {
+		log.Printf("[ERROR] ReminderRepository.%s() failed to cursor.All : %v", method, err)
+		return nil, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	reminders := make([]*reminder.Reminder, 0, len(reminderDBs))
+	for _, reminderDB := range reminderDBs {
+		reminders = append(reminders, convertToReminder(&reminderDB))
+	}
+
+	return reminders, nil
+}
+
+// DBモデルをドメインモデルに変換
+func convertToReminder(reminderDB *reminderDB) *reminder.Reminder {
+	return &reminder.Reminder{
+		Id:        reminderDB.ID.Hex(),
+		UserId:    reminderDB.UserId,
+		HabitId:   reminderDB.HabitId,
+		Time:      reminderDB.Time,
+		NextAt:    reminderDB.NextAt,
+		CreatedAt: reminderDB.CreatedAt,
+	}
+}
@@ -0,0 +1,136 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/model/reward"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DBに保存するための内部モデル
+type rewardRedemptionDB struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	UserId     string             `bson:"user_id"`
+	RewardId   string             `bson:"reward_id"`
+	RewardName string             `bson:"reward_name"`
+	Cost       int                `bson:"cost"`
+	CreatedAt  time.Time          `bson:"created_at"`
+}
+
+// RewardRedemptionRepository はMongoDBのreward_redemptionsコレクションにアクセスします
+type RewardRedemptionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewRewardRedemptionRepository は新しいRewardRedemptionRepositoryインスタンスを作成します
+func NewRewardRedemptionRepository(collection *mongo.Collection) repository.RewardRedemptionRepository {
+	return &RewardRedemptionRepository{
+		collection: collection,
+	}
+}
+
+// 交換履歴の登録
+func (r *RewardRedemptionRepository) Register(ctx context.Context, redemption *reward.Redemption) (*reward.Redemption, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if redemption.CreatedAt.IsZero() {
+		redemption.CreatedAt = time.Now()
+	}
+
+	redemptionDB := rewardRedemptionDB{
+		UserId:     redemption.UserId,
+		RewardId:   redemption.RewardId,
+		RewardName: redemption.RewardName,
+		Cost:       redemption.Cost,
+		CreatedAt:  redemption.CreatedAt,
+	}
+
+	result, err := r.collection.InsertOne(timeoutCtx, redemptionDB)
+	if err != nil {
+		log.Printf("[ERROR] RewardRedemptionRepository.Register() failed to collection.InsertOne (data: %+v) : %v", redemptionDB, err)
+		return nil, fmt.Errorf("failed to register reward redemption: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		redemption.Id = oid.Hex()
+	}
+
+	return redemption, nil
+}
+
+// 交換履歴を新しい順に取得し、全件数とあわせて返す
+func (r *RewardRedemptionRepository) FetchHistory(ctx context.Context, userId string, offset int, limit int) ([]*reward.Redemption, int64, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userId}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(timeoutCtx, filter, opts)
+	if err != nil {
+		log.Printf("[ERROR] RewardRedemptionRepository.FetchHistory() failed to collection.Find (user_id: %s): %v", userId, err)
+		return nil, 0, fmt.Errorf("failed to fetch reward redemptions: %w", err)
+	}
+
+	var redemptionDBs []rewardRedemptionDB
+	if err = cursor.All(timeoutCtx, &redemptionDBs); err != nil {
+		log.Printf("[ERROR] RewardRedemptionRepository.FetchHistory() failed to cursor.All : %v", err)
+		return nil, 0, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	total, err := r.collection.CountDocuments(timeoutCtx, filter)
+	if err != nil {
+		log.Printf("[ERROR] RewardRedemptionRepository.FetchHistory() failed to collection.CountDocuments (user_id: %s): %v", userId, err)
+		return nil, 0, fmt.Errorf("failed to count reward redemptions: %w", err)
+	}
+
+	redemptions := make([]*reward.Redemption, 0, len(redemptionDBs))
+	for _, redemptionDB := range redemptionDBs {
+		redemptions = append(redemptions, convertToRewardRedemption(&redemptionDB))
+	}
+
+	return redemptions, total, nil
+}
+
+// ユーザーの交換履歴を全て削除（アカウント削除時に使用）
+func (r *RewardRedemptionRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] RewardRedemptionRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete reward redemptions: %w", err)
+	}
+
+	return nil
+}
+
+// DBモデルをドメインモデルに変換
+func convertToRewardRedemption(redemptionDB *rewardRedemptionDB) *reward.Redemption {
+	return &reward.Redemption{
+		Id:         redemptionDB.ID.Hex(),
+		UserId:     redemptionDB.UserId,
+		RewardId:   redemptionDB.RewardId,
+		RewardName: redemptionDB.RewardName,
+		Cost:       redemptionDB.Cost,
+		CreatedAt:  redemptionDB.CreatedAt,
+	}
+}
@@ -0,0 +1,291 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/reward"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DBに保存するための内部モデル
+type rewardDB struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	UserId         string             `bson:"user_id"`
+	Name           string             `bson:"name"`
+	Cost           int                `bson:"cost"`
+	Stock          *int               `bson:"stock"`
+	CooldownHours  int                `bson:"cooldown_hours"`
+	LastRedeemedAt *time.Time         `bson:"last_redeemed_at"`
+	CreatedAt      time.Time          `bson:"created_at"`
+}
+
+// RewardRepository はMongoDBのrewardsコレクションにアクセスします
+type RewardRepository struct {
+	collection *mongo.Collection
+}
+
+// NewRewardRepository は新しいRewardRepositoryインスタンスを作成します
+func NewRewardRepository(collection *mongo.Collection) repository.RewardRepository {
+	return &RewardRepository{
+		collection: collection,
+	}
+}
+
+// ごほうび一覧取得（作成順）
+func (r *RewardRepository) FetchAll(ctx context.Context, userId string) ([]*reward.Reward, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(timeoutCtx, bson.M{"user_id": userId}, opts)
+	if err != nil {
+		log.Printf("[ERROR] RewardRepository.FetchAll() failed to collection.Find (user_id: %s): %v", userId, err)
+		return nil, fmt.Errorf("failed to fetch rewards: %w", err)
+	}
+
+	var rewardDBs []rewardDB
+	if err = cursor.All(timeoutCtx, &rewardDBs); err != nil {
+		log.Printf("[ERROR] RewardRepository.FetchAll() failed to cursor.All : %v", err)
+		return nil, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	rewards := make([]*reward.Reward, 0, len(rewardDBs))
+	for _, rewardDB := range rewardDBs {
+		rewards = append(rewards, convertToReward(&rewardDB))
+	}
+
+	return rewards, nil
+}
+
+// ごほうび取得
+func (r *RewardRepository) Find(ctx context.Context, userId string, id string) (*reward.Reward, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	var rewardDB rewardDB
+	err = r.collection.FindOne(timeoutCtx, bson.M{"_id": objectID, "user_id": userId}).Decode(&rewardDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, common.ErrNotFound
+		}
+		log.Printf("[ERROR] RewardRepository.Find() failed to collection.FindOne (_id: %s, user_id: %s) : %v", id, userId, err)
+		return nil, fmt.Errorf("failed to find reward: %w", err)
+	}
+
+	return convertToReward(&rewardDB), nil
+}
+
+// ごほうび登録
+func (r *RewardRepository) Register(ctx context.Context, reward *reward.Reward) (*reward.Reward, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// nameの重複チェック
+	var existingRewardDB rewardDB
+	err := r.collection.FindOne(timeoutCtx, bson.M{"user_id": reward.UserId, "name": reward.Name}).Decode(&existingRewardDB)
+	if err == nil {
+		return nil, common.ErrAlreadyExists
+	}
+	if err != mongo.ErrNoDocuments {
+		log.Printf("[ERROR] RewardRepository.Register() failed to collection.FindOne (name: %s) : %v", reward.Name, err)
+		return nil, fmt.Errorf("failed to check for existing reward: %w", err)
+	}
+
+	if reward.CreatedAt.IsZero() {
+		reward.CreatedAt = time.Now()
+	}
+
+	rewardDB := rewardDB{
+		UserId:        reward.UserId,
+		Name:          reward.Name,
+		Cost:          reward.Cost,
+		Stock:         reward.Stock,
+		CooldownHours: reward.CooldownHours,
+		CreatedAt:     reward.CreatedAt,
+	}
+
+	result, err := r.collection.InsertOne(timeoutCtx, rewardDB)
+	if err != nil {
+		log.Printf("[ERROR] RewardRepository.Register() failed to collection.InsertOne (data: %+v) : %v", rewardDB, err)
+		return nil, fmt.Errorf("failed to register reward: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		reward.Id = oid.Hex()
+	}
+
+	return reward, nil
+}
+
+// ごほうびの更新（名前・ポイント・在庫・クールダウン）
+func (r *RewardRepository) Update(ctx context.Context, reward *reward.Reward) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(reward.Id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	// nameの重複チェック（自分自身は除く）
+	var existingRewardDB rewardDB
+	err = r.collection.FindOne(timeoutCtx, bson.M{"user_id": reward.UserId, "name": reward.Name, "_id": bson.M{"$ne": objectID}}).Decode(&existingRewardDB)
+	if err == nil {
+		return common.ErrAlreadyExists
+	}
+	if err != mongo.ErrNoDocuments {
+		log.Printf("[ERROR] RewardRepository.Update() failed to collection.FindOne (name: %s) : %v", reward.Name, err)
+		return fmt.Errorf("failed to check for existing reward: %w", err)
+	}
+
+	filter := bson.M{"_id": objectID, "user_id": reward.UserId}
+	update := bson.M{
+		"$set": bson.M{
+			"name":           reward.Name,
+			"cost":           reward.Cost,
+			"stock":          reward.Stock,
+			"cooldown_hours": reward.CooldownHours,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] RewardRepository.Update() failed to collection.UpdateOne (_id: %s, data: %+v) : %v", reward.Id, update, err)
+		return fmt.Errorf("failed to update reward: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// ごほうびの交換を記録し、更新後のごほうびを返す
+// 在庫・クールダウンの条件を満たす場合のみ、在庫の減算と交換日時の更新をアトミックに行う
+// 条件を満たさない場合はErrRewardOutOfStockもしくはErrRewardCoolingDownを返す
+func (r *RewardRepository) Redeem(ctx context.Context, reward *reward.Reward, now time.Time) (*reward.Reward, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(reward.Id)
+	if err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objectID, "user_id": reward.UserId}
+	update := bson.M{"$set": bson.M{"last_redeemed_at": now}}
+
+	// 在庫がある場合のみ減算する
+	if reward.Stock != nil {
+		filter["stock"] = bson.M{"$gt": 0}
+		update["$inc"] = bson.M{"stock": -1}
+	}
+
+	// クールダウンが明けている場合のみ
+	if reward.CooldownHours > 0 {
+		availableFrom := now.Add(-time.Duration(reward.CooldownHours) * time.Hour)
+		filter["$or"] = bson.A{
+			bson.M{"last_redeemed_at": nil},
+			bson.M{"last_redeemed_at": bson.M{"$lte": availableFrom}},
+		}
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var rewardDB rewardDB
+	err = r.collection.FindOneAndUpdate(timeoutCtx, filter, update, opts).Decode(&rewardDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.redeemError(timeoutCtx, reward, now)
+		}
+		log.Printf("[ERROR] RewardRepository.Redeem() failed to collection.FindOneAndUpdate (_id: %s) : %v", reward.Id, err)
+		return nil, fmt.Errorf("failed to redeem reward: %w", err)
+	}
+
+	return convertToReward(&rewardDB), nil
+}
+
+// ごほうび削除（交換履歴は残す）
+func (r *RewardRepository) Delete(ctx context.Context, userId string, id string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	result, err := r.collection.DeleteOne(timeoutCtx, bson.M{"_id": objectID, "user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] RewardRepository.Delete() failed to collection.DeleteOne (_id: %s) : %v", id, err)
+		return fmt.Errorf("failed to delete reward: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// ユーザーのごほうびを全て削除（アカウント削除時に使用）
+func (r *RewardRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] RewardRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete rewards: %w", err)
+	}
+
+	return nil
+}
+
+// 交換の条件を満たさなかった理由を最新の状態から判定する（同時に交換された場合など）
+func (r *RewardRepository) redeemError(ctx context.Context, target *reward.Reward, now time.Time) error {
+	latest, err := r.Find(ctx, target.UserId, target.Id)
+	if err != nil {
+		return err
+	}
+
+	if err := latest.CanRedeem(now); err != nil {
+		return err
+	}
+	// 判定の間に条件が変わった場合
+	return common.ErrRewardCoolingDown
+}
+
+// DBモデルをドメインモデルに変換
+func convertToReward(rewardDB *rewardDB) *reward.Reward {
+	return &reward.Reward{
+		Id:             rewardDB.ID.Hex(),
+		UserId:         rewardDB.UserId,
+		Name:           rewardDB.Name,
+		Cost:           rewardDB.Cost,
+		Stock:          rewardDB.Stock,
+		CooldownHours:  rewardDB.CooldownHours,
+		LastRedeemedAt: rewardDB.LastRedeemedAt,
+		CreatedAt:      rewardDB.CreatedAt,
+	}
+}
@@ -0,0 +1,209 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/session"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DBに保存するための内部モデル
+type sessionDB struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty"`
+	UserId           string             `bson:"user_id"`
+	RefreshTokenHash string             `bson:"refresh_token_hash"`
+	UsedTokenHashes  []string           `bson:"used_token_hashes"`
+	ExpiresAt        time.Time          `bson:"expires_at"`
+	RevokedAt        *time.Time         `bson:"revoked_at"`
+	CreatedAt        time.Time          `bson:"created_at"`
+}
+
+// SessionRepository はMongoDBのsessionsコレクションにアクセスします
+type SessionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSessionRepository は新しいSessionRepositoryインスタンスを作成します
+func NewSessionRepository(collection *mongo.Collection) repository.SessionRepository {
+	return &SessionRepository{
+		collection: collection,
+	}
+}
+
+// セッション取得
+func (r *SessionRepository) Find(ctx context.Context, id string) (*session.Session, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// MongoDBの_idはObjectID型で保存される
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	var sessionDB sessionDB
+	err = r.collection.FindOne(timeoutCtx, bson.M{"_id": objectID}).Decode(&sessionDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, common.ErrNotFound
+		}
+		log.Printf("[ERROR] SessionRepository.Find() failed to collection.FindOne (_id: %s) : %v", id, err)
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	return convertToSession(&sessionDB), nil
+}
+
+// セッション登録
+func (r *SessionRepository) Register(ctx context.Context, session *session.Session) (*session.Session, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = time.Now()
+	}
+
+	sessionDB := sessionDB{
+		UserId:           session.UserId,
+		RefreshTokenHash: session.RefreshTokenHash,
+		UsedTokenHashes:  []string{},
+		ExpiresAt:        session.ExpiresAt,
+		CreatedAt:        session.CreatedAt,
+	}
+
+	result, err := r.collection.InsertOne(timeoutCtx, sessionDB)
+	if err != nil {
+		log.Printf("[ERROR] SessionRepository.Register() failed to collection.InsertOne (user_id: %s) : %v", session.UserId, err)
+		return nil, fmt.Errorf("failed to register session: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		session.Id = oid.Hex()
+	}
+
+	return session, nil
+}
+
+// リフレッシュトークンを置き換え、使用済みのハッシュを記録する
+// NOTE: フィルタに現在のハッシュを含めることで、同じトークンでのローテーションは一度しか成功しない
+func (r *SessionRepository) Rotate(ctx context.Context, id string, currentHash string, newHash string, expiresAt time.Time) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objectID, "refresh_token_hash": currentHash, "revoked_at": nil}
+	update := bson.M{
+		"$set":  bson.M{"refresh_token_hash": newHash, "expires_at": expiresAt},
+		"$push": bson.M{"used_token_hashes": currentHash},
+	}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] SessionRepository.Rotate() failed to collection.UpdateOne (_id: %s) : %v", id, err)
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// セッションを失効させる
+func (r *SessionRepository) Revoke(ctx context.Context, userId string, id string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objectID, "user_id": userId}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] SessionRepository.Revoke() failed to collection.UpdateOne (_id: %s, user_id: %s) : %v", id, userId, err)
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// ユーザーの有効なセッションをすべて失効させる
+func (r *SessionRepository) RevokeAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userId, "revoked_at": nil}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+
+	_, err := r.collection.UpdateMany(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] SessionRepository.RevokeAll() failed to collection.UpdateMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+// 指定したセッション以外の有効なセッションを失効させる（パスワード変更時に使用）
+func (r *SessionRepository) RevokeOthers(ctx context.Context, userId string, id string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return common.ErrNotFound
+	}
+
+	filter := bson.M{"user_id": userId, "_id": bson.M{"$ne": objectID}, "revoked_at": nil}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+
+	_, err = r.collection.UpdateMany(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] SessionRepository.RevokeOthers() failed to collection.UpdateMany (user_id: %s, _id: %s) : %v", userId, id, err)
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+// DBモデルをドメインモデルに変換
+func convertToSession(sessionDB *sessionDB) *session.Session {
+	return &session.Session{
+		Id:               sessionDB.ID.Hex(),
+		UserId:           sessionDB.UserId,
+		RefreshTokenHash: sessionDB.RefreshTokenHash,
+		UsedTokenHashes:  sessionDB.UsedTokenHashes,
+		ExpiresAt:        sessionDB.ExpiresAt,
+		RevokedAt:        sessionDB.RevokedAt,
+		CreatedAt:        sessionDB.CreatedAt,
+	}
+}
@@ -0,0 +1,131 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"backend/internal/domain/model/stats"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// 集計結果の内部モデル
+type completionDB struct {
+	ID        interface{} `bson:"_id"`
+	HabitName string      `bson:"habit_name"`
+	Done      int         `bson:"done"`
+	Scheduled int         `bson:"scheduled"`
+}
+
+// StatsRepository はMongoDBのdaily_trackコレクションを集計します
+// NOTE: ドキュメントをGoに読み込まず、集計はすべてaggregationパイプラインで行う
+type StatsRepository struct {
+	collection *mongo.Collection
+}
+
+// NewStatsRepository は新しいStatsRepositoryインスタンスを作成します
+func NewStatsRepository(collection *mongo.Collection) repository.StatsRepository {
+	return &StatsRepository{
+		collection: collection, // daily_track collection
+	}
+}
+
+// 期間内のdaily_trackの達成状況を集計単位ごとに集計（集計単位の昇順）
+// habitIdが空文字列の場合は全習慣を対象にする
+func (r *StatsRepository) AggregateCompletion(ctx context.Context, userId string, fromDate string, toDate string, habitId string, groupBy stats.GroupBy) ([]*stats.Completion, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	groupKey, err := completionGroupKey(groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userId, "date": bson.M{"$gte": fromDate, "$lte": toDate}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "date", Value: 1}}}},
+		{{Key: "$unwind", Value: "$habit_statuses"}},
+	}
+	if habitId != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"habit_statuses.habit_id": habitId}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":        groupKey,
+			"done":       bson.M{"$sum": bson.M{"$cond": bson.A{"$habit_statuses.is_done", 1, 0}}},
+			"scheduled":  bson.M{"$sum": 1},
+			"habit_name": bson.M{"$last": "$habit_statuses.habit_name"}, // 最新の名前
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	)
+
+	cursor, err := r.collection.Aggregate(timeoutCtx, pipeline)
+	if err != nil {
+		log.Printf("[ERROR] StatsRepository.AggregateCompletion() failed to collection.Aggregate (user_id: %s, group_by: %s): %v", userId, groupBy, err)
+		return nil, fmt.Errorf("failed to aggregate completion: %w", err)
+	}
+
+	var completionDBs []completionDB
+	if err = cursor.All(timeoutCtx, &completionDBs); err != nil {
+		log.Printf("[ERROR] StatsRepository.AggregateCompletion() failed to cursor.All : %v", err)
+		return nil, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	completions := make([]*stats.Completion, 0, len(completionDBs))
+	for _, completionDB := range completionDBs {
+		completions = append(completions, convertToCompletion(&completionDB, groupBy))
+	}
+
+	return completions, nil
+}
+
+// 集計単位ごとのグループ化のキー
+func completionGroupKey(groupBy stats.GroupBy) (interface{}, error) {
+	switch groupBy {
+	case stats.GroupByHabit:
+		return "$habit_statuses.habit_id", nil
+	case stats.GroupByDate:
+		return "$date", nil
+	case stats.GroupByMonth:
+		// YYYY-MM-DD の先頭7文字（YYYY-MM）
+		return bson.M{"$substrBytes": bson.A{"$date", 0, 7}}, nil
+	case stats.GroupByWeekday:
+		// 1(日)〜7(土)
+		return bson.M{"$dayOfWeek": bson.M{"$dateFromString": bson.M{"dateString": "$date", "format": "%Y-%m-%d"}}}, nil
+	default:
+		return nil, fmt.Errorf("unknown group by: %s", groupBy)
+	}
+}
+
+// DBモデルをドメインモデルに変換
+func convertToCompletion(completionDB *completionDB, groupBy stats.GroupBy) *stats.Completion {
+	completion := &stats.Completion{
+		Done:      completionDB.Done,
+		Scheduled: completionDB.Scheduled,
+	}
+
+	switch key := completionDB.ID.(type) {
+	case string:
+		completion.Key = key
+	case int32:
+		// $dayOfWeekは1(日)〜7(土)なので、time.Weekdayと同じ0(日)〜6(土)に揃える
+		completion.Key = strconv.Itoa(int(key) - 1)
+	}
+
+	if groupBy == stats.GroupByHabit {
+		completion.HabitName = completionDB.HabitName
+	}
+	completion.CalculateRate()
+
+	return completion
+}
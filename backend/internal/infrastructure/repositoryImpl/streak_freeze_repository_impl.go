@@ -0,0 +1,147 @@
+package repositoryImpl
+
+// RepositoryImpl規約
+//  取得したドキュメントをドメインモデルに変換して返却する
+//  エラーはDBからの元のエラーをfmt.Errorfでラップして返却する
+//  想定外のエラーの場合はlogでログ出力 -> [ERROR] HabitRepository.FugaMethod ~
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/streak"
+	"backend/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DBに保存するための内部モデル
+type streakFreezeDB struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserId    string             `bson:"user_id"`
+	HabitId   string             `bson:"habit_id"`
+	Date      string             `bson:"date"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+// StreakFreezeRepository はMongoDBのstreak_freezesコレクションにアクセスします
+type StreakFreezeRepository struct {
+	collection *mongo.Collection
+}
+
+// NewStreakFreezeRepository は新しいStreakFreezeRepositoryインスタンスを作成します
+func NewStreakFreezeRepository(collection *mongo.Collection) repository.StreakFreezeRepository {
+	return &StreakFreezeRepository{
+		collection: collection,
+	}
+}
+
+// ユーザーが使用したストリークフリーズを全件取得
+func (r *StreakFreezeRepository) FetchAll(ctx context.Context, userId string) ([]*streak.Freeze, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] StreakFreezeRepository.FetchAll() failed to collection.Find (user_id: %s): %v", userId, err)
+		return nil, fmt.Errorf("failed to fetch streak freezes: %w", err)
+	}
+
+	var freezeDBs []streakFreezeDB
+	if err = cursor.All(timeoutCtx, &freezeDBs); err != nil {
+		log.Printf("[ERROR] StreakFreezeRepository.FetchAll() failed to cursor.All : %v", err)
+		return nil, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	var freezes []*streak.Freeze
+	for _, freezeDB := range freezeDBs {
+		freezes = append(freezes, convertToStreakFreeze(&freezeDB))
+	}
+
+	return freezes, nil
+}
+
+// ストリークフリーズの使用を登録
+func (r *StreakFreezeRepository) Register(ctx context.Context, freeze *streak.Freeze) (*streak.Freeze, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// 同じ習慣・日付に使用済みかどうかのチェック
+	filter := bson.M{"user_id": freeze.UserId, "habit_id": freeze.HabitId, "date": freeze.Date}
+	var existingFreezeDB streakFreezeDB
+	err := r.collection.FindOne(timeoutCtx, filter).Decode(&existingFreezeDB)
+	if err == nil {
+		return nil, common.ErrAlreadyExists
+	}
+	if err != mongo.ErrNoDocuments {
+		log.Printf("[ERROR] StreakFreezeRepository.Register() failed to collection.FindOne (habit_id: %s, date: %s) : %v", freeze.HabitId, freeze.Date, err)
+		return nil, fmt.Errorf("failed to check for existing streak freeze: %w", err)
+	}
+
+	if freeze.CreatedAt.IsZero() {
+		freeze.CreatedAt = time.Now()
+	}
+
+	freezeDB := streakFreezeDB{
+		UserId:    freeze.UserId,
+		HabitId:   freeze.HabitId,
+		Date:      freeze.Date,
+		CreatedAt: freeze.CreatedAt,
+	}
+
+	result, err := r.collection.InsertOne(timeoutCtx, freezeDB)
+	if err != nil {
+		log.Printf("[ERROR] StreakFreezeRepository.Register() failed to collection.InsertOne (data: %+v) : %v", freezeDB, err)
+		return nil, fmt.Errorf("failed to register streak freeze: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		freeze.Id = oid.Hex()
+	}
+
+	return freeze, nil
+}
+
+// 習慣に使用したストリークフリーズを全て削除
+func (r *StreakFreezeRepository) DeleteByHabit(ctx context.Context, userId string, habitId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId, "habit_id": habitId})
+	if err != nil {
+		log.Printf("[ERROR] StreakFreezeRepository.DeleteByHabit() failed to collection.DeleteMany (user_id: %s, habit_id: %s) : %v", userId, habitId, err)
+		return fmt.Errorf("failed to delete streak freezes: %w", err)
+	}
+
+	return nil
+}
+
+// ユーザーのストリークフリーズを全て削除（アカウント削除時に使用）
+func (r *StreakFreezeRepository) DeleteAll(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(timeoutCtx, bson.M{"user_id": userId})
+	if err != nil {
+		log.Printf("[ERROR] StreakFreezeRepository.DeleteAll() failed to collection.DeleteMany (user_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete streak freezes: %w", err)
+	}
+
+	return nil
+}
+
+// DBモデルをドメインモデルに変換
+func convertToStreakFreeze(freezeDB *streakFreezeDB) *streak.Freeze {
+	return &streak.Freeze{
+		Id:        freezeDB.ID.Hex(),
+		UserId:    freezeDB.UserId,
+		HabitId:   freezeDB.HabitId,
+		Date:      freezeDB.Date,
+		CreatedAt: freezeDB.CreatedAt,
+	}
+}
@@ -17,12 +17,14 @@ import (
 	"golang.org/x/crypto/bcrypt"
 
 	"backend/internal/domain/common"
+	"backend/internal/domain/model/notification"
 	userModel "backend/internal/domain/model/user"
 	"backend/internal/domain/repository"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // DBに保存するための内部モデル
@@ -31,6 +33,20 @@ type userDB struct {
 	Username string             `bson:"username"`
 	Password string             `bson:"password"`
 	Points   int                `bson:"points"`
+
+	FreezeTokens int `bson:"freeze_tokens"`
+
+	Timezone     string `bson:"timezone,omitempty"`
+	DayStartHour int    `bson:"day_start_hour"`
+
+	HideFromLeaderboard bool `bson:"hide_from_leaderboard,omitempty"`
+
+	Notification *notificationSettingsDB `bson:"notification,omitempty"`
+}
+
+type notificationSettingsDB struct {
+	Channel string `bson:"channel"`
+	Address string `bson:"address"`
 }
 
 // UserRepository はMongoDBのusersコレクションにアクセスします
@@ -62,7 +78,7 @@ func (r *UserRepository) Find(ctx context.Context, id string) (*userModel.User,
 			return nil, common.ErrNotFound
 		}
 
-		log.Printf("[ERROR] UserRepository.Find() failed to collection.FindOne (user_id: %s): %w", id, err)
+		log.Printf("[ERROR] UserRepository.Find() failed to collection.FindOne (user_id: %s): %v", id, err)
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
 
@@ -81,7 +97,7 @@ func (r *UserRepository) FindByUserName(ctx context.Context, username string) (*
 		if err == mongo.ErrNoDocuments {
 			return nil, common.ErrNotFound
 		}
-		log.Printf("[ERROR] UserRepository.Find() failed to collection.FindOne (usernaem: %s): %w", username, err)
+		log.Printf("[ERROR] UserRepository.Find() failed to collection.FindOne (usernaem: %s): %v", username, err)
 		return nil, fmt.Errorf("failed to find user by username: %w", err)
 	}
 
@@ -105,11 +121,14 @@ func (r *UserRepository) Register(ctx context.Context, user *userModel.User) (*u
 		Username: user.Username,
 		Password: string(hashedPassword),
 		Points:   user.Points,
+
+		Timezone:     user.Timezone,
+		DayStartHour: user.DayStartHour,
 	}
 
 	result, err := r.collection.InsertOne(timeoutCtx, userDB)
 	if err != nil {
-		log.Printf("[ERROR] UserRepository.Register() failed to collection.InsertOne (data: %+v): %w", userDB, err)
+		log.Printf("[ERROR] UserRepository.Register() failed to collection.InsertOne (data: %+v): %v", userDB, err)
 		return nil, fmt.Errorf("failed to register user: %w", err)
 	}
 
@@ -127,7 +146,7 @@ func (r *UserRepository) UpdatePoints(ctx context.Context, userId string, points
 	// ID変換
 	objectID, err := primitive.ObjectIDFromHex(userId)
 	if err != nil {
-		log.Printf("[ERROR] UserRepository.UpdatePoints() failed to primitive.ObjectIDFromHex (id: %s) : %w", userId, err)
+		log.Printf("[ERROR] UserRepository.UpdatePoints() failed to primitive.ObjectIDFromHex (id: %s) : %v", userId, err)
 		return fmt.Errorf("invalid ID: %w", err)
 	}
 
@@ -146,7 +165,7 @@ func (r *UserRepository) UpdatePoints(ctx context.Context, userId string, points
 	result, err = r.collection.UpdateOne(timeoutCtx, filter, update)
 
 	if err != nil {
-		log.Printf("[ERROR] UserRepository.UpdatePoints() failed to collection.UpdateOne (_id: %s, points: %s) : %w", userId, points, err)
+		log.Printf("[ERROR] UserRepository.UpdatePoints() failed to collection.UpdateOne (_id: %s, points: %d) : %v", userId, points, err)
 		return fmt.Errorf("failed to update points: %w", err)
 	}
 
@@ -158,6 +177,391 @@ func (r *UserRepository) UpdatePoints(ctx context.Context, userId string, points
 	return nil
 }
 
+// ポイントをアトミックに加算（deltaが負の場合は減算）し、更新後のポイントを返す
+// NOTE: 読み込んだ値をもとに上書きするUpdatePointsと異なり、同時リクエストでも加算が失われない
+func (r *UserRepository) IncrementPoints(ctx context.Context, userId string, delta int) (int, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.IncrementPoints() failed to primitive.ObjectIDFromHex (id: %s) : %v", userId, err)
+		return 0, fmt.Errorf("invalid ID: %w", err)
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{"$inc": bson.M{"points": delta}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var userDB userDB
+	err = r.collection.FindOneAndUpdate(timeoutCtx, filter, update, opts).Decode(&userDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			log.Printf("[ERROR] UserRepository.IncrementPoints() failed to collection.FindOneAndUpdate target not found (_id: %s)", userId)
+			return 0, common.ErrNotFound
+		}
+		log.Printf("[ERROR] UserRepository.IncrementPoints() failed to collection.FindOneAndUpdate (_id: %s, delta: %d) : %v", userId, delta, err)
+		return 0, fmt.Errorf("failed to increment points: %w", err)
+	}
+
+	return userDB.Points, nil
+}
+
+// ポイントが足りる場合のみアトミックに減算し、更新後のポイントを返す
+// ポイントが足りない場合はErrInsufficientPointsを返す（残高がマイナスにならない）
+func (r *UserRepository) SpendPoints(ctx context.Context, userId string, cost int) (int, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.SpendPoints() failed to primitive.ObjectIDFromHex (id: %s) : %v", userId, err)
+		return 0, fmt.Errorf("invalid ID: %w", err)
+	}
+
+	// 残高が足りるユーザーのみ対象にする
+	filter := bson.M{"_id": objectID, "points": bson.M{"$gte": cost}}
+	update := bson.M{"$inc": bson.M{"points": -cost}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var userDB userDB
+	err = r.collection.FindOneAndUpdate(timeoutCtx, filter, update, opts).Decode(&userDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, common.ErrInsufficientPoints
+		}
+		log.Printf("[ERROR] UserRepository.SpendPoints() failed to collection.FindOneAndUpdate (_id: %s, cost: %d) : %v", userId, cost, err)
+		return 0, fmt.Errorf("failed to spend points: %w", err)
+	}
+
+	return userDB.Points, nil
+}
+
+// ストリークフリーズの所持数をアトミックに増減し、更新後の所持数を返す
+// 減算時に所持数が足りない場合はErrNoFreezeTokensを返す
+func (r *UserRepository) IncrementFreezeTokens(ctx context.Context, userId string, delta int) (int, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.IncrementFreezeTokens() failed to primitive.ObjectIDFromHex (id: %s) : %v", userId, err)
+		return 0, fmt.Errorf("invalid ID: %w", err)
+	}
+
+	filter := bson.M{"_id": objectID}
+	if delta < 0 {
+		filter["freeze_tokens"] = bson.M{"$gte": -delta}
+	}
+	update := bson.M{"$inc": bson.M{"freeze_tokens": delta}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var userDB userDB
+	err = r.collection.FindOneAndUpdate(timeoutCtx, filter, update, opts).Decode(&userDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments && delta < 0 {
+			return 0, common.ErrNoFreezeTokens
+		}
+		if err == mongo.ErrNoDocuments {
+			return 0, common.ErrNotFound
+		}
+		log.Printf("[ERROR] UserRepository.IncrementFreezeTokens() failed to collection.FindOneAndUpdate (_id: %s, delta: %d) : %v", userId, delta, err)
+		return 0, fmt.Errorf("failed to increment freeze tokens: %w", err)
+	}
+
+	return userDB.FreezeTokens, nil
+}
+
+// タイムゾーンと1日の始まりの時刻を更新
+func (r *UserRepository) UpdateDaySettings(ctx context.Context, userId string, timezone string, dayStartHour int) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.UpdateDaySettings() failed to primitive.ObjectIDFromHex (id: %s) : %v", userId, err)
+		return fmt.Errorf("invalid ID: %w", err)
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{
+		"$set": bson.M{
+			"timezone":       timezone,
+			"day_start_hour": dayStartHour,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.UpdateDaySettings() failed to collection.UpdateOne (_id: %s, timezone: %s, day_start_hour: %d) : %v", userId, timezone, dayStartHour, err)
+		return fmt.Errorf("failed to update day settings: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		log.Printf("[ERROR] UserRepository.UpdateDaySettings() failed to collection.UpdateOne target not found (_id: %s)", userId)
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// ランキングの公開設定を更新
+func (r *UserRepository) UpdateLeaderboardVisibility(ctx context.Context, userId string, hidden bool) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.UpdateLeaderboardVisibility() failed to primitive.ObjectIDFromHex (id: %s) : %v", userId, err)
+		return fmt.Errorf("invalid ID: %w", err)
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{"$set": bson.M{"hide_from_leaderboard": hidden}}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.UpdateLeaderboardVisibility() failed to collection.UpdateOne (_id: %s, hidden: %t) : %v", userId, hidden, err)
+		return fmt.Errorf("failed to update leaderboard visibility: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		log.Printf("[ERROR] UserRepository.UpdateLeaderboardVisibility() failed to collection.UpdateOne target not found (_id: %s)", userId)
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// 通知設定の更新（nilの場合は通知しない）
+func (r *UserRepository) UpdateNotificationSettings(ctx context.Context, userId string, settings *notification.Settings) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.UpdateNotificationSettings() failed to primitive.ObjectIDFromHex (id: %s) : %v", userId, err)
+		return fmt.Errorf("invalid ID: %w", err)
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{"$unset": bson.M{"notification": ""}}
+	if settings != nil {
+		update = bson.M{"$set": bson.M{"notification": notificationSettingsDB{Channel: string(settings.Channel), Address: settings.Address}}}
+	}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.UpdateNotificationSettings() failed to collection.UpdateOne (_id: %s, settings: %+v) : %v", userId, settings, err)
+		return fmt.Errorf("failed to update notification settings: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		log.Printf("[ERROR] UserRepository.UpdateNotificationSettings() failed to collection.UpdateOne target not found (_id: %s)", userId)
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// 指定したIDのユーザーを取得（存在しないIDは無視する）
+func (r *UserRepository) FetchByIds(ctx context.Context, ids []string) ([]*userModel.User, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(timeoutCtx, bson.M{"_id": bson.M{"$in": toObjectIDs(ids)}})
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.FetchByIds() failed to collection.Find (ids: %v): %v", ids, err)
+		return nil, fmt.Errorf("failed to fetch users: %w", err)
+	}
+
+	var userDBs []userDB
+	if err = cursor.All(timeoutCtx, &userDBs); err != nil {
+		log.Printf("[ERROR] UserRepository.FetchByIds() failed to cursor.All : %v", err)
+		return nil, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	users := make([]*userModel.User, 0, len(userDBs))
+	for _, userDB := range userDBs {
+		users = append(users, convertToUser(&userDB))
+	}
+
+	return users, nil
+}
+
+// ランキングを公開しているユーザーをポイントの高い順に取得
+// NOTE: userIdsを指定した場合は、そのユーザーのみ対象にする（nilの場合は全ユーザー）
+func (r *UserRepository) FetchTopPoints(ctx context.Context, userIds []string, offset int, limit int) ([]*userModel.User, int64, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := leaderboardFilter(userIds)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "points", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(timeoutCtx, filter, opts)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.FetchTopPoints() failed to collection.Find : %v", err)
+		return nil, 0, fmt.Errorf("failed to fetch users: %w", err)
+	}
+
+	var userDBs []userDB
+	if err = cursor.All(timeoutCtx, &userDBs); err != nil {
+		log.Printf("[ERROR] UserRepository.FetchTopPoints() failed to cursor.All : %v", err)
+		return nil, 0, fmt.Errorf("failed to decode documents from cursor: %w", err)
+	}
+
+	total, err := r.collection.CountDocuments(timeoutCtx, filter)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.FetchTopPoints() failed to collection.CountDocuments : %v", err)
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	users := make([]*userModel.User, 0, len(userDBs))
+	for _, userDB := range userDBs {
+		users = append(users, convertToUser(&userDB))
+	}
+
+	return users, total, nil
+}
+
+// 指定したポイントより多いランキング公開ユーザーの数（順位の算出に使用）
+func (r *UserRepository) CountHigherPoints(ctx context.Context, userIds []string, points int) (int64, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := leaderboardFilter(userIds)
+	filter["points"] = bson.M{"$gt": points}
+
+	count, err := r.collection.CountDocuments(timeoutCtx, filter)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.CountHigherPoints() failed to collection.CountDocuments (points: %d) : %v", points, err)
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	return count, nil
+}
+
+// パスワードを更新（ハッシュ化して保存）
+func (r *UserRepository) UpdatePassword(ctx context.Context, userId string, password string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.UpdatePassword() failed to primitive.ObjectIDFromHex (id: %s) : %v", userId, err)
+		return fmt.Errorf("invalid ID: %w", err)
+	}
+
+	// パスワードハッシュ化
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{"$set": bson.M{"password": string(hashedPassword)}}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.UpdatePassword() failed to collection.UpdateOne (_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		log.Printf("[ERROR] UserRepository.UpdatePassword() failed to collection.UpdateOne target not found (_id: %s)", userId)
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// ユーザーネームを更新
+// NOTE: 重複チェックはサービス側で行う
+func (r *UserRepository) UpdateUsername(ctx context.Context, userId string, username string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.UpdateUsername() failed to primitive.ObjectIDFromHex (id: %s) : %v", userId, err)
+		return fmt.Errorf("invalid ID: %w", err)
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{"$set": bson.M{"username": username}}
+
+	result, err := r.collection.UpdateOne(timeoutCtx, filter, update)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.UpdateUsername() failed to collection.UpdateOne (_id: %s, username: %s) : %v", userId, username, err)
+		return fmt.Errorf("failed to update username: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		log.Printf("[ERROR] UserRepository.UpdateUsername() failed to collection.UpdateOne target not found (_id: %s)", userId)
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// ユーザー削除
+func (r *UserRepository) Delete(ctx context.Context, userId string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ID変換
+	objectID, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.Delete() failed to primitive.ObjectIDFromHex (id: %s) : %v", userId, err)
+		return fmt.Errorf("invalid ID: %w", err)
+	}
+
+	result, err := r.collection.DeleteOne(timeoutCtx, bson.M{"_id": objectID})
+	if err != nil {
+		log.Printf("[ERROR] UserRepository.Delete() failed to collection.DeleteOne (_id: %s) : %v", userId, err)
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// ランキングの対象ユーザーの検索条件
+func leaderboardFilter(userIds []string) bson.M {
+	filter := bson.M{"hide_from_leaderboard": bson.M{"$ne": true}}
+	if userIds != nil {
+		filter["_id"] = bson.M{"$in": toObjectIDs(userIds)}
+	}
+	return filter
+}
+
+// 不正なIDは無視してObjectIDに変換
+func toObjectIDs(ids []string) []primitive.ObjectID {
+	objectIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			continue
+		}
+		objectIDs = append(objectIDs, objectID)
+	}
+	return objectIDs
+}
+
 // DBモデルをドメインモデルに変換
 func convertToUser(userDB *userDB) *userModel.User {
 	return &userModel.User{
@@ -165,5 +569,24 @@ func convertToUser(userDB *userDB) *userModel.User {
 		Username: userDB.Username,
 		Password: userDB.Password,
 		Points:   userDB.Points,
+
+		FreezeTokens: userDB.FreezeTokens,
+
+		Timezone:     userDB.Timezone,
+		DayStartHour: userDB.DayStartHour,
+
+		HideFromLeaderboard: userDB.HideFromLeaderboard,
+
+		Notification: convertToNotificationSettings(userDB.Notification),
+	}
+}
+
+func convertToNotificationSettings(settingsDB *notificationSettingsDB) *notification.Settings {
+	if settingsDB == nil {
+		return nil
+	}
+	return &notification.Settings{
+		Channel: notification.Channel(settingsDB.Channel),
+		Address: settingsDB.Address,
 	}
 }
@@ -0,0 +1,202 @@
+package serviceImpl
+
+// serviceImpl規約
+// ・エラーはhandlerに返すのみ。handler側でログ出力する。
+// ・複数のrepositoryメソッドもしくはデータを変更するrepositoryメソッドを使用する場合はトランザクションを実行する
+// ・repositoryのメソッドに渡すcontext.Contextについて
+//   -> トランザクションが不要な場合はhandlerから受け取ったctxをそのまま渡す
+//   -> トランザクションを実行する場合はmongo.SessionContextを渡す（mongo.SessionContextはcontext.Contextインターフェースを満たしている）
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend/internal/config"
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/achievement"
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/notification"
+	"backend/internal/domain/model/streak"
+	"backend/internal/domain/repository"
+	"backend/internal/domain/service"
+)
+
+type achievementService struct {
+	client          *mongo.Client
+	achievementRepo repository.AchievementRepository
+}
+
+func NewAchievementService(client *mongo.Client, achievementRepo repository.AchievementRepository) *achievementService {
+	return &achievementService{
+		client:          client,
+		achievementRepo: achievementRepo,
+	}
+}
+
+// 獲得したバッジ一覧を取得
+func (s *achievementService) GetAchievements(ctx context.Context, userId string) ([]*achievement.Badge, error) {
+	return s.achievementRepo.FetchAll(ctx, userId)
+}
+
+// 全ての実績のルールを判定し、新たに獲得したバッジを登録して返す
+// NOTE: 同時に獲得した場合など、登録済みのバッジは返さない
+func unlockAchievements(ctx context.Context, achievementRepo repository.AchievementRepository, userId string, facts achievement.Facts) ([]*achievement.Badge, error) {
+	badges, err := achievementRepo.FetchAll(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	unlocked := make(map[string]bool)
+	for _, badge := range badges {
+		unlocked[badge.Code] = true
+	}
+
+	rules, err := achievement.Evaluate(achievement.Rules, unlocked, facts)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	newBadges := make([]*achievement.Badge, 0, len(rules))
+	for _, rule := range rules {
+		badge, err := achievementRepo.Register(ctx, rule.NewBadge(userId, now))
+		if errors.Is(err, common.ErrAlreadyExists) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		newBadges = append(newBadges, badge)
+	}
+
+	return newBadges, nil
+}
+
+// 習慣を完了した時点のポイント・実績の判定に使う情報
+// NOTE: 習慣・連続達成数は必要になった時に一度だけ取得する（履歴はdaily_trackを全件取得せず、必要な期間のみ取得する）
+type completionFacts struct {
+	ctx              context.Context
+	habitRepo        repository.HabitRepository
+	dailyTrackRepo   repository.DailyTrackRepository
+	streakFreezeRepo repository.StreakFreezeRepository
+
+	userId  string
+	habitId string
+	date    time.Time // 完了した日
+	today   time.Time
+	points  int // 完了後のポイント（ポイントの付与後に設定する）
+
+	habit   *habit.Habit
+	habits  []*habit.Habit
+	streaks map[string]*streak.Streak // 習慣ID -> 連続達成数
+}
+
+func (f *completionFacts) targetHabit() (*habit.Habit, error) {
+	if f.habit != nil {
+		return f.habit, nil
+	}
+
+	targetHabit, err := f.habitRepo.Find(f.ctx, f.userId, f.habitId)
+	if err != nil {
+		return nil, err
+	}
+	f.habit = targetHabit
+
+	return f.habit, nil
+}
+
+// 全習慣の今日時点の連続達成数（連続達成数の画面と同じく、履歴は連続が途切れるまで遡って取得する）
+func (f *completionFacts) habitStreaks() ([]*habit.Habit, map[string]*streak.Streak, error) {
+	if f.streaks != nil {
+		return f.habits, f.streaks, nil
+	}
+
+	habits, err := f.habitRepo.FetchAll(f.ctx, f.userId)
+	if err != nil {
+		return nil, nil, err
+	}
+	streaks, err := calculateStreaksAsOf(f.ctx, f.habitRepo, f.dailyTrackRepo, f.streakFreezeRepo, f.userId, habits, f.today)
+	if err != nil {
+		return nil, nil, err
+	}
+	f.habits, f.streaks = habits, streaks
+
+	return f.habits, f.streaks, nil
+}
+
+func (f *completionFacts) TotalDone() (int, error) {
+	return f.dailyTrackRepo.CountDone(f.ctx, f.userId, common.FormatDate(f.today))
+}
+
+func (f *completionFacts) Points() (int, error) {
+	return f.points, nil
+}
+
+func (f *completionFacts) DailyStreak() (int, error) {
+	_, streaks, err := f.habitStreaks()
+	if err != nil {
+		return 0, err
+	}
+
+	habitStreak, ok := streaks[f.habitId]
+	if !ok || habitStreak.Unit != streak.UnitDay {
+		return 0, nil
+	}
+	return habitStreak.Current, nil
+}
+
+// 完了した日から遡って、全ての習慣を完了した日が続いた日数
+// NOTE: 履歴はStreakHistoryWindowDaysずつ遡って取得し、途切れた時点で取得をやめる
+func (f *completionFacts) PerfectDays() (int, error) {
+	days := 0
+	toDate := f.date
+	for {
+		fromDate := toDate.AddDate(0, 0, 1-config.StreakHistoryWindowDays)
+		dailyTracks, err := f.dailyTrackRepo.FindDailyTracks(f.ctx, f.userId, common.FormatDate(fromDate), common.FormatDate(toDate))
+		if err != nil {
+			return 0, err
+		}
+
+		tracksByDate := make(map[string]*daily_track.DailyTrack, len(dailyTracks))
+		for _, dailyTrack := range dailyTracks {
+			tracksByDate[dailyTrack.Date] = dailyTrack
+		}
+
+		for date := toDate; !date.Before(fromDate); date = date.AddDate(0, 0, -1) {
+			dailyTrack, ok := tracksByDate[common.FormatDate(date)]
+			if !ok || !dailyTrack.IsAllDone() {
+				return days, nil
+			}
+			days++
+		}
+		toDate = fromDate.AddDate(0, 0, -1)
+	}
+}
+
+// 新たに獲得したバッジをブラウザ通知で知らせる（notifierがnilの場合は何もしない）
+// NOTE: レスポンスを遅らせないようバックグラウンドで送信するため、エラーはここでログ出力する
+func notifyAchievements(notifier service.Notifier, userId string, badges []*achievement.Badge) {
+	if notifier == nil || len(badges) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		recipient := &notification.Recipient{
+			UserId:   userId,
+			Settings: notification.Settings{Channel: notification.ChannelWebPush},
+		}
+		for _, badge := range badges {
+			if err := notifier.Notify(ctx, recipient, notification.NewAchievementMessage(badge.Name, badge.Description)); err != nil {
+				log.Printf("[ERROR] %v", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,50 @@
+package serviceImpl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/daily_track"
+)
+
+// 取得する期間を区切っても、全ての習慣を完了した日が続いた日数を数える
+func TestCompletionFactsPerfectDays(t *testing.T) {
+	today := common.TruncateToDate(time.Now().UTC())
+
+	tests := []struct {
+		name        string
+		perfectDays int // 今日から遡って全ての習慣を完了した日数（その前日は未完了）
+		wantQueries int
+	}{
+		{"none", 0, 1},
+		{"within the first window", 10, 1},
+		{"across windows", config.StreakHistoryWindowDays + 10, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dailyTrackRepo := &fakeDailyTrackRepo{dailyTracks: map[string]*daily_track.DailyTrack{}}
+			for daysAgo := 0; daysAgo <= tt.perfectDays+30; daysAgo++ {
+				date := common.FormatDate(today.AddDate(0, 0, -daysAgo))
+				dailyTrackRepo.dailyTracks["user-1/"+date] = &daily_track.DailyTrack{Id: date, UserId: "user-1", Date: date, HabitStatuses: []*daily_track.HabitStatus{
+					{HabitId: "habit-1", HabitName: "読書", IsDone: daysAgo < tt.perfectDays},
+				}}
+			}
+
+			facts := &completionFacts{ctx: context.Background(), dailyTrackRepo: dailyTrackRepo, userId: "user-1", habitId: "habit-1", date: today, today: today}
+			got, err := facts.PerfectDays()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.perfectDays {
+				t.Errorf("perfect days = %d, want %d", got, tt.perfectDays)
+			}
+			if dailyTrackRepo.queries != tt.wantQueries {
+				t.Errorf("queried %d times, want %d", dailyTrackRepo.queries, tt.wantQueries)
+			}
+		})
+	}
+}
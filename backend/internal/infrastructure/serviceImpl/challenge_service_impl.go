@@ -0,0 +1,364 @@
+package serviceImpl
+
+// serviceImpl規約
+// ・エラーはhandlerに返すのみ。handler側でログ出力する。
+// ・複数のrepositoryメソッドもしくはデータを変更するrepositoryメソッドを使用する場合はトランザクションを実行する
+// ・repositoryのメソッドに渡すcontext.Contextについて
+//   -> トランザクションが不要な場合はhandlerから受け取ったctxをそのまま渡す
+//   -> トランザクションを実行する場合はmongo.SessionContextを渡す（mongo.SessionContextはcontext.Contextインターフェースを満たしている）
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/challenge"
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/point"
+	"backend/internal/domain/repository"
+)
+
+type challengeService struct {
+	client          *mongo.Client
+	userRepo        repository.UserRepository
+	habitRepo       repository.HabitRepository
+	dailyTrackRepo  repository.DailyTrackRepository
+	pointLedgerRepo repository.PointLedgerRepository
+	friendshipRepo  repository.FriendshipRepository
+	challengeRepo   repository.ChallengeRepository
+}
+
+func NewChallengeService(
+	client *mongo.Client,
+	userRepo repository.UserRepository,
+	habitRepo repository.HabitRepository,
+	dailyTrackRepo repository.DailyTrackRepository,
+	pointLedgerRepo repository.PointLedgerRepository,
+	friendshipRepo repository.FriendshipRepository,
+	challengeRepo repository.ChallengeRepository,
+) *challengeService {
+	return &challengeService{
+		client:          client,
+		userRepo:        userRepo,
+		habitRepo:       habitRepo,
+		dailyTrackRepo:  dailyTrackRepo,
+		pointLedgerRepo: pointLedgerRepo,
+		friendshipRepo:  friendshipRepo,
+		challengeRepo:   challengeRepo,
+	}
+}
+
+// 招待中・参加中のチャレンジ一覧
+func (s *challengeService) GetChallenges(ctx context.Context, userId string) ([]*challenge.Challenge, error) {
+	return s.challengeRepo.FetchByUser(ctx, userId)
+}
+
+// チャレンジの作成（作成したユーザーは指定した習慣で参加する）
+func (s *challengeService) CreateChallenge(ctx context.Context, userId string, name string, startDate string, endDate string, targetDays int, habitId string) (*challenge.Challenge, error) {
+	newChallenge, err := challenge.New(userId, name, startDate, endDate, targetDays, habitId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result *challenge.Challenge
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		// 終了済みの期間では作成できない
+		today, err := userToday(sessionContext, s.userRepo, userId)
+		if err != nil {
+			return nil, err
+		}
+		if newChallenge.IsEnded(today) {
+			return nil, common.ErrInvalidChallenge
+		}
+
+		// 自分の習慣のみ紐づけられる
+		if _, err = s.habitRepo.Find(sessionContext, userId, habitId); err != nil {
+			return nil, err
+		}
+
+		result, err = s.challengeRepo.Register(sessionContext, newChallenge)
+		return nil, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// フレンドをチャレンジに招待する（作成したユーザーのみ）
+func (s *challengeService) InviteParticipant(ctx context.Context, userId string, challengeId string, friendId string) (*challenge.Challenge, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result *challenge.Challenge
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		targetChallenge, err := s.challengeRepo.Find(sessionContext, userId, challengeId)
+		if err != nil {
+			return nil, err
+		}
+		if targetChallenge.OwnerId != userId {
+			return nil, common.ErrForbidden
+		}
+
+		today, err := userToday(sessionContext, s.userRepo, userId)
+		if err != nil {
+			return nil, err
+		}
+		if targetChallenge.IsEnded(today) {
+			return nil, common.ErrInvalidChallenge
+		}
+
+		// フレンドのみ招待できる
+		friendship, err := s.friendshipRepo.Find(sessionContext, userId, friendId)
+		if err != nil && !errors.Is(err, common.ErrNotFound) {
+			return nil, err
+		}
+		if friendship == nil || !friendship.IsAccepted() {
+			return nil, common.ErrNotFriends
+		}
+
+		participant := &challenge.Participant{UserId: friendId, Status: challenge.StatusInvited, InvitedBy: userId}
+		if err = s.challengeRepo.AddParticipant(sessionContext, challengeId, participant); err != nil {
+			return nil, err
+		}
+
+		result, err = s.challengeRepo.Find(sessionContext, userId, challengeId)
+		return nil, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// 招待されたチャレンジに自分の習慣で参加する（参加中の場合は習慣を変更する）
+// 参加時点で成功条件を満たしている場合はボーナスを付与する
+func (s *challengeService) JoinChallenge(ctx context.Context, userId string, challengeId string, habitId string) (*challenge.Challenge, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result *challenge.Challenge
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		targetChallenge, err := s.challengeRepo.Find(sessionContext, userId, challengeId)
+		if err != nil {
+			return nil, err
+		}
+
+		today, err := userToday(sessionContext, s.userRepo, userId)
+		if err != nil {
+			return nil, err
+		}
+		if targetChallenge.IsEnded(today) {
+			return nil, common.ErrInvalidChallenge
+		}
+
+		if _, err = s.habitRepo.Find(sessionContext, userId, habitId); err != nil {
+			return nil, err
+		}
+
+		participant := targetChallenge.FindParticipant(userId)
+		if participant.JoinedAt == nil {
+			now := time.Now()
+			participant.JoinedAt = &now
+		}
+		participant.HabitId, participant.Status = habitId, challenge.StatusJoined
+		if err = s.challengeRepo.UpdateParticipant(sessionContext, challengeId, participant); err != nil {
+			return nil, err
+		}
+
+		if _, err = settleChallenge(sessionContext, s.challengeRepo, s.dailyTrackRepo, s.userRepo, s.pointLedgerRepo, targetChallenge, participant); err != nil {
+			return nil, err
+		}
+
+		result, err = s.challengeRepo.Find(sessionContext, userId, challengeId)
+		return nil, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// チャレンジからの退出（招待の辞退を含む）
+// NOTE: 付与済みの成功ボーナスは取り消さない。作成したユーザーは退出できない（削除する）
+func (s *challengeService) LeaveChallenge(ctx context.Context, userId string, challengeId string) error {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		targetChallenge, err := s.challengeRepo.Find(sessionContext, userId, challengeId)
+		if err != nil {
+			return nil, err
+		}
+		if targetChallenge.OwnerId == userId {
+			return nil, common.ErrInvalidChallenge
+		}
+
+		return nil, s.challengeRepo.RemoveParticipant(sessionContext, challengeId, userId)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// チャレンジの削除（作成したユーザーのみ）
+// NOTE: 付与済みの成功ボーナスは取り消さない
+func (s *challengeService) DeleteChallenge(ctx context.Context, userId string, challengeId string) error {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		targetChallenge, err := s.challengeRepo.Find(sessionContext, userId, challengeId)
+		if err != nil {
+			return nil, err
+		}
+		if targetChallenge.OwnerId != userId {
+			return nil, common.ErrForbidden
+		}
+
+		return nil, s.challengeRepo.Delete(sessionContext, userId, challengeId)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// チャレンジの進捗ボード（参加中の参加者ごとに、紐づけた習慣の達成日数をdaily_trackから集計する）
+func (s *challengeService) GetChallengeBoard(ctx context.Context, userId string, challengeId string) (*challenge.Board, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var board *challenge.Board
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		targetChallenge, err := s.challengeRepo.Find(sessionContext, userId, challengeId)
+		if err != nil {
+			return err
+		}
+
+		userIds := make([]string, 0, len(targetChallenge.Participants))
+		for _, participant := range targetChallenge.Participants {
+			userIds = append(userIds, participant.UserId)
+		}
+		users, err := s.userRepo.FetchByIds(sessionContext, userIds)
+		if err != nil {
+			return err
+		}
+		usernames := make(map[string]string, len(users))
+		for _, user := range users {
+			usernames[user.Id] = user.Username
+		}
+
+		board = &challenge.Board{Challenge: targetChallenge, Progress: make([]*challenge.Progress, 0, len(targetChallenge.Participants))}
+		for _, participant := range targetChallenge.Participants {
+			if participant.Status != challenge.StatusJoined {
+				continue
+			}
+			doneDays, err := countChallengeDays(sessionContext, s.dailyTrackRepo, targetChallenge, participant)
+			if err != nil {
+				return err
+			}
+			progress := challenge.NewProgress(targetChallenge, participant, doneDays)
+			progress.Username = usernames[participant.UserId]
+			board.Progress = append(board.Progress, progress)
+		}
+		challenge.SortProgress(board.Progress)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return board, nil
+}
+
+// 参加者が期間中に紐づけた習慣を達成した日数
+func countChallengeDays(ctx context.Context, dailyTrackRepo repository.DailyTrackRepository, targetChallenge *challenge.Challenge, participant *challenge.Participant) (int, error) {
+	dailyTracks, err := dailyTrackRepo.FindDailyTracks(ctx, participant.UserId, targetChallenge.StartDate, targetChallenge.EndDate)
+	if err != nil {
+		return 0, err
+	}
+	return daily_track.CountDoneByHabit(dailyTracks)[participant.HabitId], nil
+}
+
+// 参加者の達成日数から成功を判定し、成功ボーナスを付与する（成功条件を満たさなくなった場合は取り消す）
+// ポイントの増減を返す（変化が無い場合は0）
+func settleChallenge(ctx context.Context, challengeRepo repository.ChallengeRepository, dailyTrackRepo repository.DailyTrackRepository, userRepo repository.UserRepository, pointLedgerRepo repository.PointLedgerRepository, targetChallenge *challenge.Challenge, participant *challenge.Participant) (int, error) {
+	doneDays, err := countChallengeDays(ctx, dailyTrackRepo, targetChallenge, participant)
+	if err != nil {
+		return 0, err
+	}
+	completed := doneDays >= targetChallenge.TargetDays
+
+	// 状態が変わった場合のみボーナスを増減する（同時に判定しても二重に付与しない）
+	changed, err := challengeRepo.MarkCompleted(ctx, targetChallenge.Id, participant.UserId, completed)
+	if err != nil || !changed {
+		return 0, err
+	}
+
+	amount := targetChallenge.BonusPoints
+	if !completed {
+		amount = -amount
+	}
+	entry := &point.LedgerEntry{
+		UserId:      participant.UserId,
+		Amount:      amount,
+		Reason:      point.ReasonChallenge,
+		HabitId:     participant.HabitId,
+		ChallengeId: targetChallenge.Id,
+	}
+	if _, err = recordPoints(ctx, userRepo, pointLedgerRepo, entry); err != nil {
+		return 0, err
+	}
+
+	return amount, nil
+}
@@ -10,22 +10,36 @@ package serviceImpl
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"backend/internal/config"
 	"backend/internal/domain/common"
+	"backend/internal/domain/model/achievement"
+	"backend/internal/domain/model/challenge"
 	"backend/internal/domain/model/daily_track"
 	"backend/internal/domain/model/habit"
-	userModel "backend/internal/domain/model/user"
+	"backend/internal/domain/model/level"
+	"backend/internal/domain/model/point"
 	"backend/internal/domain/repository"
+	"backend/internal/domain/service"
 )
 
 type dailyTrackService struct {
-	client         *mongo.Client
-	userRepo       repository.UserRepository
-	habitRepo      repository.HabitRepository
-	dailyTrackRepo repository.DailyTrackRepository
+	client           *mongo.Client
+	userRepo         repository.UserRepository
+	habitRepo        repository.HabitRepository
+	dailyTrackRepo   repository.DailyTrackRepository
+	pointLedgerRepo  repository.PointLedgerRepository
+	streakFreezeRepo repository.StreakFreezeRepository
+	achievementRepo  repository.AchievementRepository
+	rankingRepo      repository.RankingRepository
+	challengeRepo    repository.ChallengeRepository
+	feedItemRepo     repository.FeedItemRepository
+	cheerRepo        repository.CheerRepository
+	scoringPolicy    point.ScoringPolicy
+	pushNotifier     service.Notifier // 実績の獲得をブラウザ通知で知らせる（Web Pushを使用しない場合はnil）
 }
 
 func NewDailyTrackService(
@@ -33,16 +47,38 @@ func NewDailyTrackService(
 	userRepo repository.UserRepository,
 	habitRepo repository.HabitRepository,
 	dailyTrackRepo repository.DailyTrackRepository,
+	pointLedgerRepo repository.PointLedgerRepository,
+	streakFreezeRepo repository.StreakFreezeRepository,
+	achievementRepo repository.AchievementRepository,
+	rankingRepo repository.RankingRepository,
+	challengeRepo repository.ChallengeRepository,
+	feedItemRepo repository.FeedItemRepository,
+	cheerRepo repository.CheerRepository,
+	scoringPolicy point.ScoringPolicy,
+	pushNotifier service.Notifier,
 ) *dailyTrackService {
 	return &dailyTrackService{
-		client:         client,
-		userRepo:       userRepo,
-		habitRepo:      habitRepo,
-		dailyTrackRepo: dailyTrackRepo,
+		client:           client,
+		userRepo:         userRepo,
+		habitRepo:        habitRepo,
+		dailyTrackRepo:   dailyTrackRepo,
+		pointLedgerRepo:  pointLedgerRepo,
+		streakFreezeRepo: streakFreezeRepo,
+		achievementRepo:  achievementRepo,
+		rankingRepo:      rankingRepo,
+		challengeRepo:    challengeRepo,
+		feedItemRepo:     feedItemRepo,
+		cheerRepo:        cheerRepo,
+		scoringPolicy:    scoringPolicy,
+		pushNotifier:     pushNotifier,
 	}
 }
 
 func (s *dailyTrackService) GetDailyTrack(ctx context.Context, userId string, targetDate string) (*daily_track.DailyTrack, error) {
+	if _, err := common.ParseDate(targetDate); err != nil {
+		return nil, common.ErrInvalidDate
+	}
+
 	// セッションの開始
 	session, err := s.client.StartSession()
 	if err != nil {
@@ -53,6 +89,16 @@ func (s *dailyTrackService) GetDailyTrack(ctx context.Context, userId string, ta
 	// トランザクションの実行
 	var todaysTrack *daily_track.DailyTrack
 	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		// ユーザーにとっての今日を基準に参照できる日付か検証
+		today, err := userToday(sessionContext, s.userRepo, userId)
+		if err != nil {
+			return err
+		}
+		date, err := parseTrackDate(targetDate, today, false)
+		if err != nil {
+			return err
+		}
+
 		todaysTrack, err = s.dailyTrackRepo.FindDailyTrack(sessionContext, userId, targetDate)
 
 		// 想定外のエラーはエラーとして返す
@@ -60,8 +106,16 @@ func (s *dailyTrackService) GetDailyTrack(ctx context.Context, userId string, ta
 			return err
 		}
 
-		// 指定日のデータが存在していたら抜ける
+		// 今週の習慣ごとの達成回数（週あたりの回数目標の判定・進捗表示に使用）
+		var weeklyDone map[string]int
+		weeklyDone, err = countWeeklyDone(sessionContext, s.dailyTrackRepo, userId, date)
+		if err != nil {
+			return err
+		}
+
+		// 指定日のデータが存在していたら進捗を反映して抜ける
 		if todaysTrack != nil {
+			fillWeeklyProgress(todaysTrack, weeklyDone)
 			return nil
 		}
 
@@ -74,15 +128,12 @@ func (s *dailyTrackService) GetDailyTrack(ctx context.Context, userId string, ta
 			return err
 		}
 
-		// 習慣一覧を使ってステータスの配列を作成
+		// 指定日が実施予定日の習慣のみでステータスの配列を作成
 		var habitStatuses []*daily_track.HabitStatus
 		for _, habit := range habits {
-			habitStatus := &daily_track.HabitStatus{
-				HabitId:   habit.Id,
-				HabitName: habit.Name,
-				IsDone:    false,
+			if habitStatus := newHabitStatus(habit, date, weeklyDone); habitStatus != nil {
+				habitStatuses = append(habitStatuses, habitStatus)
 			}
-			habitStatuses = append(habitStatuses, habitStatus)
 		}
 
 		newDailyTrack := daily_track.DailyTrack{
@@ -95,6 +146,7 @@ func (s *dailyTrackService) GetDailyTrack(ctx context.Context, userId string, ta
 		if err != nil {
 			return err
 		}
+		fillWeeklyProgress(todaysTrack, weeklyDone)
 
 		return nil
 	})
@@ -107,55 +159,602 @@ func (s *dailyTrackService) GetDailyTrack(ctx context.Context, userId string, ta
 
 }
 
-func (s *dailyTrackService) UpdateDoneDailyTrack(ctx context.Context, userId string, targetDate string, targetHabitId string) error {
+// 期間内（fromDate〜toDate）に存在するdaily_trackを取得
+// NOTE: 存在しない日のdaily_trackは作成しない
+func (s *dailyTrackService) GetDailyTracks(ctx context.Context, userId string, fromDate string, toDate string) ([]*daily_track.DailyTrack, error) {
+	if _, _, err := parseDateRange(fromDate, toDate); err != nil {
+		return nil, err
+	}
+
+	dailyTracks, err := s.dailyTrackRepo.FindDailyTracks(ctx, userId, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if dailyTracks == nil {
+		dailyTracks = make([]*daily_track.DailyTrack, 0)
+	}
+
+	return dailyTracks, nil
+}
+
+// 期間内の習慣ごとのヒートマップを取得
+func (s *dailyTrackService) GetHeatmaps(ctx context.Context, userId string, fromDate string, toDate string) ([]*daily_track.HabitHeatmap, error) {
+	from, to, err := parseDateRange(fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
 	// セッションの開始
 	session, err := s.client.StartSession()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer session.EndSession(ctx)
 
 	// トランザクションの実行
+	var heatmaps []*daily_track.HabitHeatmap
 	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		habits, err := s.habitRepo.FetchAll(sessionContext, userId)
+		if err != nil {
+			return err
+		}
+
+		dailyTracks, err := s.dailyTrackRepo.FindDailyTracks(sessionContext, userId, fromDate, toDate)
+		if err != nil {
+			return err
+		}
+
+		today, err := userToday(sessionContext, s.userRepo, userId)
+		if err != nil {
+			return err
+		}
+		heatmaps = daily_track.BuildHeatmaps(habits, dailyTracks, from, to, today)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if heatmaps == nil {
+		heatmaps = make([]*daily_track.HabitHeatmap, 0)
+	}
+
+	return heatmaps, nil
+}
+
+func (s *dailyTrackService) UpdateDoneDailyTrack(ctx context.Context, userId string, targetDate string, targetHabitId string) (*daily_track.CompletionResult, error) {
+	return s.updateHabitDone(ctx, userId, targetDate, targetHabitId, true)
+}
+
+func (s *dailyTrackService) UndoDoneDailyTrack(ctx context.Context, userId string, targetDate string, targetHabitId string) (*daily_track.CompletionResult, error) {
+	return s.updateHabitDone(ctx, userId, targetDate, targetHabitId, false)
+}
+
+// 習慣の完了状態を切り替え、状態が変化した場合のみポイントを付与・取り消す
+// 完了にした場合は実績を判定する
+// 更新後のdaily_track・ユーザーのポイント・獲得したバッジを返す
+func (s *dailyTrackService) updateHabitDone(ctx context.Context, userId string, targetDate string, targetHabitId string, isDone bool) (*daily_track.CompletionResult, error) {
+	if _, err := common.ParseDate(targetDate); err != nil {
+		return nil, common.ErrInvalidDate
+	}
+
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result *daily_track.CompletionResult
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		// NOTE: 一時的なエラーで再実行される場合があるため、結果はここで初期化する
+		result = &daily_track.CompletionResult{Achievements: make([]*achievement.Badge, 0), Challenges: make([]*challenge.Challenge, 0)}
+
+		// 完了を記録できるのは今日まで
+		today, err := userToday(sessionContext, s.userRepo, userId)
+		if err != nil {
+			return nil, err
+		}
+		date, err := parseTrackDate(targetDate, today, true)
+		if err != nil {
+			return nil, err
+		}
+
 		// todaysTrack 取得
 		todaysTrack, err := s.dailyTrackRepo.FindDailyTrack(sessionContext, userId, targetDate)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		result.DailyTrack = todaysTrack
 
-		// todaysTrack.IsDone 更新
-		for _, habitStatus := range todaysTrack.HabitStatuses {
-			if habitStatus.HabitId == targetHabitId {
-				habitStatus.IsDone = true
+		targetStatus := findHabitStatus(todaysTrack, targetHabitId)
+		if targetStatus == nil {
+			// 他のユーザーの習慣もdaily_trackに含まれないためErrNotFound
+			return nil, common.ErrNotFound
+		}
+		// 量を記録する習慣は、記録量が目標に達した時のみ完了にする（取り消しは可能）
+		if isDone && targetStatus.IsMeasurable() {
+			return nil, common.ErrAmountRequired
+		}
+
+		// 完了状態を更新（すでに同じ状態の場合は変化なし）
+		var changed bool
+		changed, err = s.dailyTrackRepo.UpdateHabitDone(sessionContext, userId, todaysTrack.Id, targetHabitId, isDone)
+		if err != nil {
+			return nil, err
+		}
+
+		if changed {
+			targetStatus.IsDone = isDone
+			if !isDone {
+				targetStatus.Value = 0
 			}
 		}
 
-		// dailyTrack更新して保存
-		err = s.dailyTrackRepo.UpdateHabitStatuses(sessionContext, todaysTrack)
+		// 今週の達成回数を反映
+		var weeklyDone map[string]int
+		weeklyDone, err = countWeeklyDone(sessionContext, s.dailyTrackRepo, userId, date)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		fillWeeklyProgress(todaysTrack, weeklyDone)
 
-		// ユーザー情報取得
-		var user *userModel.User
-		user, err = s.userRepo.Find(sessionContext, userId)
+		// レベルアップの判定用に更新前のレベルを取得
+		beforeLevel, err := userLevel(sessionContext, s.pointLedgerRepo, userId)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		// point 加算
-		points := user.Points + config.PointsForHabitDone
-		err = s.userRepo.UpdatePoints(sessionContext, userId, points)
+		// point 付与・取り消し（状態が変化した場合のみ）
+		facts := s.newCompletionFacts(sessionContext, userId, targetHabitId, date, today)
+		switch {
+		case changed && isDone:
+			result.Points, err = s.scoreCompletion(sessionContext, userId, todaysTrack, facts)
+		case changed && !isDone:
+			result.Points, err = s.revokeCompletion(sessionContext, userId, todaysTrack, targetHabitId)
+		default:
+			result.Points, err = s.recordEntries(sessionContext, userId, nil)
+		}
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		return nil
+		// グループチャレンジの成功ボーナス（状態が変化した場合のみ）
+		if changed {
+			result.Challenges, result.Points, err = s.settleChallenges(sessionContext, userId, targetHabitId, targetDate, result.Points)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// 更新後のレベル
+		result.Level, err = userLevel(sessionContext, s.pointLedgerRepo, userId)
+		if err != nil {
+			return nil, err
+		}
+		result.LevelUp = level.NewLevelUp(beforeLevel, result.Level)
+
+		// ランキングの更新（状態が変化した場合のみ）
+		if changed {
+			earned := result.Level.Experience - beforeLevel.Experience
+			if err = updateRankings(sessionContext, s.userRepo, s.rankingRepo, facts, earned); err != nil {
+				return nil, err
+			}
+		}
+
+		// 実績の判定（完了にした場合のみ）
+		if changed && isDone {
+			facts.points = result.Points
+			result.Achievements, err = unlockAchievements(sessionContext, s.achievementRepo, userId, facts)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// フィードへの登録・削除（状態が変化した場合のみ）
+		switch {
+		case changed && isDone:
+			err = postFeedItem(sessionContext, s.feedItemRepo, userId, targetStatus, targetDate)
+		case changed && !isDone:
+			err = deleteFeedItem(sessionContext, s.feedItemRepo, s.cheerRepo, userId, targetHabitId, targetDate)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, nil
 	})
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	notifyAchievements(s.pushNotifier, userId, result.Achievements)
+
+	return result, nil
+}
+
+func (s *dailyTrackService) LogHabitAmount(ctx context.Context, userId string, targetDate string, targetHabitId string, amount float64) (*daily_track.CompletionResult, error) {
+	if amount <= 0 {
+		return nil, common.ErrInvalidAmount
+	}
+	if _, err := common.ParseDate(targetDate); err != nil {
+		return nil, common.ErrInvalidDate
 	}
 
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result *daily_track.CompletionResult
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		// NOTE: 一時的なエラーで再実行される場合があるため、結果はここで初期化する
+		result = &daily_track.CompletionResult{Achievements: make([]*achievement.Badge, 0), Challenges: make([]*challenge.Challenge, 0)}
+
+		// 記録できるのは今日まで
+		today, err := userToday(sessionContext, s.userRepo, userId)
+		if err != nil {
+			return nil, err
+		}
+		date, err := parseTrackDate(targetDate, today, true)
+		if err != nil {
+			return nil, err
+		}
+
+		// todaysTrack 取得
+		todaysTrack, err := s.dailyTrackRepo.FindDailyTrack(sessionContext, userId, targetDate)
+		if err != nil {
+			return nil, err
+		}
+		result.DailyTrack = todaysTrack
+
+		// 対象の習慣のステータスを取得
+		targetStatus := findHabitStatus(todaysTrack, targetHabitId)
+		if targetStatus == nil {
+			// 他のユーザーの習慣もdaily_trackに含まれないためErrNotFound
+			return nil, common.ErrNotFound
+		}
+		if !targetStatus.IsMeasurable() {
+			return nil, common.ErrNotMeasurable
+		}
+
+		// 記録量を集計し、目標達成で完了にする
+		// NOTE: 未完了から完了に変わった場合のみポイントを付与する（同時リクエストでも一度だけ）
+		updatedTrack, reached, err := s.dailyTrackRepo.LogHabitAmount(sessionContext, userId, todaysTrack.Id, targetHabitId, amount, targetStatus.Measure)
+		if err != nil {
+			return nil, err
+		}
+		todaysTrack, result.DailyTrack = updatedTrack, updatedTrack
+		targetStatus = findHabitStatus(todaysTrack, targetHabitId)
+
+		// レベルアップの判定用に更新前のレベルを取得
+		beforeLevel, err := userLevel(sessionContext, s.pointLedgerRepo, userId)
+		if err != nil {
+			return nil, err
+		}
+
+		// ポイントは目標に達した記録の時だけ付与する
+		facts := s.newCompletionFacts(sessionContext, userId, targetHabitId, date, today)
+		if reached {
+			result.Points, err = s.scoreCompletion(sessionContext, userId, todaysTrack, facts)
+		} else {
+			result.Points, err = s.recordEntries(sessionContext, userId, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// グループチャレンジの成功ボーナス（目標に達した場合のみ）
+		if reached {
+			result.Challenges, result.Points, err = s.settleChallenges(sessionContext, userId, targetHabitId, targetDate, result.Points)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// 更新後のレベル
+		result.Level, err = userLevel(sessionContext, s.pointLedgerRepo, userId)
+		if err != nil {
+			return nil, err
+		}
+		result.LevelUp = level.NewLevelUp(beforeLevel, result.Level)
+
+		// ランキングの更新（目標に達した場合のみ）
+		if reached {
+			earned := result.Level.Experience - beforeLevel.Experience
+			if err = updateRankings(sessionContext, s.userRepo, s.rankingRepo, facts, earned); err != nil {
+				return nil, err
+			}
+		}
+
+		// 実績の判定（目標に達した場合のみ）
+		if reached {
+			facts.points = result.Points
+			result.Achievements, err = unlockAchievements(sessionContext, s.achievementRepo, userId, facts)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// フィードへの登録（目標に達した場合のみ）
+		if reached {
+			if err = postFeedItem(sessionContext, s.feedItemRepo, userId, targetStatus, targetDate); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	notifyAchievements(s.pushNotifier, userId, result.Achievements)
+
+	return result, nil
+}
+
+// 習慣の完了に対してScoringPolicyのルールごとにポイントを付与し、更新後のポイントを返す
+// その日の全ての習慣を完了した場合はボーナスも付与する（同じ日に付与済みの場合を除く）
+func (s *dailyTrackService) scoreCompletion(ctx context.Context, userId string, dailyTrack *daily_track.DailyTrack, facts *completionFacts) (int, error) {
+	targetHabit, err := facts.targetHabit()
+	if err != nil {
+		return 0, err
+	}
+	streakDays, err := facts.DailyStreak()
+	if err != nil {
+		return 0, err
+	}
+
+	var entries []*point.LedgerEntry
+	for _, award := range s.scoringPolicy.ScoreCompletion(targetHabit, streakDays) {
+		entries = append(entries, &point.LedgerEntry{
+			UserId:  userId,
+			Amount:  award.Amount,
+			Reason:  point.ReasonHabitDone,
+			HabitId: targetHabit.Id,
+			Date:    dailyTrack.Date,
+			Rule:    award.Rule,
+		})
+	}
+
+	if dailyTrack.IsAllDone() {
+		bonus, err := s.pointLedgerRepo.SumAmountByDate(ctx, userId, dailyTrack.Date, "")
+		if err != nil {
+			return 0, err
+		}
+		if bonus == 0 {
+			for _, award := range s.scoringPolicy.ScorePerfectDay() {
+				entries = append(entries, &point.LedgerEntry{
+					UserId: userId,
+					Amount: award.Amount,
+					Reason: point.ReasonBonus,
+					Date:   dailyTrack.Date,
+					Rule:   award.Rule,
+				})
+			}
+		}
+	}
+
+	return s.recordEntries(ctx, userId, entries)
+}
+
+// 習慣の完了の取り消しに伴い、その完了で付与したポイントを差し引き、更新後のポイントを返す
+// 全ての習慣の完了ではなくなった場合はボーナスも差し引く
+// NOTE: 付与時とルールや連続日数が変わっていても正しく戻せるよう、台帳に記録した付与済みの合計を差し引く
+func (s *dailyTrackService) revokeCompletion(ctx context.Context, userId string, dailyTrack *daily_track.DailyTrack, habitId string) (int, error) {
+	var entries []*point.LedgerEntry
+
+	awarded, err := s.pointLedgerRepo.SumAmountByDate(ctx, userId, dailyTrack.Date, habitId)
+	if err != nil {
+		return 0, err
+	}
+	if awarded != 0 {
+		entries = append(entries, &point.LedgerEntry{
+			UserId:  userId,
+			Amount:  -awarded,
+			Reason:  point.ReasonHabitUndone,
+			HabitId: habitId,
+			Date:    dailyTrack.Date,
+		})
+	}
+
+	if !dailyTrack.IsAllDone() {
+		bonus, err := s.pointLedgerRepo.SumAmountByDate(ctx, userId, dailyTrack.Date, "")
+		if err != nil {
+			return 0, err
+		}
+		if bonus != 0 {
+			entries = append(entries, &point.LedgerEntry{
+				UserId: userId,
+				Amount: -bonus,
+				Reason: point.ReasonBonus,
+				Date:   dailyTrack.Date,
+				Rule:   point.RulePerfectDay,
+			})
+		}
+	}
+
+	return s.recordEntries(ctx, userId, entries)
+}
+
+// ポイントの増減を台帳に記録し、更新後のポイントを返す
+// NOTE: エントリが無い場合は台帳に記録せず、現在のポイントを返すのみ
+func (s *dailyTrackService) recordEntries(ctx context.Context, userId string, entries []*point.LedgerEntry) (int, error) {
+	if len(entries) == 0 {
+		// ユーザー情報取得
+		user, err := s.userRepo.Find(ctx, userId)
+		if err != nil {
+			return 0, err
+		}
+		return user.Points, nil
+	}
+
+	var points int
+	for _, entry := range entries {
+		var err error
+		points, err = recordPoints(ctx, s.userRepo, s.pointLedgerRepo, entry)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return points, nil
+}
+
+// 完了・取り消しした習慣で参加中のグループチャレンジの成功ボーナスを付与・取り消しする
+// 今回成功したチャレンジと、更新後のポイントを返す
+func (s *dailyTrackService) settleChallenges(ctx context.Context, userId string, habitId string, date string, points int) ([]*challenge.Challenge, int, error) {
+	challenges, err := s.challengeRepo.FetchJoinedByHabit(ctx, userId, habitId, date)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	completed := make([]*challenge.Challenge, 0)
+	changed := false
+	for _, targetChallenge := range challenges {
+		amount, err := settleChallenge(ctx, s.challengeRepo, s.dailyTrackRepo, s.userRepo, s.pointLedgerRepo, targetChallenge, targetChallenge.FindParticipant(userId))
+		if err != nil {
+			return nil, 0, err
+		}
+		if amount > 0 {
+			completed = append(completed, targetChallenge)
+		}
+		changed = changed || amount != 0
+	}
+
+	// ボーナスを増減した場合は更新後のポイントを取得し直す
+	if changed {
+		points, err = s.recordEntries(ctx, userId, nil)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return completed, points, nil
+}
+
+// 習慣の完了時のポイント・実績の判定に使う情報を作成する
+func (s *dailyTrackService) newCompletionFacts(ctx context.Context, userId string, habitId string, date time.Time, today time.Time) *completionFacts {
+	return &completionFacts{
+		ctx:              ctx,
+		habitRepo:        s.habitRepo,
+		dailyTrackRepo:   s.dailyTrackRepo,
+		streakFreezeRepo: s.streakFreezeRepo,
+		userId:           userId,
+		habitId:          habitId,
+		date:             date,
+		today:            today,
+	}
+}
+
+// daily_trackから指定した習慣のステータスを取得（存在しない場合はnil）
+func findHabitStatus(dailyTrack *daily_track.DailyTrack, habitId string) *daily_track.HabitStatus {
+	for _, habitStatus := range dailyTrack.HabitStatuses {
+		if habitStatus.HabitId == habitId {
+			return habitStatus
+		}
+	}
 	return nil
 }
+
+// 期間指定（YYYY-MM-DD）を検証して変換する
+func parseDateRange(fromDate string, toDate string) (time.Time, time.Time, error) {
+	from, err := common.ParseDate(fromDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, common.ErrInvalidDate
+	}
+	to, err := common.ParseDate(toDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, common.ErrInvalidDate
+	}
+
+	if to.Before(from) || to.Sub(from) >= config.MaxDailyTrackRangeDays*24*time.Hour {
+		return time.Time{}, time.Time{}, common.ErrInvalidDateRange
+	}
+
+	return from, to, nil
+}
+
+// ユーザーのタイムゾーン・1日の始まりの時刻をもとに「今日」を取得
+func userToday(ctx context.Context, userRepo repository.UserRepository, userId string) (time.Time, error) {
+	user, err := userRepo.Find(ctx, userId)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return user.Today(time.Now()), nil
+}
+
+// daily_trackの日付指定（YYYY-MM-DD）を検証して変換する
+// 参照できるのは今日を基準に過去DailyTrackPastDays日〜未来DailyTrackFutureDays日まで
+// editableがtrueの場合（完了の記録など）は今日までに制限する
+func parseTrackDate(targetDate string, today time.Time, editable bool) (time.Time, error) {
+	date, err := common.ParseDate(targetDate)
+	if err != nil {
+		return time.Time{}, common.ErrInvalidDate
+	}
+
+	latest := today.AddDate(0, 0, config.DailyTrackFutureDays)
+	if editable {
+		latest = today
+	}
+	if date.Before(today.AddDate(0, 0, -config.DailyTrackPastDays)) || date.After(latest) {
+		return time.Time{}, common.ErrDateOutOfRange
+	}
+
+	return date, nil
+}
+
+// 指定日が属する週の、週初めから指定日までの習慣ごとの達成回数を取得
+func countWeeklyDone(ctx context.Context, dailyTrackRepo repository.DailyTrackRepository, userId string, date time.Time) (map[string]int, error) {
+	fromDate := common.FormatDate(habit.WeekStart(date))
+	toDate := common.FormatDate(date)
+
+	weekTracks, err := dailyTrackRepo.FindDailyTracks(ctx, userId, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return daily_track.CountDoneByHabit(weekTracks), nil
+}
+
+// 指定日のdaily_trackに含める習慣のステータスを作成する
+// 実施予定日でない場合、または週あたりの回数目標を既に達成している場合はnilを返す
+func newHabitStatus(targetHabit *habit.Habit, date time.Time, weeklyDone map[string]int) *daily_track.HabitStatus {
+	if !targetHabit.IsScheduledOn(date) {
+		return nil
+	}
+
+	habitStatus := &daily_track.HabitStatus{
+		HabitId:   targetHabit.Id,
+		HabitName: targetHabit.Name,
+		IsDone:    false,
+		Measure:   targetHabit.Measure,
+	}
+
+	if targetHabit.Schedule.IsWeeklyQuota() {
+		if weeklyDone[targetHabit.Id] >= targetHabit.Schedule.TimesPerWeek {
+			return nil
+		}
+		habitStatus.WeeklyTarget = targetHabit.Schedule.TimesPerWeek
+	}
+
+	return habitStatus
+}
+
+// 週あたりの回数目標を持つ習慣に今週の達成回数を反映する
+func fillWeeklyProgress(dailyTrack *daily_track.DailyTrack, weeklyDone map[string]int) {
+	for _, habitStatus := range dailyTrack.HabitStatuses {
+		if habitStatus.WeeklyTarget > 0 {
+			habitStatus.WeeklyDone = weeklyDone[habitStatus.HabitId]
+		}
+	}
+}
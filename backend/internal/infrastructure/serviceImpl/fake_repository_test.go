@@ -0,0 +1,235 @@
+package serviceImpl
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/notification"
+	"backend/internal/domain/model/reminder"
+	"backend/internal/domain/model/streak"
+	userModel "backend/internal/domain/model/user"
+	"backend/internal/domain/repository"
+)
+
+// テスト用のクライアント（接続は遅延されるため、DBにアクセスしない範囲でセッションを使用できる）
+func newTestClient(t *testing.T) *mongo.Client {
+	t.Helper()
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:1"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { client.Disconnect(context.Background()) })
+	return client
+}
+
+// NOTE: 以下のfakeはインターフェースを埋め込み、テストで使用するメソッドのみ実装する（それ以外はpanicになる）
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	users map[string]*userModel.User
+}
+
+func (r *fakeUserRepo) Find(ctx context.Context, id string) (*userModel.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, common.ErrNotFound
+	}
+	return user, nil
+}
+
+// 所持数が足りない場合はErrNoFreezeTokens（UserRepositoryのフィルターと同じ）
+func (r *fakeUserRepo) IncrementFreezeTokens(ctx context.Context, userId string, delta int) (int, error) {
+	user, ok := r.users[userId]
+	if !ok {
+		return 0, common.ErrNotFound
+	}
+	if user.FreezeTokens+delta < 0 {
+		return 0, common.ErrNoFreezeTokens
+	}
+	user.FreezeTokens += delta
+	return user.FreezeTokens, nil
+}
+
+// 習慣IDごとに保存し、所有者が一致しない場合はErrNotFoundを返す（HabitRepositoryのフィルターと同じ）
+type fakeHabitRepo struct {
+	repository.HabitRepository
+	habits  map[string]*habit.Habit
+	updated []string
+	deleted []string
+}
+
+func (r *fakeHabitRepo) Find(ctx context.Context, userId string, id string) (*habit.Habit, error) {
+	targetHabit, ok := r.habits[id]
+	if !ok || targetHabit.UserId != userId {
+		return nil, common.ErrNotFound
+	}
+	copied := *targetHabit
+	return &copied, nil
+}
+
+func (r *fakeHabitRepo) Update(ctx context.Context, targetHabit *habit.Habit) error {
+	r.updated = append(r.updated, targetHabit.Id)
+	return nil
+}
+
+func (r *fakeHabitRepo) UpdateLongestStreak(ctx context.Context, userId string, id string, longest int) error {
+	targetHabit, ok := r.habits[id]
+	if !ok || targetHabit.UserId != userId {
+		return common.ErrNotFound
+	}
+	if targetHabit.LongestStreak == nil || *targetHabit.LongestStreak < longest {
+		targetHabit.LongestStreak = &longest
+	}
+	return nil
+}
+
+func (r *fakeHabitRepo) Delete(ctx context.Context, userId string, id string) error {
+	if _, err := r.Find(ctx, userId, id); err != nil {
+		return err
+	}
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+// ユーザーID・日付ごとに保存する（queriesは期間指定で取得した回数）
+type fakeDailyTrackRepo struct {
+	repository.DailyTrackRepository
+	dailyTracks map[string]*daily_track.DailyTrack
+	updated     []string
+	queries     int
+}
+
+// 日付の昇順で返す
+func (r *fakeDailyTrackRepo) FindDailyTracks(ctx context.Context, userId string, fromDate string, toDate string) ([]*daily_track.DailyTrack, error) {
+	r.queries++
+	dailyTracks := make([]*daily_track.DailyTrack, 0)
+	for _, dailyTrack := range r.dailyTracks {
+		if dailyTrack.UserId == userId && dailyTrack.Date >= fromDate && dailyTrack.Date <= toDate {
+			dailyTracks = append(dailyTracks, dailyTrack)
+		}
+	}
+	sort.Slice(dailyTracks, func(i, j int) bool { return dailyTracks[i].Date < dailyTracks[j].Date })
+	return dailyTracks, nil
+}
+
+func (r *fakeDailyTrackRepo) ExistsHabitStatusBefore(ctx context.Context, userId string, habitIds []string, beforeDate string) (bool, error) {
+	for _, dailyTrack := range r.dailyTracks {
+		if dailyTrack.UserId != userId || dailyTrack.Date >= beforeDate {
+			continue
+		}
+		for _, habitStatus := range dailyTrack.HabitStatuses {
+			if slices.Contains(habitIds, habitStatus.HabitId) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeDailyTrackRepo) FindDailyTrack(ctx context.Context, userId string, targetDate string) (*daily_track.DailyTrack, error) {
+	dailyTrack, ok := r.dailyTracks[userId+"/"+targetDate]
+	if !ok {
+		return nil, common.ErrNotFound
+	}
+	return dailyTrack, nil
+}
+
+func (r *fakeDailyTrackRepo) UpdateHabitDone(ctx context.Context, userId string, dailyTrackId string, habitId string, isDone bool) (bool, error) {
+	r.updated = append(r.updated, habitId)
+	return true, nil
+}
+
+func (r *fakeDailyTrackRepo) UpdateHabitStatuses(ctx context.Context, dailyTrack *daily_track.DailyTrack) error {
+	r.updated = append(r.updated, dailyTrack.Id)
+	return nil
+}
+
+type fakeStreakFreezeRepo struct {
+	repository.StreakFreezeRepository
+	freezes []*streak.Freeze
+}
+
+func (r *fakeStreakFreezeRepo) FetchAll(ctx context.Context, userId string) ([]*streak.Freeze, error) {
+	return r.freezes, nil
+}
+
+// 同じ習慣・日付に使用済みの場合はErrAlreadyExists
+func (r *fakeStreakFreezeRepo) Register(ctx context.Context, freeze *streak.Freeze) (*streak.Freeze, error) {
+	for _, registered := range r.freezes {
+		if registered.UserId == freeze.UserId && registered.HabitId == freeze.HabitId && registered.Date == freeze.Date {
+			return nil, common.ErrAlreadyExists
+		}
+	}
+	r.freezes = append(r.freezes, freeze)
+	return freeze, nil
+}
+
+// 送信日時と送信済みの記録をメモリに保存する（Advanceは送信日時が一致する場合のみ進める）
+type fakeReminderRepo struct {
+	repository.ReminderRepository
+	reminders []*reminder.Reminder
+}
+
+func (r *fakeReminderRepo) FetchDue(ctx context.Context, now time.Time, limit int) ([]*reminder.Reminder, error) {
+	due := make([]*reminder.Reminder, 0)
+	for _, target := range r.reminders {
+		if !target.NextAt.After(now) {
+			copied := *target
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeReminderRepo) Advance(ctx context.Context, id string, from time.Time, to time.Time) (bool, error) {
+	for _, target := range r.reminders {
+		if target.Id == id && target.NextAt.Equal(from) {
+			target.NextAt = to
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeReminderRepo) UpdateNextAt(ctx context.Context, userId string, id string, nextAt time.Time) error {
+	for _, target := range r.reminders {
+		if target.Id == id && target.UserId == userId {
+			target.NextAt = nextAt
+		}
+	}
+	return nil
+}
+
+// reminder_deliveriesのユニークインデックス（reminder_id, date）と同じく、重複はErrAlreadyExists
+type fakeReminderDeliveryRepo struct {
+	repository.ReminderDeliveryRepository
+	deliveries map[string]*reminder.Delivery
+}
+
+func (r *fakeReminderDeliveryRepo) Register(ctx context.Context, delivery *reminder.Delivery) error {
+	key := delivery.ReminderId + "/" + delivery.Date
+	if _, ok := r.deliveries[key]; ok {
+		return common.ErrAlreadyExists
+	}
+	r.deliveries[key] = delivery
+	return nil
+}
+
+// 送信した通知を記録する
+type recordingNotifier struct {
+	messages []*notification.Message
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, recipient *notification.Recipient, message *notification.Message) error {
+	n.messages = append(n.messages, message)
+	return nil
+}
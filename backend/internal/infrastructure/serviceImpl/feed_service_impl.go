@@ -0,0 +1,298 @@
+package serviceImpl
+
+// serviceImpl規約
+// ・エラーはhandlerに返すのみ。handler側でログ出力する。
+// ・複数のrepositoryメソッドもしくはデータを変更するrepositoryメソッドを使用する場合はトランザクションを実行する
+// ・repositoryのメソッドに渡すcontext.Contextについて
+//   -> トランザクションが不要な場合はhandlerから受け取ったctxをそのまま渡す
+//   -> トランザクションを実行する場合はmongo.SessionContextを渡す（mongo.SessionContextはcontext.Contextインターフェースを満たしている）
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend/internal/config"
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/feed"
+	"backend/internal/domain/repository"
+)
+
+type feedService struct {
+	client         *mongo.Client
+	userRepo       repository.UserRepository
+	friendshipRepo repository.FriendshipRepository
+	habitShareRepo repository.HabitShareRepository
+	feedItemRepo   repository.FeedItemRepository
+	cheerRepo      repository.CheerRepository
+	cheerRateRepo  repository.CheerRateLimitRepository
+}
+
+func NewFeedService(
+	client *mongo.Client,
+	userRepo repository.UserRepository,
+	friendshipRepo repository.FriendshipRepository,
+	habitShareRepo repository.HabitShareRepository,
+	feedItemRepo repository.FeedItemRepository,
+	cheerRepo repository.CheerRepository,
+	cheerRateRepo repository.CheerRateLimitRepository,
+) *feedService {
+	return &feedService{
+		client:         client,
+		userRepo:       userRepo,
+		friendshipRepo: friendshipRepo,
+		habitShareRepo: habitShareRepo,
+		feedItemRepo:   feedItemRepo,
+		cheerRepo:      cheerRepo,
+		cheerRateRepo:  cheerRateRepo,
+	}
+}
+
+// 自分とフレンドの習慣の完了を新しい順に取得
+// NOTE: フレンドの完了は、フレンドが自分に公開している習慣のみ
+func (s *feedService) GetFeed(ctx context.Context, userId string, page int, limit int) (*feed.Feed, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	result := &feed.Feed{Page: page, Limit: limit}
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		sharedHabitIds, err := s.fetchSharedHabitIds(sessionContext, userId)
+		if err != nil {
+			return err
+		}
+
+		offset := (page - 1) * limit
+		result.Items, result.Total, err = s.feedItemRepo.FetchFeed(sessionContext, userId, sharedHabitIds, offset, limit)
+		if err != nil {
+			return err
+		}
+
+		return s.attachCheers(sessionContext, userId, result.Items)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// 絵文字でリアクションする（リアクション済みの場合は何もしない）
+func (s *feedService) AddReaction(ctx context.Context, userId string, itemId string, emoji feed.Emoji) (*feed.Item, error) {
+	item, err := s.cheer(ctx, userId, itemId, func(sessionContext mongo.SessionContext, item *feed.Item) error {
+		if err := s.checkCheerRate(sessionContext, userId); err != nil {
+			return err
+		}
+
+		_, err := s.cheerRepo.Register(sessionContext, feed.NewReaction(item, userId, emoji))
+		return err
+	})
+
+	// リアクション済みの場合は、トランザクションが中断されて件数も戻るため、変更せずに取得し直す
+	if errors.Is(err, common.ErrAlreadyExists) {
+		return s.cheer(ctx, userId, itemId, func(sessionContext mongo.SessionContext, item *feed.Item) error {
+			return nil
+		})
+	}
+	return item, err
+}
+
+// リアクションの取り消し
+func (s *feedService) RemoveReaction(ctx context.Context, userId string, itemId string, emoji feed.Emoji) (*feed.Item, error) {
+	return s.cheer(ctx, userId, itemId, func(sessionContext mongo.SessionContext, item *feed.Item) error {
+		return s.cheerRepo.DeleteReaction(sessionContext, item.Id, userId, emoji)
+	})
+}
+
+// コメントする
+func (s *feedService) AddComment(ctx context.Context, userId string, itemId string, body string) (*feed.Item, error) {
+	return s.cheer(ctx, userId, itemId, func(sessionContext mongo.SessionContext, item *feed.Item) error {
+		comment, err := feed.NewComment(item, userId, body)
+		if err != nil {
+			return err
+		}
+		if err := s.checkCheerRate(sessionContext, userId); err != nil {
+			return err
+		}
+
+		_, err = s.cheerRepo.Register(sessionContext, comment)
+		return err
+	})
+}
+
+// コメントの削除（コメントしたユーザーと、フィードの持ち主のみ）
+func (s *feedService) DeleteComment(ctx context.Context, userId string, commentId string) error {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		comment, err := s.cheerRepo.Find(sessionContext, userId, commentId)
+		if err != nil {
+			return err
+		}
+		if !comment.CanDelete(userId) {
+			return common.ErrForbidden
+		}
+
+		return s.cheerRepo.Delete(sessionContext, userId, comment.Id)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// 閲覧できるフィードに対して応援を変更し、更新後のフィードを返す
+// NOTE: 応援の件数の加算と登録は1つのトランザクションで行う（登録に失敗した場合は件数を数えない）
+func (s *feedService) cheer(ctx context.Context, userId string, itemId string, change func(sessionContext mongo.SessionContext, item *feed.Item) error) (*feed.Item, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result *feed.Item
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		item, err := s.findVisibleItem(sessionContext, userId, itemId)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = change(sessionContext, item); err != nil {
+			return nil, err
+		}
+
+		if err = s.attachCheers(sessionContext, userId, []*feed.Item{item}); err != nil {
+			return nil, err
+		}
+		result = item
+		return nil, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// 閲覧できるフィードを取得する
+// 自分の完了か、フレンドが自分に公開している習慣の完了のみ（それ以外はErrNotFound）
+func (s *feedService) findVisibleItem(ctx context.Context, userId string, itemId string) (*feed.Item, error) {
+	sharedHabitIds, err := s.fetchSharedHabitIds(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return s.feedItemRepo.Find(ctx, userId, sharedHabitIds, itemId)
+}
+
+// フレンドが自分に公開している習慣IDを、フレンドのユーザーIDごとに取得する
+func (s *feedService) fetchSharedHabitIds(ctx context.Context, userId string) (map[string][]string, error) {
+	friendships, err := s.friendshipRepo.FetchAll(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	friendIds := make(map[string]bool, len(friendships))
+	for _, friendship := range friendships {
+		if friendship.IsAccepted() {
+			friendIds[friendship.OtherUserId(userId)] = true
+		}
+	}
+
+	shares, err := s.habitShareRepo.FetchSharedWith(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	sharedHabitIds := make(map[string][]string, len(shares))
+	for _, share := range shares {
+		if friendIds[share.UserId] {
+			sharedHabitIds[share.UserId] = share.HabitIds
+		}
+	}
+	return sharedHabitIds, nil
+}
+
+// 連投制限（期間内に送った応援が上限に達している場合はErrTooManyCheers）
+// NOTE: 期間はCheerRateLimitMinuteごとに区切り、期間ごとの件数を加算して判定する
+func (s *feedService) checkCheerRate(ctx context.Context, userId string) error {
+	period := config.CheerRateLimitMinute * time.Minute
+	window := time.Now().Truncate(period)
+	allowed, err := s.cheerRateRepo.Increment(ctx, userId, window, window.Add(period), config.CheerRateLimitCount)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return common.ErrTooManyCheers
+	}
+	return nil
+}
+
+// フィードに応援とユーザーネームを設定する
+func (s *feedService) attachCheers(ctx context.Context, userId string, items []*feed.Item) error {
+	itemIds := make([]string, 0, len(items))
+	userIds := make([]string, 0, len(items))
+	for _, item := range items {
+		itemIds = append(itemIds, item.Id)
+		userIds = append(userIds, item.UserId)
+	}
+
+	cheers, err := s.cheerRepo.FetchByItems(ctx, itemIds)
+	if err != nil {
+		return err
+	}
+	for _, cheer := range cheers {
+		if cheer.Kind == feed.KindComment {
+			userIds = append(userIds, cheer.UserId)
+		}
+	}
+
+	users, err := s.userRepo.FetchByIds(ctx, userIds)
+	if err != nil {
+		return err
+	}
+	usernames := make(map[string]string, len(users))
+	for _, user := range users {
+		usernames[user.Id] = user.Username
+	}
+
+	for _, item := range items {
+		item.AttachCheers(cheers, userId, usernames)
+	}
+	return nil
+}
+
+// 習慣の完了をフィードに登録する
+func postFeedItem(ctx context.Context, feedItemRepo repository.FeedItemRepository, userId string, habitStatus *daily_track.HabitStatus, date string) error {
+	_, err := feedItemRepo.Register(ctx, &feed.Item{
+		UserId:    userId,
+		HabitId:   habitStatus.HabitId,
+		HabitName: habitStatus.HabitName,
+		Date:      date,
+	})
+	return err
+}
+
+// 完了の取り消し時に、フィードとその応援を削除する
+func deleteFeedItem(ctx context.Context, feedItemRepo repository.FeedItemRepository, cheerRepo repository.CheerRepository, userId string, habitId string, date string) error {
+	if err := feedItemRepo.DeleteByCompletion(ctx, userId, habitId, date); err != nil {
+		return err
+	}
+	return cheerRepo.DeleteByCompletion(ctx, userId, habitId, date)
+}
@@ -0,0 +1,47 @@
+package serviceImpl
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"backend/internal/domain/model/feed"
+	"backend/internal/infrastructure/repositoryImpl"
+)
+
+// リアクション済みの場合は応援の件数を数えない（MongoDBを使用する）
+func TestAddReactionCountsOnlyRegistered(t *testing.T) {
+	client, db := newTestDatabase(t)
+	ctx := context.Background()
+
+	feedItemRepo := repositoryImpl.NewFeedItemRepository(db.Collection("feed_items"))
+	feedService := NewFeedService(client,
+		repositoryImpl.NewUserRepository(db.Collection("user")),
+		repositoryImpl.NewFriendshipRepository(db.Collection("friendships")),
+		repositoryImpl.NewHabitShareRepository(db.Collection("habit_shares")),
+		feedItemRepo,
+		repositoryImpl.NewCheerRepository(db.Collection("cheers")),
+		repositoryImpl.NewCheerRateLimitRepository(db.Collection("cheer_rate_limits")))
+
+	item, err := feedItemRepo.Register(ctx, &feed.Item{UserId: "user-1", HabitId: "habit-1", HabitName: "読書", Date: "2024-01-01"})
+	if err != nil {
+		t.Fatalf("failed to register feed item: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err = feedService.AddReaction(ctx, "user-1", item.Id, feed.EmojiClap); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var counter struct {
+		Count int `bson:"count"`
+	}
+	if err = db.Collection("cheer_rate_limits").FindOne(ctx, bson.M{"user_id": "user-1"}).Decode(&counter); err != nil {
+		t.Fatalf("failed to find counter: %v", err)
+	}
+	if counter.Count != 1 {
+		t.Errorf("count = %d, want 1", counter.Count)
+	}
+}
@@ -0,0 +1,453 @@
+package serviceImpl
+
+// serviceImpl規約
+// ・エラーはhandlerに返すのみ。handler側でログ出力する。
+// ・複数のrepositoryメソッドもしくはデータを変更するrepositoryメソッドを使用する場合はトランザクションを実行する
+// ・repositoryのメソッドに渡すcontext.Contextについて
+//   -> トランザクションが不要な場合はhandlerから受け取ったctxをそのまま渡す
+//   -> トランザクションを実行する場合はmongo.SessionContextを渡す（mongo.SessionContextはcontext.Contextインターフェースを満たしている）
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/friend"
+	userModel "backend/internal/domain/model/user"
+	"backend/internal/domain/repository"
+)
+
+type friendService struct {
+	client         *mongo.Client
+	userRepo       repository.UserRepository
+	habitRepo      repository.HabitRepository
+	dailyTrackRepo repository.DailyTrackRepository
+	friendshipRepo repository.FriendshipRepository
+	habitShareRepo repository.HabitShareRepository
+}
+
+func NewFriendService(
+	client *mongo.Client,
+	userRepo repository.UserRepository,
+	habitRepo repository.HabitRepository,
+	dailyTrackRepo repository.DailyTrackRepository,
+	friendshipRepo repository.FriendshipRepository,
+	habitShareRepo repository.HabitShareRepository,
+) *friendService {
+	return &friendService{
+		client:         client,
+		userRepo:       userRepo,
+		habitRepo:      habitRepo,
+		dailyTrackRepo: dailyTrackRepo,
+		friendshipRepo: friendshipRepo,
+		habitShareRepo: habitShareRepo,
+	}
+}
+
+// フレンド一覧（申請中・自分がブロックしたユーザーを含む）
+// NOTE: 相手にブロックされている場合は関係が無いものとして扱う
+func (s *friendService) GetFriends(ctx context.Context, userId string) ([]*friend.Friend, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var friends []*friend.Friend
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		friendships, err := s.friendshipRepo.FetchAll(sessionContext, userId)
+		if err != nil {
+			return err
+		}
+
+		otherUserIds := make([]string, 0, len(friendships))
+		for _, friendship := range friendships {
+			otherUserIds = append(otherUserIds, friendship.OtherUserId(userId))
+		}
+		users, err := s.userRepo.FetchByIds(sessionContext, otherUserIds)
+		if err != nil {
+			return err
+		}
+		usersById := make(map[string]*userModel.User, len(users))
+		for _, user := range users {
+			usersById[user.Id] = user
+		}
+
+		shares, err := s.habitShareRepo.FetchAll(sessionContext, userId)
+		if err != nil {
+			return err
+		}
+		sharesByFriend := make(map[string]*friend.Share, len(shares))
+		for _, share := range shares {
+			sharesByFriend[share.FriendId] = share
+		}
+
+		friends = make([]*friend.Friend, 0, len(friendships))
+		for _, friendship := range friendships {
+			otherUserId := friendship.OtherUserId(userId)
+			otherUser, ok := usersById[otherUserId]
+			if !ok || friendship.IsBlockedBy(otherUserId) {
+				continue
+			}
+			friends = append(friends, newFriend(friendship, userId, otherUser, sharesByFriend[otherUserId]))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return friends, nil
+}
+
+// ユーザーネームを指定してフレンド申請する
+// 相手からの申請が届いている場合は、そのまま承認する
+func (s *friendService) RequestFriend(ctx context.Context, userId string, username string) (*friend.Friend, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result *friend.Friend
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		target, err := s.userRepo.FindByUserName(sessionContext, username)
+		if err != nil {
+			return nil, err
+		}
+		if target.Id == userId {
+			return nil, common.ErrInvalidFriendRequest
+		}
+
+		friendship, err := s.friendshipRepo.Find(sessionContext, userId, target.Id)
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			friendship = &friend.Friendship{RequesterId: userId, AddresseeId: target.Id, Status: friend.StatusPending}
+			friendship, err = s.friendshipRepo.Register(sessionContext, friendship)
+			if err != nil {
+				return nil, err
+			}
+		case err != nil:
+			return nil, err
+		case friendship.IsBlockedBy(target.Id):
+			// ブロックされていることは相手に伝えない
+			return nil, common.ErrNotFound
+		case friendship.CanAccept(userId):
+			friendship.Status = friend.StatusAccepted
+			if err = s.friendshipRepo.Update(sessionContext, userId, friendship); err != nil {
+				return nil, err
+			}
+		case friendship.Status == friend.StatusBlocked:
+			// 自分がブロックしている場合は、解除してから申請する
+			return nil, common.ErrInvalidFriendRequest
+		default:
+			return nil, common.ErrAlreadyExists
+		}
+
+		result = newFriend(friendship, userId, target, nil)
+		return nil, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// 届いたフレンド申請を承認する
+func (s *friendService) AcceptFriend(ctx context.Context, userId string, friendId string) (*friend.Friend, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result *friend.Friend
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		friendship, err := s.friendshipRepo.Find(sessionContext, userId, friendId)
+		if err != nil {
+			return nil, err
+		}
+		if friendship.IsBlockedBy(friendId) {
+			return nil, common.ErrNotFound
+		}
+		if !friendship.CanAccept(userId) {
+			return nil, common.ErrInvalidFriendRequest
+		}
+
+		friendship.Status = friend.StatusAccepted
+		if err = s.friendshipRepo.Update(sessionContext, userId, friendship); err != nil {
+			return nil, err
+		}
+
+		target, err := s.userRepo.Find(sessionContext, friendId)
+		if err != nil {
+			return nil, err
+		}
+
+		result = newFriend(friendship, userId, target, nil)
+		return nil, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// フレンド解除（申請の取り消し・拒否を含む）
+// 双方の習慣の公開設定も削除する
+func (s *friendService) RemoveFriend(ctx context.Context, userId string, friendId string) error {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		friendship, err := s.friendshipRepo.Find(sessionContext, userId, friendId)
+		if err != nil {
+			return nil, err
+		}
+		if friendship.IsBlockedBy(friendId) {
+			return nil, common.ErrNotFound
+		}
+		if friendship.Status == friend.StatusBlocked {
+			// ブロックの解除はUnblockUserで行う
+			return nil, common.ErrInvalidFriendRequest
+		}
+
+		if err = s.friendshipRepo.Delete(sessionContext, userId, friendship.Id); err != nil {
+			return nil, err
+		}
+		return nil, s.habitShareRepo.DeleteBetween(sessionContext, userId, friendId)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ユーザーをブロックする（フレンドの場合は解除され、双方の習慣の公開設定も削除する）
+func (s *friendService) BlockUser(ctx context.Context, userId string, targetUserId string) error {
+	if targetUserId == userId {
+		return common.ErrInvalidFriendRequest
+	}
+
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		users, err := s.userRepo.FetchByIds(sessionContext, []string{targetUserId})
+		if err != nil {
+			return nil, err
+		}
+		if len(users) == 0 {
+			return nil, common.ErrNotFound
+		}
+
+		friendship, err := s.friendshipRepo.Find(sessionContext, userId, targetUserId)
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			friendship = &friend.Friendship{RequesterId: userId, AddresseeId: targetUserId, Status: friend.StatusBlocked, BlockedBy: userId}
+			if _, err = s.friendshipRepo.Register(sessionContext, friendship); err != nil {
+				return nil, err
+			}
+		case err != nil:
+			return nil, err
+		case friendship.Status == friend.StatusBlocked:
+			// すでにどちらかがブロックしている場合は何もしない
+			return nil, nil
+		default:
+			friendship.RequesterId, friendship.AddresseeId = userId, targetUserId
+			friendship.Status, friendship.BlockedBy = friend.StatusBlocked, userId
+			if err = s.friendshipRepo.Update(sessionContext, userId, friendship); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, s.habitShareRepo.DeleteBetween(sessionContext, userId, targetUserId)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ブロックの解除（自分がブロックした場合のみ）
+func (s *friendService) UnblockUser(ctx context.Context, userId string, targetUserId string) error {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		friendship, err := s.friendshipRepo.Find(sessionContext, userId, targetUserId)
+		if err != nil {
+			return nil, err
+		}
+		if !friendship.IsBlockedBy(userId) {
+			return nil, common.ErrNotFound
+		}
+
+		return nil, s.friendshipRepo.Delete(sessionContext, userId, friendship.Id)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// フレンドに公開する習慣を設定する（指定した習慣で置き換える）
+func (s *friendService) ShareHabits(ctx context.Context, userId string, friendId string, habitIds []string) (*friend.Share, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result *friend.Share
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		if err := s.checkFriendship(sessionContext, userId, friendId); err != nil {
+			return nil, err
+		}
+
+		// 自分の習慣のみ公開できる（重複は除く）
+		sharedHabitIds := make([]string, 0, len(habitIds))
+		seen := make(map[string]bool, len(habitIds))
+		for _, habitId := range habitIds {
+			if seen[habitId] {
+				continue
+			}
+			if _, err := s.habitRepo.Find(sessionContext, userId, habitId); err != nil {
+				return nil, err
+			}
+			seen[habitId] = true
+			sharedHabitIds = append(sharedHabitIds, habitId)
+		}
+
+		result, err = s.habitShareRepo.Update(sessionContext, &friend.Share{UserId: userId, FriendId: friendId, HabitIds: sharedHabitIds})
+		return nil, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// フレンドの指定日のdaily_trackを取得（閲覧のみ）
+// フレンドが自分に公開している習慣のみ返す
+// NOTE: フレンドのdaily_trackが無い日は作成せずErrNotFoundを返す
+func (s *friendService) GetFriendDailyTrack(ctx context.Context, userId string, friendId string, targetDate string) (*daily_track.DailyTrack, error) {
+	date, err := common.ParseDate(targetDate)
+	if err != nil {
+		return nil, common.ErrInvalidDate
+	}
+
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result *daily_track.DailyTrack
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		if err := s.checkFriendship(sessionContext, userId, friendId); err != nil {
+			return err
+		}
+
+		share, err := s.habitShareRepo.Find(sessionContext, friendId, userId)
+		if errors.Is(err, common.ErrNotFound) {
+			share = &friend.Share{UserId: friendId, FriendId: userId}
+		} else if err != nil {
+			return err
+		}
+
+		dailyTrack, err := s.dailyTrackRepo.FindDailyTrack(sessionContext, friendId, targetDate)
+		if err != nil {
+			return err
+		}
+
+		// 今週の達成回数を反映
+		weeklyDone, err := countWeeklyDone(sessionContext, s.dailyTrackRepo, friendId, date)
+		if err != nil {
+			return err
+		}
+		fillWeeklyProgress(dailyTrack, weeklyDone)
+
+		result = share.FilterDailyTrack(dailyTrack)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// フレンドかどうかを確認する（フレンドでない場合はErrNotFriends）
+func (s *friendService) checkFriendship(ctx context.Context, userId string, friendId string) error {
+	friendship, err := s.friendshipRepo.Find(ctx, userId, friendId)
+	if errors.Is(err, common.ErrNotFound) {
+		return common.ErrNotFriends
+	}
+	if err != nil {
+		return err
+	}
+	if !friendship.IsAccepted() {
+		return common.ErrNotFriends
+	}
+	return nil
+}
+
+// 自分から見たフレンド一覧の1行を作成する
+func newFriend(friendship *friend.Friendship, userId string, otherUser *userModel.User, share *friend.Share) *friend.Friend {
+	sharedHabitIds := make([]string, 0)
+	if share != nil {
+		sharedHabitIds = share.HabitIds
+	}
+	return &friend.Friend{
+		UserId:         otherUser.Id,
+		Username:       otherUser.Username,
+		Status:         friendship.Status,
+		Incoming:       friendship.Status == friend.StatusPending && friendship.AddresseeId == userId,
+		SharedHabitIds: sharedHabitIds,
+		Since:          friendship.UpdatedAt,
+	}
+}
@@ -15,40 +15,86 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"backend/internal/domain/common"
-	"backend/internal/domain/model/daily_track"
 	"backend/internal/domain/model/habit"
 	"backend/internal/domain/repository"
 )
 
 type habitService struct {
-	client         *mongo.Client
-	habitRepo      repository.HabitRepository
-	dailyTrackRepo repository.DailyTrackRepository
+	client           *mongo.Client
+	userRepo         repository.UserRepository
+	habitRepo        repository.HabitRepository
+	dailyTrackRepo   repository.DailyTrackRepository
+	streakFreezeRepo repository.StreakFreezeRepository
+	feedItemRepo     repository.FeedItemRepository
+	cheerRepo        repository.CheerRepository
+	reminderRepo     repository.ReminderRepository
+	habitShareRepo   repository.HabitShareRepository
+	challengeRepo    repository.ChallengeRepository
 }
 
-func NewHabitService(client *mongo.Client, habitRepo repository.HabitRepository, dailyTrackRepo repository.DailyTrackRepository) *habitService {
+func NewHabitService(
+	client *mongo.Client,
+	userRepo repository.UserRepository,
+	habitRepo repository.HabitRepository,
+	dailyTrackRepo repository.DailyTrackRepository,
+	streakFreezeRepo repository.StreakFreezeRepository,
+	feedItemRepo repository.FeedItemRepository,
+	cheerRepo repository.CheerRepository,
+	reminderRepo repository.ReminderRepository,
+	habitShareRepo repository.HabitShareRepository,
+	challengeRepo repository.ChallengeRepository,
+) *habitService {
 	return &habitService{
-		client:         client,
-		habitRepo:      habitRepo,
-		dailyTrackRepo: dailyTrackRepo,
+		client:           client,
+		userRepo:         userRepo,
+		habitRepo:        habitRepo,
+		dailyTrackRepo:   dailyTrackRepo,
+		streakFreezeRepo: streakFreezeRepo,
+		feedItemRepo:     feedItemRepo,
+		cheerRepo:        cheerRepo,
+		reminderRepo:     reminderRepo,
+		habitShareRepo:   habitShareRepo,
+		challengeRepo:    challengeRepo,
 	}
 }
 
-func (s *habitService) GetHabitList(ctx context.Context, userId string) ([]*habit.Habit, error) {
+// 習慣一覧取得
+// NOTE: includeArchivedがfalseの場合、アーカイブ済みの習慣は含めない
+func (s *habitService) GetHabitList(ctx context.Context, userId string, includeArchived bool) ([]*habit.Habit, error) {
 	habits, err := s.habitRepo.FetchAll(ctx, userId)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if habits == nil {
-		habits = make([]*habit.Habit, 0)
+	habitList := make([]*habit.Habit, 0, len(habits))
+	for _, targetHabit := range habits {
+		if targetHabit.IsArchived() && !includeArchived {
+			continue
+		}
+		habitList = append(habitList, targetHabit)
 	}
 
-	return habits, nil
+	return habitList, nil
 }
 
-func (s *habitService) RegisterHabit(ctx context.Context, userId string, habitName string) (*habit.Habit, error) {
+func (s *habitService) RegisterHabit(ctx context.Context, userId string, habitName string, schedule habit.Schedule, measure *habit.Measure, scoring habit.Scoring) (*habit.Habit, error) {
+	// スケジュールの検証
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 量の目標の検証（量を記録する習慣のみ）
+	if measure != nil {
+		if err := measure.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// ポイントの設定の検証
+	if err := scoring.Validate(); err != nil {
+		return nil, err
+	}
 
 	// セッションの開始
 	session, err := s.client.StartSession()
@@ -62,47 +108,90 @@ func (s *habitService) RegisterHabit(ctx context.Context, userId string, habitNa
 	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
 
 		// 新規登録
-		newHabit := habit.Habit{UserId: userId, Name: habitName}
+		newHabit := habit.Habit{UserId: userId, Name: habitName, Schedule: schedule, Measure: measure, Scoring: scoring, Status: habit.StatusActive}
 		resultHabit, err = s.habitRepo.Register(sessionContext, &newHabit)
 
 		if err != nil {
 			return err
 		}
 
-		// 今日のdaily-trackを取得
-		todayString := time.Now().Format(`2006-01-02`) // YYYY-MM-DD
-		todaysTrack, err := s.dailyTrackRepo.FindDailyTrack(sessionContext, userId, todayString)
-		if err != nil && !errors.Is(err, common.ErrNotFound) {
+		// 今日のdaily-trackがあり、今日が実施予定日であれば作成した習慣を追加
+		today, err := userToday(sessionContext, s.userRepo, userId)
+		if err != nil {
 			return err
 		}
+		return s.addToTodaysTrack(sessionContext, userId, resultHabit, today)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resultHabit, nil
+}
+
+// 習慣の名前・表示順・ポイントの設定を更新する
+// 名前を変更した場合、今日以降のdaily_trackにも反映する（過去のdaily_trackは当時の名前を残す）
+// NOTE: sortOrder・scoringがnilの場合は変更しない
+func (s *habitService) UpdateHabit(ctx context.Context, userId string, habitId string, habitName string, sortOrder *int, scoring *habit.Scoring) (*habit.Habit, error) {
+	if scoring != nil {
+		if err := scoring.Validate(); err != nil {
+			return nil, err
+		}
+	}
 
-		// 今日のdaily-trackがあれば作成した習慣を追加
-		if !errors.Is(err, common.ErrNotFound) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
 
-			newHabitStatus := &daily_track.HabitStatus{
-				HabitId:   newHabit.Id,
-				HabitName: newHabit.Name,
-				IsDone:    false,
-			}
-			todaysTrack.HabitStatuses = append(todaysTrack.HabitStatuses, newHabitStatus)
+	// トランザクションの実行
+	var targetHabit *habit.Habit
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		targetHabit, err = s.habitRepo.Find(sessionContext, userId, habitId)
+		if err != nil {
+			return err
+		}
 
-			err = s.dailyTrackRepo.UpdateHabitStatuses(sessionContext, todaysTrack)
-			if err != nil {
-				return err
-			}
+		renamed := targetHabit.Name != habitName
+		targetHabit.Name = habitName
+		if sortOrder != nil {
+			targetHabit.SortOrder = *sortOrder
+		}
+		if scoring != nil {
+			targetHabit.Scoring = *scoring
 		}
 
-		return nil
+		// 更新
+		err = s.habitRepo.Update(sessionContext, targetHabit)
+		if err != nil {
+			return err
+		}
+
+		if !renamed {
+			return nil
+		}
+
+		// 今日以降のdaily_trackの習慣名を更新
+		today, err := userToday(sessionContext, s.userRepo, userId)
+		if err != nil {
+			return err
+		}
+		todayString := common.FormatDate(today) // YYYY-MM-DD
+		return s.dailyTrackRepo.RenameHabit(sessionContext, userId, habitId, habitName, todayString)
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	return resultHabit, nil
+	return targetHabit, nil
 }
 
-func (s *habitService) DeleteHabit(ctx context.Context, userId string, habitId string) error {
+// 習慣の表示順をhabitIdsの並び順に更新する
+func (s *habitService) ReorderHabits(ctx context.Context, userId string, habitIds []string) error {
 	// セッションの開始
 	session, err := s.client.StartSession()
 	if err != nil {
@@ -112,47 +201,194 @@ func (s *habitService) DeleteHabit(ctx context.Context, userId string, habitId s
 
 	// トランザクションの実行
 	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		return s.habitRepo.UpdateSortOrders(sessionContext, userId, habitIds)
+	})
 
-		// 削除
-		err := s.habitRepo.Delete(sessionContext, habitId)
+	if err != nil {
+		return err
+	}
 
-		if err != nil {
-			return err
+	return nil
+}
+
+// 習慣を再開日まで一時停止する
+func (s *habitService) PauseHabit(ctx context.Context, userId string, habitId string, pausedUntil string) (*habit.Habit, error) {
+	return s.changeHabitStatus(ctx, userId, habitId, func(targetHabit *habit.Habit, today time.Time) error {
+		return targetHabit.Pause(pausedUntil, today)
+	})
+}
+
+// 習慣の一時停止を解除する
+func (s *habitService) ResumeHabit(ctx context.Context, userId string, habitId string) (*habit.Habit, error) {
+	return s.changeHabitStatus(ctx, userId, habitId, func(targetHabit *habit.Habit, today time.Time) error {
+		return targetHabit.Resume()
+	})
+}
+
+// 習慣をアーカイブする（履歴は残る）
+func (s *habitService) ArchiveHabit(ctx context.Context, userId string, habitId string) (*habit.Habit, error) {
+	now := time.Now()
+	return s.changeHabitStatus(ctx, userId, habitId, func(targetHabit *habit.Habit, today time.Time) error {
+		return targetHabit.Archive(now)
+	})
+}
+
+// 習慣をアーカイブから復元する
+func (s *habitService) RestoreHabit(ctx context.Context, userId string, habitId string) (*habit.Habit, error) {
+	return s.changeHabitStatus(ctx, userId, habitId, func(targetHabit *habit.Habit, today time.Time) error {
+		return targetHabit.Restore()
+	})
+}
+
+// 習慣を完全に削除する（daily_trackの履歴・ストリークフリーズ・フィードと応援・リマインダーも削除）
+// NOTE: ポイント台帳は変更しない
+func (s *habitService) PurgeHabit(ctx context.Context, userId string, habitId string) error {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		// 削除（他のユーザーの習慣の場合はErrNotFound）
+		if err := s.habitRepo.Delete(sessionContext, userId, habitId); err != nil {
+			return nil, err
 		}
 
-		// 今日のdaily-trackを取得
-		todayString := time.Now().Format(`2006-01-02`) // YYYY-MM-DD
-		todaysTrack, err := s.dailyTrackRepo.FindDailyTrack(sessionContext, userId, todayString)
-		if err != nil && !errors.Is(err, common.ErrNotFound) {
-			return err
+		// 履歴の削除
+		if err := s.dailyTrackRepo.RemoveHabit(sessionContext, userId, habitId); err != nil {
+			return nil, err
 		}
 
-		if errors.Is(err, common.ErrNotFound) {
-			return nil
+		if err := s.streakFreezeRepo.DeleteByHabit(sessionContext, userId, habitId); err != nil {
+			return nil, err
 		}
 
-		// 今日のdaily-trackがあれば習慣を削除
-		for index, habitStatus := range todaysTrack.HabitStatuses {
-			if habitStatus.HabitId == habitId && !habitStatus.IsDone {
-				// 削除対象の習慣が完了していなければ削除
-				todaysTrack.HabitStatuses = append(todaysTrack.HabitStatuses[:index], todaysTrack.HabitStatuses[index+1:]...)
+		// フィードと応援の削除
+		if err := s.feedItemRepo.DeleteByHabit(sessionContext, userId, habitId); err != nil {
+			return nil, err
+		}
+		if err := s.cheerRepo.DeleteByHabit(sessionContext, userId, habitId); err != nil {
+			return nil, err
+		}
 
-				// 永続化
-				err = s.dailyTrackRepo.UpdateHabitStatuses(sessionContext, todaysTrack)
-				if err != nil {
-					return err
-				}
+		if err := s.reminderRepo.DeleteByHabit(sessionContext, userId, habitId); err != nil {
+			return nil, err
+		}
 
-				break
-			}
+		// フレンドへの公開設定と、グループチャレンジの紐づけから外す
+		if err := s.habitShareRepo.RemoveHabit(sessionContext, userId, habitId); err != nil {
+			return nil, err
 		}
+		return nil, s.challengeRepo.UnlinkHabit(sessionContext, userId, habitId)
+	})
 
-		return nil
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// 習慣の状態を変更し、今日のdaily_trackに反映する
+func (s *habitService) changeHabitStatus(ctx context.Context, userId string, habitId string, change func(targetHabit *habit.Habit, today time.Time) error) (*habit.Habit, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var targetHabit *habit.Habit
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		targetHabit, err = s.habitRepo.Find(sessionContext, userId, habitId)
+		if err != nil {
+			return nil, err
+		}
+
+		today, err := userToday(sessionContext, s.userRepo, userId)
+		if err != nil {
+			return nil, err
+		}
+
+		// 状態の変更
+		if err = change(targetHabit, today); err != nil {
+			return nil, err
+		}
+
+		// 更新
+		if err = s.habitRepo.Update(sessionContext, targetHabit); err != nil {
+			return nil, err
+		}
+
+		// 今日のdaily_trackに反映
+		if targetHabit.IsScheduledOn(today) {
+			return nil, s.addToTodaysTrack(sessionContext, userId, targetHabit, today)
+		}
+		return nil, s.removeFromTodaysTrack(sessionContext, userId, habitId, today)
 	})
 
+	if err != nil {
+		return nil, err
+	}
+
+	return targetHabit, nil
+}
+
+// 今日のdaily_trackがあれば、今日が実施予定日の習慣を追加する（追加済みの場合は何もしない）
+func (s *habitService) addToTodaysTrack(ctx context.Context, userId string, targetHabit *habit.Habit, today time.Time) error {
+	// 今日のdaily-trackを取得
+	todayString := common.FormatDate(today) // YYYY-MM-DD
+	todaysTrack, err := s.dailyTrackRepo.FindDailyTrack(ctx, userId, todayString)
+	if errors.Is(err, common.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if findHabitStatus(todaysTrack, targetHabit.Id) != nil {
+		return nil
+	}
+
+	// 今日が実施予定日であれば追加
+	weeklyDone, err := countWeeklyDone(ctx, s.dailyTrackRepo, userId, today)
 	if err != nil {
 		return err
 	}
+	habitStatus := newHabitStatus(targetHabit, today, weeklyDone)
+	if habitStatus == nil {
+		return nil
+	}
+	todaysTrack.HabitStatuses = append(todaysTrack.HabitStatuses, habitStatus)
+
+	return s.dailyTrackRepo.UpdateHabitStatuses(ctx, todaysTrack)
+}
+
+// 今日のdaily_trackがあれば、未完了の習慣を取り除く（完了済みの場合は記録を残す）
+func (s *habitService) removeFromTodaysTrack(ctx context.Context, userId string, habitId string, today time.Time) error {
+	// 今日のdaily-trackを取得
+	todayString := common.FormatDate(today) // YYYY-MM-DD
+	todaysTrack, err := s.dailyTrackRepo.FindDailyTrack(ctx, userId, todayString)
+	if errors.Is(err, common.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for index, habitStatus := range todaysTrack.HabitStatuses {
+		if habitStatus.HabitId == habitId && !habitStatus.IsDone {
+			// 対象の習慣が完了していなければ削除
+			todaysTrack.HabitStatuses = append(todaysTrack.HabitStatuses[:index], todaysTrack.HabitStatuses[index+1:]...)
+
+			// 永続化
+			return s.dailyTrackRepo.UpdateHabitStatuses(ctx, todaysTrack)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,298 @@
+package serviceImpl
+
+// serviceImpl規約
+// ・エラーはhandlerに返すのみ。handler側でログ出力する。
+// ・複数のrepositoryメソッドもしくはデータを変更するrepositoryメソッドを使用する場合はトランザクションを実行する
+// ・repositoryのメソッドに渡すcontext.Contextについて
+//   -> トランザクションが不要な場合はhandlerから受け取ったctxをそのまま渡す
+//   -> トランザクションを実行する場合はmongo.SessionContextを渡す（mongo.SessionContextはcontext.Contextインターフェースを満たしている）
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/ranking"
+	"backend/internal/domain/model/streak"
+	userModel "backend/internal/domain/model/user"
+	"backend/internal/domain/repository"
+)
+
+type leaderboardService struct {
+	client         *mongo.Client
+	userRepo       repository.UserRepository
+	friendshipRepo repository.FriendshipRepository
+	rankingRepo    repository.RankingRepository
+}
+
+func NewLeaderboardService(client *mongo.Client, userRepo repository.UserRepository, friendshipRepo repository.FriendshipRepository, rankingRepo repository.RankingRepository) *leaderboardService {
+	return &leaderboardService{
+		client:         client,
+		userRepo:       userRepo,
+		friendshipRepo: friendshipRepo,
+		rankingRepo:    rankingRepo,
+	}
+}
+
+// ランキングを取得（ランキングを公開していないユーザーは含めない）
+// 週・月の集計期間は自分にとっての今日が属する期間とする
+// フレンドのランキングは自分と承認済みのフレンドのみで順位を付ける
+func (s *leaderboardService) GetLeaderboard(ctx context.Context, userId string, board ranking.Board, scope ranking.Scope, page int, limit int) (*ranking.Leaderboard, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var leaderboard *ranking.Leaderboard
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		me, err := s.userRepo.Find(sessionContext, userId)
+		if err != nil {
+			return err
+		}
+		today := me.Today(time.Now())
+
+		leaderboard = &ranking.Leaderboard{
+			Board:  board,
+			Scope:  scope,
+			Period: board.Period(today),
+			Page:   page,
+			Limit:  limit,
+		}
+
+		// 対象ユーザー（nilの場合は全ユーザー）
+		var userIds []string
+		if scope == ranking.ScopeFriends {
+			userIds, err = s.fetchFriendIds(sessionContext, me.Id)
+			if err != nil {
+				return err
+			}
+		}
+
+		offset := (page - 1) * limit
+		if board == ranking.BoardPoints {
+			return s.fillPointsLeaderboard(sessionContext, leaderboard, me, userIds, offset)
+		}
+		return s.fillScoreLeaderboard(sessionContext, leaderboard, me, userIds, today, offset)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return leaderboard, nil
+}
+
+// 累計ポイントのランキング（ユーザーのポイントをそのまま使う）
+func (s *leaderboardService) fillPointsLeaderboard(ctx context.Context, leaderboard *ranking.Leaderboard, me *userModel.User, targetIds []string, offset int) error {
+	users, total, err := s.userRepo.FetchTopPoints(ctx, targetIds, offset, leaderboard.Limit)
+	if err != nil {
+		return err
+	}
+	leaderboard.Total = total
+
+	scores := make([]*ranking.Score, 0, len(users))
+	usernames := make(map[string]string, len(users))
+	for _, user := range users {
+		scores = append(scores, &ranking.Score{UserId: user.Id, Score: user.Points})
+		usernames[user.Id] = user.Username
+	}
+
+	leaderboard.Entries, err = buildEntries(scores, usernames, me.Id, offset, func(score int) (int64, error) {
+		return s.userRepo.CountHigherPoints(ctx, targetIds, score)
+	})
+	if err != nil {
+		return err
+	}
+
+	// 自分の順位
+	if !me.HideFromLeaderboard {
+		higher, err := s.userRepo.CountHigherPoints(ctx, targetIds, me.Points)
+		if err != nil {
+			return err
+		}
+		leaderboard.Me = &ranking.Entry{Rank: int(higher) + 1, UserId: me.Id, Username: me.Username, Score: me.Points, IsMe: true}
+	}
+
+	return nil
+}
+
+// 完了の度に集計しているスコアのランキング（週・月の獲得ポイント、連続達成日数）
+func (s *leaderboardService) fillScoreLeaderboard(ctx context.Context, leaderboard *ranking.Leaderboard, me *userModel.User, targetIds []string, today time.Time, offset int) error {
+	// 連続達成日数は途切れていないものだけ対象にする
+	// NOTE: ユーザーごとにタイムゾーンが異なるため、1日前までを許容する
+	activeFrom := ""
+	if leaderboard.Board == ranking.BoardStreak {
+		activeFrom = common.FormatDate(today.AddDate(0, 0, -1))
+	}
+
+	scores, total, err := s.rankingRepo.FetchTop(ctx, leaderboard.Board, leaderboard.Period, activeFrom, targetIds, offset, leaderboard.Limit)
+	if err != nil {
+		return err
+	}
+	leaderboard.Total = total
+
+	userIds := make([]string, 0, len(scores))
+	for _, score := range scores {
+		userIds = append(userIds, score.UserId)
+	}
+	users, err := s.userRepo.FetchByIds(ctx, userIds)
+	if err != nil {
+		return err
+	}
+	usernames := make(map[string]string, len(users))
+	for _, user := range users {
+		usernames[user.Id] = user.Username
+	}
+
+	countHigher := func(score int) (int64, error) {
+		return s.rankingRepo.CountHigher(ctx, leaderboard.Board, leaderboard.Period, activeFrom, targetIds, score)
+	}
+	leaderboard.Entries, err = buildEntries(scores, usernames, me.Id, offset, countHigher)
+	if err != nil {
+		return err
+	}
+
+	// 自分の順位（未集計・途切れた連続達成の場合はなし）
+	if me.HideFromLeaderboard {
+		return nil
+	}
+	myScore, err := s.rankingRepo.FindScore(ctx, me.Id, leaderboard.Board, leaderboard.Period)
+	if errors.Is(err, common.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if activeFrom != "" && myScore.ActiveUntil < activeFrom {
+		return nil
+	}
+	higher, err := countHigher(myScore.Score)
+	if err != nil {
+		return err
+	}
+	leaderboard.Me = &ranking.Entry{Rank: int(higher) + 1, UserId: me.Id, Username: me.Username, Score: myScore.Score, IsMe: true}
+
+	return nil
+}
+
+// 自分と承認済みのフレンドのユーザーID
+func (s *leaderboardService) fetchFriendIds(ctx context.Context, userId string) ([]string, error) {
+	friendships, err := s.friendshipRepo.FetchAll(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	userIds := []string{userId}
+	for _, friendship := range friendships {
+		if friendship.IsAccepted() {
+			userIds = append(userIds, friendship.OtherUserId(userId))
+		}
+	}
+	return userIds, nil
+}
+
+// スコアの高い順に並んだページに順位を付ける（同点は同順位）
+// NOTE: ページの先頭は前のページと同点の可能性があるため、より高いスコアの数から順位を求める
+func buildEntries(scores []*ranking.Score, usernames map[string]string, myId string, offset int, countHigher func(score int) (int64, error)) ([]*ranking.Entry, error) {
+	entries := make([]*ranking.Entry, 0, len(scores))
+	for i, score := range scores {
+		rank := offset + i + 1
+		switch {
+		case i == 0 && offset > 0:
+			higher, err := countHigher(score.Score)
+			if err != nil {
+				return nil, err
+			}
+			rank = int(higher) + 1
+		case i > 0 && score.Score == scores[i-1].Score:
+			rank = entries[i-1].Rank
+		}
+
+		entries = append(entries, &ranking.Entry{
+			Rank:     rank,
+			UserId:   score.UserId,
+			Username: usernames[score.UserId],
+			Score:    score.Score,
+			IsMe:     score.UserId == myId,
+		})
+	}
+	return entries, nil
+}
+
+// 習慣の完了・取り消しをランキングのスコアに反映する
+// earnedは今回の完了・取り消しで増減した獲得ポイント（完了した日の週・月に加算する）
+func updateRankings(ctx context.Context, userRepo repository.UserRepository, rankingRepo repository.RankingRepository, facts *completionFacts, earned int) error {
+	user, err := userRepo.Find(ctx, facts.userId)
+	if err != nil {
+		return err
+	}
+
+	if earned != 0 {
+		for _, board := range []ranking.Board{ranking.BoardWeekly, ranking.BoardMonthly} {
+			score := &ranking.Score{
+				UserId: user.Id,
+				Board:  board,
+				Period: board.Period(facts.date),
+				Score:  earned,
+				Hidden: user.HideFromLeaderboard,
+			}
+			if err := rankingRepo.IncrementScore(ctx, score); err != nil {
+				return err
+			}
+		}
+	}
+
+	// 連続達成日数は全習慣のうち最長のものを記録する
+	current, activeUntil, err := longestActiveStreak(facts)
+	if err != nil {
+		return err
+	}
+	score := &ranking.Score{
+		UserId:      user.Id,
+		Board:       ranking.BoardStreak,
+		Score:       current,
+		Hidden:      user.HideFromLeaderboard,
+		ActiveUntil: activeUntil,
+	}
+	return rankingRepo.UpdateScore(ctx, score)
+}
+
+// 日単位の習慣のうち最長の継続中の連続達成日数と、その連続が途切れない最終日（次の実施予定日）を返す
+func longestActiveStreak(facts *completionFacts) (int, string, error) {
+	habits, streaks, err := facts.habitStreaks()
+	if err != nil {
+		return 0, "", err
+	}
+
+	var longestHabit *habit.Habit
+	longest := 0
+	for _, targetHabit := range habits {
+		habitStreak := streaks[targetHabit.Id]
+		if targetHabit.IsArchived() || habitStreak.Unit != streak.UnitDay || habitStreak.Current <= longest {
+			continue
+		}
+		longestHabit, longest = targetHabit, habitStreak.Current
+	}
+
+	if longestHabit == nil {
+		return 0, common.FormatDate(facts.today), nil
+	}
+	return longest, common.FormatDate(nextScheduledDate(longestHabit, facts.today)), nil
+}
+
+// 指定日より後の最初の実施予定日（1年以内に無い場合は指定日）
+func nextScheduledDate(targetHabit *habit.Habit, date time.Time) time.Time {
+	for next := date.AddDate(0, 0, 1); next.Before(date.AddDate(1, 0, 1)); next = next.AddDate(0, 0, 1) {
+		if targetHabit.IsScheduledOn(next) {
+			return next
+		}
+	}
+	return date
+}
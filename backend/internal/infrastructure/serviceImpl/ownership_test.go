@@ -0,0 +1,144 @@
+package serviceImpl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
+	userModel "backend/internal/domain/model/user"
+)
+
+const (
+	ownerUserId = "owner"
+	otherUserId = "other"
+	ownedHabit  = "habit-1"
+)
+
+type ownershipFixture struct {
+	habitRepo      *fakeHabitRepo
+	dailyTrackRepo *fakeDailyTrackRepo
+	habitService   *habitService
+	streakService  *streakService
+	trackService   *dailyTrackService
+	today          string
+}
+
+func newOwnershipFixture(t *testing.T) *ownershipFixture {
+	t.Helper()
+
+	users := map[string]*userModel.User{
+		ownerUserId: {Id: ownerUserId, Timezone: "UTC"},
+		otherUserId: {Id: otherUserId, Timezone: "UTC"},
+	}
+	today := common.FormatDate(users[ownerUserId].Today(time.Now()))
+
+	userRepo := &fakeUserRepo{users: users}
+	habitRepo := &fakeHabitRepo{habits: map[string]*habit.Habit{
+		ownedHabit: {Id: ownedHabit, UserId: ownerUserId, Name: "読書", Schedule: habit.DefaultSchedule(), Status: habit.StatusActive},
+	}}
+	// 他のユーザーのdaily_trackには、所有者の習慣は含まれない
+	dailyTrackRepo := &fakeDailyTrackRepo{dailyTracks: map[string]*daily_track.DailyTrack{
+		ownerUserId + "/" + today: {Id: "track-owner", UserId: ownerUserId, Date: today, HabitStatuses: []*daily_track.HabitStatus{
+			{HabitId: ownedHabit, HabitName: "読書", Measure: &habit.Measure{Target: 10, Unit: "ページ"}},
+		}},
+		otherUserId + "/" + today: {Id: "track-other", UserId: otherUserId, Date: today, HabitStatuses: []*daily_track.HabitStatus{}},
+	}}
+
+	client := newTestClient(t)
+	return &ownershipFixture{
+		habitRepo:      habitRepo,
+		dailyTrackRepo: dailyTrackRepo,
+		habitService:   NewHabitService(client, userRepo, habitRepo, dailyTrackRepo, nil, nil, nil, nil, nil, nil),
+		streakService:  NewStreakService(client, userRepo, habitRepo, dailyTrackRepo, nil, nil),
+		trackService:   NewDailyTrackService(client, userRepo, habitRepo, dailyTrackRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil),
+		today:          today,
+	}
+}
+
+// 他のユーザーの習慣に対する操作は、存在しない習慣と同じくErrNotFoundになり、何も変更しない
+func TestHabitOwnership(t *testing.T) {
+	yesterday := common.FormatDate(time.Now().UTC().AddDate(0, 0, -1))
+
+	tests := []struct {
+		name string
+		call func(f *ownershipFixture, userId string) error
+	}{
+		{"UpdateHabit", func(f *ownershipFixture, userId string) error {
+			_, err := f.habitService.UpdateHabit(context.Background(), userId, ownedHabit, "改名", nil, nil)
+			return err
+		}},
+		{"PauseHabit", func(f *ownershipFixture, userId string) error {
+			_, err := f.habitService.PauseHabit(context.Background(), userId, ownedHabit, "2999-01-01")
+			return err
+		}},
+		{"ResumeHabit", func(f *ownershipFixture, userId string) error {
+			_, err := f.habitService.ResumeHabit(context.Background(), userId, ownedHabit)
+			return err
+		}},
+		{"ArchiveHabit", func(f *ownershipFixture, userId string) error {
+			_, err := f.habitService.ArchiveHabit(context.Background(), userId, ownedHabit)
+			return err
+		}},
+		{"RestoreHabit", func(f *ownershipFixture, userId string) error {
+			_, err := f.habitService.RestoreHabit(context.Background(), userId, ownedHabit)
+			return err
+		}},
+		{"PurgeHabit", func(f *ownershipFixture, userId string) error {
+			return f.habitService.PurgeHabit(context.Background(), userId, ownedHabit)
+		}},
+		{"GetStreak", func(f *ownershipFixture, userId string) error {
+			_, err := f.streakService.GetStreak(context.Background(), userId, ownedHabit)
+			return err
+		}},
+		{"UseFreeze", func(f *ownershipFixture, userId string) error {
+			_, _, err := f.streakService.UseFreeze(context.Background(), userId, ownedHabit, yesterday)
+			return err
+		}},
+		{"UpdateDoneDailyTrack", func(f *ownershipFixture, userId string) error {
+			_, err := f.trackService.UpdateDoneDailyTrack(context.Background(), userId, f.today, ownedHabit)
+			return err
+		}},
+		{"UndoDoneDailyTrack", func(f *ownershipFixture, userId string) error {
+			_, err := f.trackService.UndoDoneDailyTrack(context.Background(), userId, f.today, ownedHabit)
+			return err
+		}},
+		{"LogHabitAmount", func(f *ownershipFixture, userId string) error {
+			_, err := f.trackService.LogHabitAmount(context.Background(), userId, f.today, ownedHabit, 5)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newOwnershipFixture(t)
+
+			err := tt.call(f, otherUserId)
+			if !errors.Is(err, common.ErrNotFound) {
+				t.Fatalf("got error %v, want ErrNotFound", err)
+			}
+			if len(f.habitRepo.updated) > 0 || len(f.habitRepo.deleted) > 0 {
+				t.Errorf("habit was changed: updated %v, deleted %v", f.habitRepo.updated, f.habitRepo.deleted)
+			}
+			if len(f.dailyTrackRepo.updated) > 0 {
+				t.Errorf("daily track was changed: %v", f.dailyTrackRepo.updated)
+			}
+		})
+	}
+}
+
+// 量を記録する習慣は完了にできず、取り消しのみ受け付ける
+func TestUpdateDoneMeasurableHabit(t *testing.T) {
+	f := newOwnershipFixture(t)
+
+	_, err := f.trackService.UpdateDoneDailyTrack(context.Background(), ownerUserId, f.today, ownedHabit)
+	if !errors.Is(err, common.ErrAmountRequired) {
+		t.Fatalf("got error %v, want ErrAmountRequired", err)
+	}
+	if len(f.dailyTrackRepo.updated) > 0 {
+		t.Errorf("daily track was changed: %v", f.dailyTrackRepo.updated)
+	}
+}
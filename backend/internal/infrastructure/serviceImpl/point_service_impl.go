@@ -0,0 +1,107 @@
+package serviceImpl
+
+// serviceImpl規約
+// ・エラーはhandlerに返すのみ。handler側でログ出力する。
+// ・複数のrepositoryメソッドもしくはデータを変更するrepositoryメソッドを使用する場合はトランザクションを実行する
+// ・repositoryのメソッドに渡すcontext.Contextについて
+//   -> トランザクションが不要な場合はhandlerから受け取ったctxをそのまま渡す
+//   -> トランザクションを実行する場合はmongo.SessionContextを渡す（mongo.SessionContextはcontext.Contextインターフェースを満たしている）
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend/internal/domain/model/level"
+	"backend/internal/domain/model/point"
+	"backend/internal/domain/repository"
+)
+
+type pointService struct {
+	client          *mongo.Client
+	pointLedgerRepo repository.PointLedgerRepository
+}
+
+func NewPointService(client *mongo.Client, pointLedgerRepo repository.PointLedgerRepository) *pointService {
+	return &pointService{
+		client:          client,
+		pointLedgerRepo: pointLedgerRepo,
+	}
+}
+
+func (s *pointService) GetPointHistory(ctx context.Context, userId string, page int, limit int) (*point.History, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var history *point.History
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		offset := (page - 1) * limit
+		entries, total, err := s.pointLedgerRepo.FetchHistory(sessionContext, userId, offset, limit)
+		if err != nil {
+			return err
+		}
+
+		// 残高は台帳から算出する
+		balance, err := s.pointLedgerRepo.SumAmount(sessionContext, userId)
+		if err != nil {
+			return err
+		}
+
+		history = &point.History{
+			Entries: entries,
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Balance: balance,
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// ポイントの増減を台帳に記録し、ユーザーのポイントに反映する
+// 更新後のポイントを返す
+// NOTE: ポイントを変更するサービスは必ずこの関数を経由する。台帳とユーザーのポイントがずれないよう、トランザクション内で呼び出す
+func recordPoints(ctx context.Context, userRepo repository.UserRepository, pointLedgerRepo repository.PointLedgerRepository, entry *point.LedgerEntry) (int, error) {
+	if _, err := pointLedgerRepo.Append(ctx, entry); err != nil {
+		return 0, err
+	}
+
+	return userRepo.IncrementPoints(ctx, entry.UserId, entry.Amount)
+}
+
+// ポイントを消費し、台帳に記録する（ポイントが足りない場合はErrInsufficientPoints）
+// 消費後のポイントを返す
+// NOTE: entry.Amountには消費するポイントを負の値で指定する。recordPointsと同じくトランザクション内で呼び出す
+func spendPoints(ctx context.Context, userRepo repository.UserRepository, pointLedgerRepo repository.PointLedgerRepository, entry *point.LedgerEntry) (int, error) {
+	points, err := userRepo.SpendPoints(ctx, entry.UserId, -entry.Amount)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = pointLedgerRepo.Append(ctx, entry); err != nil {
+		return 0, err
+	}
+
+	return points, nil
+}
+
+// 台帳の獲得ポイントの累計を経験値としてユーザーのレベルを算出する
+func userLevel(ctx context.Context, pointLedgerRepo repository.PointLedgerRepository, userId string) (*level.Level, error) {
+	experience, err := pointLedgerRepo.SumEarned(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return level.DefaultCurve().Calculate(experience), nil
+}
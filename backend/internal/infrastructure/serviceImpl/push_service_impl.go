@@ -0,0 +1,99 @@
+package serviceImpl
+
+// serviceImpl規約
+// ・エラーはhandlerに返すのみ。handler側でログ出力する。
+// ・複数のrepositoryメソッドもしくはデータを変更するrepositoryメソッドを使用する場合はトランザクションを実行する
+// ・repositoryのメソッドに渡すcontext.Contextについて
+//   -> トランザクションが不要な場合はhandlerから受け取ったctxをそのまま渡す
+//   -> トランザクションを実行する場合はmongo.SessionContextを渡す（mongo.SessionContextはcontext.Contextインターフェースを満たしている）
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend/internal/config"
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/notification"
+	"backend/internal/domain/repository"
+)
+
+type pushService struct {
+	client               *mongo.Client
+	pushSubscriptionRepo repository.PushSubscriptionRepository
+	vapid                *config.VAPID
+}
+
+// NOTE: vapidがnilの場合はWeb Pushを使用しない（ErrPushNotConfigured）
+func NewPushService(
+	client *mongo.Client,
+	pushSubscriptionRepo repository.PushSubscriptionRepository,
+	vapid *config.VAPID,
+) *pushService {
+	return &pushService{
+		client:               client,
+		pushSubscriptionRepo: pushSubscriptionRepo,
+		vapid:                vapid,
+	}
+}
+
+// ブラウザで購読する際に使う公開鍵（applicationServerKey）
+func (s *pushService) GetPublicKey() (string, error) {
+	if s.vapid == nil {
+		return "", common.ErrPushNotConfigured
+	}
+	return s.vapid.PublicKey, nil
+}
+
+// ブラウザの購読を登録する
+// NOTE: 同じブラウザで別のユーザーがログインした場合は、後から登録したユーザーの購読になる
+func (s *pushService) Subscribe(ctx context.Context, userId string, subscription *notification.PushSubscription) (*notification.PushSubscription, error) {
+	if s.vapid == nil {
+		return nil, common.ErrPushNotConfigured
+	}
+	subscription.UserId = userId
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result *notification.PushSubscription
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		result, err = s.pushSubscriptionRepo.Register(sessionContext, subscription)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ブラウザの購読を解除する（ログアウト時・通知の許可を取り消した時）
+func (s *pushService) Unsubscribe(ctx context.Context, userId string, endpoint string) error {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		return s.pushSubscriptionRepo.Delete(sessionContext, userId, endpoint)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package serviceImpl
+
+// serviceImpl規約
+// ・エラーはhandlerに返すのみ。handler側でログ出力する。
+// ・複数のrepositoryメソッドもしくはデータを変更するrepositoryメソッドを使用する場合はトランザクションを実行する
+// ・repositoryのメソッドに渡すcontext.Contextについて
+//   -> トランザクションが不要な場合はhandlerから受け取ったctxをそのまま渡す
+//   -> トランザクションを実行する場合はmongo.SessionContextを渡す（mongo.SessionContextはcontext.Contextインターフェースを満たしている）
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend/internal/config"
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/notification"
+	"backend/internal/domain/model/reminder"
+	userModel "backend/internal/domain/model/user"
+	"backend/internal/domain/repository"
+	"backend/internal/domain/service"
+)
+
+type reminderService struct {
+	client               *mongo.Client
+	userRepo             repository.UserRepository
+	habitRepo            repository.HabitRepository
+	dailyTrackRepo       repository.DailyTrackRepository
+	reminderRepo         repository.ReminderRepository
+	reminderDeliveryRepo repository.ReminderDeliveryRepository
+	notifiers            map[notification.Channel]service.Notifier
+}
+
+func NewReminderService(
+	client *mongo.Client,
+	userRepo repository.UserRepository,
+	habitRepo repository.HabitRepository,
+	dailyTrackRepo repository.DailyTrackRepository,
+	reminderRepo repository.ReminderRepository,
+	reminderDeliveryRepo repository.ReminderDeliveryRepository,
+	notifiers map[notification.Channel]service.Notifier,
+) *reminderService {
+	return &reminderService{
+		client:               client,
+		userRepo:             userRepo,
+		habitRepo:            habitRepo,
+		dailyTrackRepo:       dailyTrackRepo,
+		reminderRepo:         reminderRepo,
+		reminderDeliveryRepo: reminderDeliveryRepo,
+		notifiers:            notifiers,
+	}
+}
+
+// 習慣のリマインダー一覧
+func (s *reminderService) GetReminders(ctx context.Context, userId string, habitId string) ([]*reminder.Reminder, error) {
+	// 他のユーザーの習慣の場合はErrNotFound
+	if _, err := s.habitRepo.Find(ctx, userId, habitId); err != nil {
+		return nil, err
+	}
+
+	return s.reminderRepo.FetchByHabit(ctx, userId, habitId)
+}
+
+// 習慣のリマインダーの時刻を指定したもので置き換える（空の場合は全て削除）
+func (s *reminderService) UpdateReminders(ctx context.Context, userId string, habitId string, times []string) ([]*reminder.Reminder, error) {
+	// 時刻の検証（重複は除く）
+	clocks := make([]string, 0, len(times))
+	seen := make(map[string]bool, len(times))
+	for _, value := range times {
+		clock, err := reminder.ParseTime(value)
+		if err != nil {
+			return nil, err
+		}
+		if seen[clock] {
+			continue
+		}
+		seen[clock] = true
+		clocks = append(clocks, clock)
+	}
+	if len(clocks) > config.MaxRemindersPerHabit {
+		return nil, common.ErrInvalidReminder
+	}
+	sort.Strings(clocks)
+
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result []*reminder.Reminder
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		// 他のユーザーの習慣の場合はErrNotFound
+		if _, err := s.habitRepo.Find(sessionContext, userId, habitId); err != nil {
+			return err
+		}
+
+		user, err := s.userRepo.Find(sessionContext, userId)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		reminders := make([]*reminder.Reminder, 0, len(clocks))
+		for _, clock := range clocks {
+			reminders = append(reminders, &reminder.Reminder{Time: clock, NextAt: reminder.NextAt(clock, user.Location(), now)})
+		}
+
+		result, err = s.reminderRepo.ReplaceByHabit(sessionContext, userId, habitId, reminders)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// 送信日時を過ぎたリマインダーを処理し、送信した件数を返す
+// 対象の日に予定があり、まだ完了していない習慣のみ通知する
+// NOTE: 送信日時を進めてから送信するため、複数台で実行しても1回だけ送信される（送信に失敗した場合も再送しない）
+func (s *reminderService) DispatchDueReminders(ctx context.Context, now time.Time) (int, error) {
+	reminders, err := s.reminderRepo.FetchDue(ctx, now, config.ReminderBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	errs := make([]error, 0)
+	for _, dueReminder := range reminders {
+		recipient, message, err := s.claimReminder(ctx, dueReminder, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if message == nil {
+			continue
+		}
+
+		if err := s.notify(ctx, recipient, message); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send reminder (reminder_id: %s): %w", dueReminder.Id, err))
+			continue
+		}
+		sent++
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+// リマインダーの送信日時を次回に進め、今回送信する場合は宛先と内容を返す（送信しない場合はnil）
+func (s *reminderService) claimReminder(ctx context.Context, dueReminder *reminder.Reminder, now time.Time) (*notification.Recipient, *notification.Message, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var recipient *notification.Recipient
+	var message *notification.Message
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		user, err := s.userRepo.Find(sessionContext, dueReminder.UserId)
+		if err != nil {
+			return err
+		}
+
+		// 他の処理が先に進めていた場合は何もしない
+		nextAt := reminder.NextAt(dueReminder.Time, user.Location(), now)
+		claimed, err := s.reminderRepo.Advance(sessionContext, dueReminder.Id, dueReminder.NextAt, nextAt)
+		if err != nil || !claimed {
+			return err
+		}
+
+		// 停止中などで大きく遅れた場合は送信しない
+		if now.Sub(dueReminder.NextAt) > config.ReminderMaxDelayMinute*time.Minute || user.Notification == nil {
+			return nil
+		}
+
+		// 送信予定の日時がユーザーにとってどの日か
+		date := user.Today(dueReminder.NextAt)
+		dateString := common.FormatDate(date)
+
+		targetHabit, err := s.habitRepo.Find(sessionContext, user.Id, dueReminder.HabitId)
+		if errors.Is(err, common.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !targetHabit.IsScheduledOn(date) {
+			return nil
+		}
+
+		// daily_trackが未作成の場合は未完了として扱う
+		dailyTrack, err := s.dailyTrackRepo.FindDailyTrack(sessionContext, user.Id, dateString)
+		if err != nil && !errors.Is(err, common.ErrNotFound) {
+			return err
+		}
+		if dailyTrack != nil {
+			habitStatus := findHabitStatus(dailyTrack, targetHabit.Id)
+			if habitStatus == nil || habitStatus.IsDone {
+				return nil
+			}
+		}
+
+		// 同じ日に送信済みの場合は送信しない（再起動後・時刻の変更後の二重送信を防ぐ）
+		err = s.reminderDeliveryRepo.Register(sessionContext, &reminder.Delivery{ReminderId: dueReminder.Id, UserId: user.Id, Date: dateString})
+		if errors.Is(err, common.ErrAlreadyExists) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		recipient = &notification.Recipient{UserId: user.Id, Settings: *user.Notification}
+		message = notification.NewReminderMessage(targetHabit.Id, targetHabit.Name, dateString)
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return recipient, message, nil
+}
+
+// ユーザーの通知設定の送信方法で送信する
+func (s *reminderService) notify(ctx context.Context, recipient *notification.Recipient, message *notification.Message) error {
+	notifier, ok := s.notifiers[recipient.Settings.Channel]
+	if !ok {
+		return fmt.Errorf("notifier is not configured (channel: %s)", recipient.Settings.Channel)
+	}
+	return notifier.Notify(ctx, recipient, message)
+}
+
+// タイムゾーンの変更時に、ユーザーのリマインダーの送信日時を計算し直す
+func rescheduleReminders(ctx context.Context, reminderRepo repository.ReminderRepository, user *userModel.User, now time.Time) error {
+	reminders, err := reminderRepo.FetchByUser(ctx, user.Id)
+	if err != nil {
+		return err
+	}
+
+	for _, target := range reminders {
+		if err := reminderRepo.UpdateNextAt(ctx, user.Id, target.Id, reminder.NextAt(target.Time, user.Location(), now)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
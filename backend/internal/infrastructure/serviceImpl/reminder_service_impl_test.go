@@ -0,0 +1,100 @@
+package serviceImpl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/notification"
+	"backend/internal/domain/model/reminder"
+	userModel "backend/internal/domain/model/user"
+	"backend/internal/domain/service"
+	"backend/internal/infrastructure/repositoryImpl"
+)
+
+// 送信後に送信日時が同じ日の時刻に戻された場合（タイムゾーンの変更・再起動前の状態からの再実行など）も、
+// 送信済みの記録で同じ日の二重送信を防ぐ
+func TestDispatchDueRemindersSendsOncePerDay(t *testing.T) {
+	dueAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	notifier := &recordingNotifier{}
+	userRepo := &fakeUserRepo{users: map[string]*userModel.User{
+		"user-1": {Id: "user-1", Timezone: "UTC", Notification: &notification.Settings{Channel: notification.ChannelWebhook, Address: "https://example.com/hook"}},
+	}}
+	habitRepo := &fakeHabitRepo{habits: map[string]*habit.Habit{
+		"habit-1": {Id: "habit-1", UserId: "user-1", Name: "読書", Schedule: habit.DefaultSchedule(), Status: habit.StatusActive},
+	}}
+	reminderRepo := &fakeReminderRepo{reminders: []*reminder.Reminder{
+		{Id: "reminder-1", UserId: "user-1", HabitId: "habit-1", Time: "09:00", NextAt: dueAt},
+	}}
+	deliveryRepo := &fakeReminderDeliveryRepo{deliveries: map[string]*reminder.Delivery{}}
+
+	reminderService := NewReminderService(newTestClient(t), userRepo, habitRepo, &fakeDailyTrackRepo{}, reminderRepo, deliveryRepo,
+		map[notification.Channel]service.Notifier{notification.ChannelWebhook: notifier})
+
+	assertDispatchTwice(t, reminderService, func() error {
+		return reminderRepo.UpdateNextAt(context.Background(), "user-1", "reminder-1", dueAt)
+	}, dueAt, notifier)
+}
+
+// reminder_deliveriesのユニークインデックス（reminder_id, date）で二重送信を防ぐ（MongoDBを使用する）
+func TestDispatchDueRemindersUniqueDeliveryIndex(t *testing.T) {
+	client, db := newTestDatabase(t)
+	ctx := context.Background()
+	dueAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	userRepo := repositoryImpl.NewUserRepository(db.Collection("user"))
+	habitRepo := repositoryImpl.NewHabitRepository(db.Collection("habits"))
+	reminderRepo := repositoryImpl.NewReminderRepository(db.Collection("reminders"))
+
+	registeredUser, err := userRepo.Register(ctx, &userModel.User{Username: "reminder-user", Password: "password", Timezone: "UTC"})
+	if err != nil {
+		t.Fatalf("failed to register user: %v", err)
+	}
+	settings := &notification.Settings{Channel: notification.ChannelWebhook, Address: "https://example.com/hook"}
+	if err = userRepo.UpdateNotificationSettings(ctx, registeredUser.Id, settings); err != nil {
+		t.Fatalf("failed to update notification settings: %v", err)
+	}
+	registeredHabit, err := habitRepo.Register(ctx, &habit.Habit{UserId: registeredUser.Id, Name: "読書", Schedule: habit.DefaultSchedule(), Status: habit.StatusActive})
+	if err != nil {
+		t.Fatalf("failed to register habit: %v", err)
+	}
+	reminders, err := reminderRepo.ReplaceByHabit(ctx, registeredUser.Id, registeredHabit.Id, []*reminder.Reminder{{Time: "09:00", NextAt: dueAt}})
+	if err != nil {
+		t.Fatalf("failed to register reminder: %v", err)
+	}
+
+	notifier := &recordingNotifier{}
+	reminderService := NewReminderService(client, userRepo, habitRepo,
+		repositoryImpl.NewDailyTrackRepository(db.Collection("daily_track")), reminderRepo,
+		repositoryImpl.NewReminderDeliveryRepository(db.Collection("reminder_deliveries")),
+		map[notification.Channel]service.Notifier{notification.ChannelWebhook: notifier})
+
+	assertDispatchTwice(t, reminderService, func() error {
+		return reminderRepo.UpdateNextAt(ctx, registeredUser.Id, reminders[0].Id, dueAt)
+	}, dueAt, notifier)
+}
+
+// 1回目は送信し、rewindで送信日時を戻した2回目は送信しないことを確認する
+func assertDispatchTwice(t *testing.T, reminderService *reminderService, rewind func() error, dueAt time.Time, notifier *recordingNotifier) {
+	t.Helper()
+	ctx := context.Background()
+
+	sent, err := reminderService.DispatchDueReminders(ctx, dueAt.Add(time.Minute))
+	if err != nil || sent != 1 {
+		t.Fatalf("first dispatch: sent %d, err %v (want 1, nil)", sent, err)
+	}
+
+	if err = rewind(); err != nil {
+		t.Fatalf("failed to rewind reminder: %v", err)
+	}
+
+	sent, err = reminderService.DispatchDueReminders(ctx, dueAt.Add(2*time.Minute))
+	if err != nil || sent != 0 {
+		t.Fatalf("second dispatch: sent %d, err %v (want 0, nil)", sent, err)
+	}
+	if len(notifier.messages) != 1 {
+		t.Errorf("notified %d times, want 1", len(notifier.messages))
+	}
+}
@@ -0,0 +1,213 @@
+package serviceImpl
+
+// serviceImpl規約
+// ・エラーはhandlerに返すのみ。handler側でログ出力する。
+// ・複数のrepositoryメソッドもしくはデータを変更するrepositoryメソッドを使用する場合はトランザクションを実行する
+// ・repositoryのメソッドに渡すcontext.Contextについて
+//   -> トランザクションが不要な場合はhandlerから受け取ったctxをそのまま渡す
+//   -> トランザクションを実行する場合はmongo.SessionContextを渡す（mongo.SessionContextはcontext.Contextインターフェースを満たしている）
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend/internal/domain/model/point"
+	"backend/internal/domain/model/reward"
+	"backend/internal/domain/repository"
+)
+
+type rewardService struct {
+	client          *mongo.Client
+	userRepo        repository.UserRepository
+	rewardRepo      repository.RewardRepository
+	redemptionRepo  repository.RewardRedemptionRepository
+	pointLedgerRepo repository.PointLedgerRepository
+}
+
+func NewRewardService(
+	client *mongo.Client,
+	userRepo repository.UserRepository,
+	rewardRepo repository.RewardRepository,
+	redemptionRepo repository.RewardRedemptionRepository,
+	pointLedgerRepo repository.PointLedgerRepository,
+) *rewardService {
+	return &rewardService{
+		client:          client,
+		userRepo:        userRepo,
+		rewardRepo:      rewardRepo,
+		redemptionRepo:  redemptionRepo,
+		pointLedgerRepo: pointLedgerRepo,
+	}
+}
+
+func (s *rewardService) GetRewards(ctx context.Context, userId string) ([]*reward.Reward, error) {
+	return s.rewardRepo.FetchAll(ctx, userId)
+}
+
+func (s *rewardService) RegisterReward(ctx context.Context, userId string, name string, cost int, stock *int, cooldownHours int) (*reward.Reward, error) {
+	newReward := &reward.Reward{
+		UserId:        userId,
+		Name:          name,
+		Cost:          cost,
+		Stock:         stock,
+		CooldownHours: cooldownHours,
+	}
+	if err := newReward.Validate(); err != nil {
+		return nil, err
+	}
+
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var registeredReward *reward.Reward
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		registeredReward, err = s.rewardRepo.Register(sessionContext, newReward)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return registeredReward, nil
+}
+
+// ごほうびの更新（交換日時はそのまま）
+func (s *rewardService) UpdateReward(ctx context.Context, userId string, rewardId string, name string, cost int, stock *int, cooldownHours int) (*reward.Reward, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var updatedReward *reward.Reward
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		targetReward, err := s.rewardRepo.Find(sessionContext, userId, rewardId)
+		if err != nil {
+			return err
+		}
+
+		targetReward.Name = name
+		targetReward.Cost = cost
+		targetReward.Stock = stock
+		targetReward.CooldownHours = cooldownHours
+		if err := targetReward.Validate(); err != nil {
+			return err
+		}
+
+		if err := s.rewardRepo.Update(sessionContext, targetReward); err != nil {
+			return err
+		}
+		updatedReward = targetReward
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedReward, nil
+}
+
+// ごほうびの削除（交換履歴は残す）
+func (s *rewardService) DeleteReward(ctx context.Context, userId string, rewardId string) error {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	return mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		return s.rewardRepo.Delete(sessionContext, userId, rewardId)
+	})
+}
+
+// ポイントを消費してごほうびと交換する
+// 交換履歴・交換後のごほうび・交換後のポイントを返す
+// NOTE: ポイントは残高が足りる場合のみ減算する条件付き更新で消費するため、同時に交換しても残高は負にならない
+// 在庫・クールダウンの確保、ポイントの消費、交換履歴の登録は1つのトランザクションで行い、途中で失敗した場合は全て取り消す
+func (s *rewardService) RedeemReward(ctx context.Context, userId string, rewardId string) (*reward.Redemption, *reward.Reward, int, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var redemption *reward.Redemption
+	var redeemedReward *reward.Reward
+	var points int
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		targetReward, err := s.rewardRepo.Find(sessionContext, userId, rewardId)
+		if err != nil {
+			return nil, err
+		}
+
+		// 在庫・クールダウンのチェック
+		now := time.Now()
+		if err := targetReward.CanRedeem(now); err != nil {
+			return nil, err
+		}
+
+		// 在庫の減算・交換日時の更新（同時に交換された場合はErrRewardOutOfStockもしくはErrRewardCoolingDown）
+		redeemedReward, err = s.rewardRepo.Redeem(sessionContext, targetReward, now)
+		if err != nil {
+			return nil, err
+		}
+
+		// ポイント消費（足りない場合はErrInsufficientPoints）
+		entry := &point.LedgerEntry{
+			UserId: userId,
+			Amount: -targetReward.Cost,
+			Reason: point.ReasonReward,
+		}
+		points, err = spendPoints(sessionContext, s.userRepo, s.pointLedgerRepo, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		// 交換履歴の登録
+		redemption, err = s.redemptionRepo.Register(sessionContext, &reward.Redemption{
+			UserId:     userId,
+			RewardId:   targetReward.Id,
+			RewardName: targetReward.Name,
+			Cost:       targetReward.Cost,
+			CreatedAt:  now,
+		})
+		return nil, err
+	})
+
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	return redemption, redeemedReward, points, nil
+}
+
+func (s *rewardService) GetRedemptionHistory(ctx context.Context, userId string, page int, limit int) (*reward.RedemptionHistory, error) {
+	offset := (page - 1) * limit
+	redemptions, total, err := s.redemptionRepo.FetchHistory(ctx, userId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reward.RedemptionHistory{
+		Redemptions: redemptions,
+		Page:        page,
+		Limit:       limit,
+		Total:       total,
+	}, nil
+}
@@ -0,0 +1,133 @@
+package serviceImpl
+
+// serviceImpl規約
+// ・エラーはhandlerに返すのみ。handler側でログ出力する。
+// ・複数のrepositoryメソッドもしくはデータを変更するrepositoryメソッドを使用する場合はトランザクションを実行する
+// ・repositoryのメソッドに渡すcontext.Contextについて
+//   -> トランザクションが不要な場合はhandlerから受け取ったctxをそのまま渡す
+//   -> トランザクションを実行する場合はmongo.SessionContextを渡す（mongo.SessionContextはcontext.Contextインターフェースを満たしている）
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend/internal/config"
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/stats"
+	"backend/internal/domain/repository"
+)
+
+type statsService struct {
+	client    *mongo.Client
+	userRepo  repository.UserRepository
+	statsRepo repository.StatsRepository
+}
+
+func NewStatsService(client *mongo.Client, userRepo repository.UserRepository, statsRepo repository.StatsRepository) *statsService {
+	return &statsService{
+		client:    client,
+		userRepo:  userRepo,
+		statsRepo: statsRepo,
+	}
+}
+
+// 今日までのwindowDays日間の統計を取得
+// habitIdが空文字列の場合は全習慣を対象にする
+func (s *statsService) GetStats(ctx context.Context, userId string, windowDays int, habitId string) (*stats.Stats, error) {
+	if windowDays < 1 || windowDays > config.MaxDailyTrackRangeDays {
+		return nil, common.ErrInvalidDateRange
+	}
+
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result *stats.Stats
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		today, err := userToday(sessionContext, s.userRepo, userId)
+		if err != nil {
+			return err
+		}
+		toDate := common.FormatDate(today)
+		fromDate := common.FormatDate(today.AddDate(0, 0, -(windowDays - 1)))
+
+		// 月ごとの推移は直近数ヶ月の月初から
+		trendFrom := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(config.StatsTrendMonths - 1), 0)
+		trendFromDate := common.FormatDate(trendFrom)
+
+		result = &stats.Stats{WindowDays: windowDays, From: fromDate, To: toDate, HabitId: habitId}
+
+		// 日ごと
+		result.Daily, err = s.statsRepo.AggregateCompletion(sessionContext, userId, fromDate, toDate, habitId, stats.GroupByDate)
+		if err != nil {
+			return err
+		}
+
+		// 期間全体（日ごとの集計を合算）
+		result.Overall = &stats.Completion{Key: "overall"}
+		for _, daily := range result.Daily {
+			result.Overall.Done += daily.Done
+			result.Overall.Scheduled += daily.Scheduled
+		}
+		result.Overall.CalculateRate()
+
+		// 曜日ごと
+		result.Weekdays, err = s.statsRepo.AggregateCompletion(sessionContext, userId, fromDate, toDate, habitId, stats.GroupByWeekday)
+		if err != nil {
+			return err
+		}
+
+		// 月ごと
+		result.Monthly, err = s.statsRepo.AggregateCompletion(sessionContext, userId, trendFromDate, toDate, habitId, stats.GroupByMonth)
+		if err != nil {
+			return err
+		}
+
+		// 習慣ごとの期間別の達成率
+		result.Habits, err = s.aggregateHabitRates(sessionContext, userId, today, habitId)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// 習慣ごとに、7日・30日・90日などの期間別の達成率を集計
+func (s *statsService) aggregateHabitRates(ctx context.Context, userId string, today time.Time, habitId string) ([]*stats.HabitStats, error) {
+	habitStatsList := make([]*stats.HabitStats, 0)
+	habitStatsMap := make(map[string]*stats.HabitStats)
+
+	// NOTE: 長い期間から集計し、習慣名は最も新しいものを採用する
+	for i := len(stats.RateWindows) - 1; i >= 0; i-- {
+		window := stats.RateWindows[i]
+		fromDate := common.FormatDate(today.AddDate(0, 0, -(window - 1)))
+
+		completions, err := s.statsRepo.AggregateCompletion(ctx, userId, fromDate, common.FormatDate(today), habitId, stats.GroupByHabit)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, completion := range completions {
+			habitStats, ok := habitStatsMap[completion.Key]
+			if !ok {
+				habitStats = &stats.HabitStats{HabitId: completion.Key, Rates: make(map[string]*stats.Completion)}
+				habitStatsMap[completion.Key] = habitStats
+				habitStatsList = append(habitStatsList, habitStats)
+			}
+			habitStats.HabitName = completion.HabitName
+			habitStats.Rates[strconv.Itoa(window)+"d"] = completion
+		}
+	}
+
+	return habitStatsList, nil
+}
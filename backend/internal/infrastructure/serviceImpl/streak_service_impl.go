@@ -0,0 +1,341 @@
+package serviceImpl
+
+// serviceImpl規約
+// ・エラーはhandlerに返すのみ。handler側でログ出力する。
+// ・複数のrepositoryメソッドもしくはデータを変更するrepositoryメソッドを使用する場合はトランザクションを実行する
+// ・repositoryのメソッドに渡すcontext.Contextについて
+//   -> トランザクションが不要な場合はhandlerから受け取ったctxをそのまま渡す
+//   -> トランザクションを実行する場合はmongo.SessionContextを渡す（mongo.SessionContextはcontext.Contextインターフェースを満たしている）
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend/internal/config"
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/point"
+	"backend/internal/domain/model/streak"
+	"backend/internal/domain/repository"
+)
+
+// 履歴取得の下限日付（全期間を対象にする）
+// NOTE: 連続達成数の算出では、最長の記録が無い習慣がある場合のみ全期間を対象にする
+const streakHistoryFromDate = "0001-01-01"
+
+type streakService struct {
+	client           *mongo.Client
+	userRepo         repository.UserRepository
+	habitRepo        repository.HabitRepository
+	dailyTrackRepo   repository.DailyTrackRepository
+	streakFreezeRepo repository.StreakFreezeRepository
+	pointLedgerRepo  repository.PointLedgerRepository
+}
+
+func NewStreakService(
+	client *mongo.Client,
+	userRepo repository.UserRepository,
+	habitRepo repository.HabitRepository,
+	dailyTrackRepo repository.DailyTrackRepository,
+	streakFreezeRepo repository.StreakFreezeRepository,
+	pointLedgerRepo repository.PointLedgerRepository,
+) *streakService {
+	return &streakService{
+		client:           client,
+		userRepo:         userRepo,
+		habitRepo:        habitRepo,
+		dailyTrackRepo:   dailyTrackRepo,
+		streakFreezeRepo: streakFreezeRepo,
+		pointLedgerRepo:  pointLedgerRepo,
+	}
+}
+
+// 全習慣の連続達成数を取得（習慣ID -> 連続達成数）
+func (s *streakService) GetStreaks(ctx context.Context, userId string) (map[string]*streak.Streak, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var streaks map[string]*streak.Streak
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		habits, err := s.habitRepo.FetchAll(sessionContext, userId)
+		if err != nil {
+			return err
+		}
+
+		streaks, err = s.calculateStreaks(sessionContext, userId, habits)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return streaks, nil
+}
+
+// 指定した習慣の連続達成数を取得
+func (s *streakService) GetStreak(ctx context.Context, userId string, habitId string) (*streak.Streak, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result *streak.Streak
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		targetHabit, err := s.habitRepo.Find(sessionContext, userId, habitId)
+		if err != nil {
+			return err
+		}
+
+		streaks, err := s.calculateStreaks(sessionContext, userId, []*habit.Habit{targetHabit})
+		if err != nil {
+			return err
+		}
+		result = streaks[habitId]
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ポイントを消費してストリークフリーズを1つ購入する
+// 購入後のストリークフリーズの所持数とポイントを返す
+// ポイントの消費とストリークフリーズの付与は1つのトランザクションで行う
+func (s *streakService) PurchaseFreezeToken(ctx context.Context, userId string) (int, int, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var freezeTokens, points int
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		// ポイント消費
+		entry := &point.LedgerEntry{
+			UserId: userId,
+			Amount: -config.PointsForStreakFreeze,
+			Reason: point.ReasonStreakFreeze,
+		}
+		points, err = spendPoints(sessionContext, s.userRepo, s.pointLedgerRepo, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		// ストリークフリーズ付与
+		freezeTokens, err = s.userRepo.IncrementFreezeTokens(sessionContext, userId, 1)
+		return nil, err
+	})
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return freezeTokens, points, nil
+}
+
+// 未達成の日にストリークフリーズを使用する
+// 使用後の連続達成数とストリークフリーズの所持数を返す
+// 所持数の減算と使用の登録は1つのトランザクションで行う
+func (s *streakService) UseFreeze(ctx context.Context, userId string, habitId string, targetDate string) (*streak.Streak, int, error) {
+	date, err := common.ParseDate(targetDate)
+	if err != nil {
+		return nil, 0, common.ErrInvalidDate
+	}
+
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var result *streak.Streak
+	var freezeTokens int
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		// 今日以降の日付には使用できない
+		today, err := userToday(sessionContext, s.userRepo, userId)
+		if err != nil {
+			return nil, err
+		}
+		if !date.Before(today) {
+			return nil, common.ErrFreezeNotNeeded
+		}
+
+		targetHabit, err := s.habitRepo.Find(sessionContext, userId, habitId)
+		if err != nil {
+			return nil, err
+		}
+
+		// 実施予定日でない日には不要
+		if !targetHabit.IsScheduledOn(date) {
+			return nil, common.ErrFreezeNotNeeded
+		}
+
+		// 達成済みの日には不要
+		dailyTrack, err := s.dailyTrackRepo.FindDailyTrack(sessionContext, userId, targetDate)
+		if err != nil && !errors.Is(err, common.ErrNotFound) {
+			return nil, err
+		}
+		if dailyTrack != nil {
+			if habitStatus := findHabitStatus(dailyTrack, habitId); habitStatus != nil && habitStatus.IsDone {
+				return nil, common.ErrFreezeNotNeeded
+			}
+		}
+
+		// 所持数を減らす（所持していない場合はErrNoFreezeTokens）
+		freezeTokens, err = s.userRepo.IncrementFreezeTokens(sessionContext, userId, -1)
+		if err != nil {
+			return nil, err
+		}
+
+		// ストリークフリーズの使用を登録（同じ日に使用済みの場合はErrAlreadyExists）
+		freeze := &streak.Freeze{UserId: userId, HabitId: habitId, Date: targetDate}
+		if _, err = s.streakFreezeRepo.Register(sessionContext, freeze); err != nil {
+			return nil, err
+		}
+
+		streaks, err := s.calculateStreaks(sessionContext, userId, []*habit.Habit{targetHabit})
+		if err != nil {
+			return nil, err
+		}
+		result = streaks[habitId]
+
+		return nil, nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return result, freezeTokens, nil
+}
+
+// ユーザーにとっての今日時点の連続達成数を算出
+func (s *streakService) calculateStreaks(ctx context.Context, userId string, habits []*habit.Habit) (map[string]*streak.Streak, error) {
+	today, err := userToday(ctx, s.userRepo, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return calculateStreaksAsOf(ctx, s.habitRepo, s.dailyTrackRepo, s.streakFreezeRepo, userId, habits, today)
+}
+
+// daily_trackの履歴とストリークフリーズから習慣ごとの連続達成数を算出
+// 履歴は今日からStreakHistoryWindowDaysずつ遡って取得し、連続が途切れていない習慣がそれより前の履歴に無くなった時点で取得をやめる
+// 最長の連続達成数は習慣に記録した値と比較し、長くなった場合は記録を更新する
+// NOTE: 最長の記録は短くしない（完了を取り消しても記録は残る）
+func calculateStreaksAsOf(
+	ctx context.Context,
+	habitRepo repository.HabitRepository,
+	dailyTrackRepo repository.DailyTrackRepository,
+	streakFreezeRepo repository.StreakFreezeRepository,
+	userId string,
+	habits []*habit.Habit,
+	today time.Time,
+) (map[string]*streak.Streak, error) {
+	freezes, err := streakFreezeRepo.FetchAll(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 最長の記録が無い習慣（記録の導入前に登録された習慣）がある場合は全期間を取得する
+	fullHistory := false
+	for _, targetHabit := range habits {
+		fullHistory = fullHistory || targetHabit.LongestStreak == nil
+	}
+
+	var dailyTracks []*daily_track.DailyTrack
+	var streaks map[string]*streak.Streak
+	toDate := today
+	for {
+		// 週の途中で区切ると週単位の連続が途切れたと判定されるため、週の始まりから取得する
+		windowStart := habit.WeekStart(toDate.AddDate(0, 0, 1-config.StreakHistoryWindowDays))
+		fromDate := common.FormatDate(windowStart)
+		if fullHistory {
+			fromDate = streakHistoryFromDate
+		}
+
+		windowTracks, err := dailyTrackRepo.FindDailyTracks(ctx, userId, fromDate, common.FormatDate(toDate))
+		if err != nil {
+			return nil, err
+		}
+		dailyTracks = append(windowTracks, dailyTracks...)
+		streaks = buildStreaks(habits, dailyTracks, freezes, today)
+		if fullHistory {
+			break
+		}
+
+		// 連続が途切れていない習慣が、取得した期間より前の履歴にあれば続けて遡る
+		unbrokenHabitIds := make([]string, 0)
+		for habitId, habitStreak := range streaks {
+			if !habitStreak.Broken {
+				unbrokenHabitIds = append(unbrokenHabitIds, habitId)
+			}
+		}
+		if len(unbrokenHabitIds) == 0 {
+			break
+		}
+		exists, err := dailyTrackRepo.ExistsHabitStatusBefore(ctx, userId, unbrokenHabitIds, fromDate)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			break
+		}
+		toDate = windowStart.AddDate(0, 0, -1)
+	}
+
+	// 最長の記録と比較して更新
+	for _, targetHabit := range habits {
+		habitStreak := streaks[targetHabit.Id]
+		if targetHabit.LongestStreak != nil && *targetHabit.LongestStreak >= habitStreak.Longest {
+			habitStreak.Longest = *targetHabit.LongestStreak
+			continue
+		}
+		if err = habitRepo.UpdateLongestStreak(ctx, userId, targetHabit.Id, habitStreak.Longest); err != nil {
+			return nil, err
+		}
+	}
+
+	return streaks, nil
+}
+
+// 取得済みの履歴から各習慣の連続達成数を算出する（習慣ID -> 連続達成数）
+// NOTE: dailyTracksは日付の昇順で渡す
+func buildStreaks(habits []*habit.Habit, dailyTracks []*daily_track.DailyTrack, freezes []*streak.Freeze, today time.Time) map[string]*streak.Streak {
+	// 習慣ID -> フリーズを使用した日付
+	frozenDates := make(map[string]map[string]bool)
+	for _, freeze := range freezes {
+		if frozenDates[freeze.HabitId] == nil {
+			frozenDates[freeze.HabitId] = make(map[string]bool)
+		}
+		frozenDates[freeze.HabitId][freeze.Date] = true
+	}
+
+	streaks := make(map[string]*streak.Streak)
+	for _, h := range habits {
+		streaks[h.Id] = streak.Calculate(h, dailyTracks, frozenDates[h.Id], today)
+	}
+
+	return streaks
+}
@@ -0,0 +1,168 @@
+package serviceImpl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/internal/domain/common"
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
+	userModel "backend/internal/domain/model/user"
+	"backend/internal/infrastructure/repositoryImpl"
+)
+
+// 遡って取得する期間を区切っても、全期間の履歴から算出した場合と同じ連続達成数になる
+func TestCalculateStreaksMatchesFullHistory(t *testing.T) {
+	today := common.TruncateToDate(time.Now().UTC())
+	daily := habit.DefaultSchedule()
+	weekly := habit.Schedule{Type: habit.ScheduleTypeWeeklyQuota, TimesPerWeek: 3}
+	recorded := func(longest int) *int { return &longest }
+
+	tests := []struct {
+		name          string
+		schedule      habit.Schedule
+		longestStreak *int
+		// 今日から何日前か -> 完了したかどうか（含まれない日はdaily_trackに習慣を含めない）
+		history     func(daysAgo int) (included bool, done bool)
+		days        int
+		wantQueries int // 0の場合は確認しない
+	}{
+		{"unbroken since the first record", daily, recorded(0), func(daysAgo int) (bool, bool) {
+			return daysAgo < 200, true
+		}, 260, 0},
+		{"broken within the first window", daily, recorded(380), func(daysAgo int) (bool, bool) {
+			return true, daysAgo != 20
+		}, 400, 1},
+		{"paused across windows", daily, recorded(0), func(daysAgo int) (bool, bool) {
+			return daysAgo == 0 || daysAgo > 100, true
+		}, 300, 0},
+		{"weekly quota", weekly, recorded(0), func(daysAgo int) (bool, bool) {
+			weekday := today.AddDate(0, 0, -daysAgo).Weekday()
+			return true, weekday == time.Monday || weekday == time.Tuesday || weekday == time.Wednesday
+		}, 250, 0},
+		{"without a longest record", daily, nil, func(daysAgo int) (bool, bool) {
+			return true, daysAgo < 30 || daysAgo > 40
+		}, 300, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targetHabit := &habit.Habit{Id: "habit-1", UserId: "user-1", Name: "読書", Schedule: tt.schedule, Status: habit.StatusActive, LongestStreak: tt.longestStreak}
+			habitRepo := &fakeHabitRepo{habits: map[string]*habit.Habit{targetHabit.Id: targetHabit}}
+			dailyTrackRepo := &fakeDailyTrackRepo{dailyTracks: map[string]*daily_track.DailyTrack{}}
+
+			var allTracks []*daily_track.DailyTrack
+			for daysAgo := tt.days - 1; daysAgo >= 0; daysAgo-- {
+				date := common.FormatDate(today.AddDate(0, 0, -daysAgo))
+				dailyTrack := &daily_track.DailyTrack{Id: date, UserId: "user-1", Date: date, HabitStatuses: []*daily_track.HabitStatus{}}
+				if included, done := tt.history(daysAgo); included {
+					dailyTrack.HabitStatuses = append(dailyTrack.HabitStatuses, &daily_track.HabitStatus{HabitId: targetHabit.Id, HabitName: targetHabit.Name, IsDone: done})
+				}
+				dailyTrackRepo.dailyTracks["user-1/"+date] = dailyTrack
+				allTracks = append(allTracks, dailyTrack)
+			}
+			want := buildStreaks([]*habit.Habit{targetHabit}, allTracks, nil, today)[targetHabit.Id]
+
+			streakService := NewStreakService(newTestClient(t),
+				&fakeUserRepo{users: map[string]*userModel.User{"user-1": {Id: "user-1", Timezone: "UTC"}}},
+				habitRepo, dailyTrackRepo, &fakeStreakFreezeRepo{}, nil)
+			streaks, err := streakService.calculateStreaks(context.Background(), "user-1", []*habit.Habit{targetHabit})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := streaks[targetHabit.Id]
+			if got.Current != want.Current {
+				t.Errorf("current = %d, want %d", got.Current, want.Current)
+			}
+			wantLongest := want.Longest
+			if tt.longestStreak != nil {
+				wantLongest = max(wantLongest, *tt.longestStreak)
+			}
+			if got.Longest != wantLongest || targetHabit.LongestStreak == nil || *targetHabit.LongestStreak != wantLongest {
+				t.Errorf("longest = %d (recorded %v), want %d", got.Longest, targetHabit.LongestStreak, wantLongest)
+			}
+			if tt.wantQueries > 0 && dailyTrackRepo.queries != tt.wantQueries {
+				t.Errorf("queried %d times, want %d", dailyTrackRepo.queries, tt.wantQueries)
+			}
+		})
+	}
+}
+
+// ストリークフリーズを所持していない場合は、使用を登録しない
+func TestUseFreezeWithoutTokens(t *testing.T) {
+	yesterday := common.FormatDate(time.Now().UTC().AddDate(0, 0, -1))
+
+	userRepo := &fakeUserRepo{users: map[string]*userModel.User{"user-1": {Id: "user-1", Timezone: "UTC"}}}
+	habitRepo := &fakeHabitRepo{habits: map[string]*habit.Habit{
+		"habit-1": {Id: "habit-1", UserId: "user-1", Name: "読書", Schedule: habit.DefaultSchedule(), Status: habit.StatusActive},
+	}}
+	streakFreezeRepo := &fakeStreakFreezeRepo{}
+
+	streakService := NewStreakService(newTestClient(t), userRepo, habitRepo, &fakeDailyTrackRepo{}, streakFreezeRepo, nil)
+	if _, _, err := streakService.UseFreeze(context.Background(), "user-1", "habit-1", yesterday); !errors.Is(err, common.ErrNoFreezeTokens) {
+		t.Fatalf("got error %v, want ErrNoFreezeTokens", err)
+	}
+	if len(streakFreezeRepo.freezes) > 0 {
+		t.Errorf("registered %d freezes, want none", len(streakFreezeRepo.freezes))
+	}
+}
+
+// 所持数の減算と使用の登録は、どちらかが失敗した場合にもう一方も取り消される（MongoDBを使用する）
+func TestUseFreezeTransaction(t *testing.T) {
+	client, db := newTestDatabase(t)
+	ctx := context.Background()
+	yesterday := common.FormatDate(time.Now().UTC().AddDate(0, 0, -1))
+
+	userRepo := repositoryImpl.NewUserRepository(db.Collection("user"))
+	habitRepo := repositoryImpl.NewHabitRepository(db.Collection("habits"))
+	streakFreezeRepo := repositoryImpl.NewStreakFreezeRepository(db.Collection("streak_freezes"))
+	streakService := NewStreakService(client, userRepo, habitRepo,
+		repositoryImpl.NewDailyTrackRepository(db.Collection("daily_track")), streakFreezeRepo, nil)
+
+	registeredUser, err := userRepo.Register(ctx, &userModel.User{Username: "freeze-user", Password: "password", Timezone: "UTC"})
+	if err != nil {
+		t.Fatalf("failed to register user: %v", err)
+	}
+	registeredHabit, err := habitRepo.Register(ctx, &habit.Habit{UserId: registeredUser.Id, Name: "読書", Schedule: habit.DefaultSchedule(), Status: habit.StatusActive})
+	if err != nil {
+		t.Fatalf("failed to register habit: %v", err)
+	}
+
+	assertState := func(wantTokens int, wantFreezes int) {
+		t.Helper()
+		user, err := userRepo.Find(ctx, registeredUser.Id)
+		if err != nil {
+			t.Fatalf("failed to find user: %v", err)
+		}
+		freezes, err := streakFreezeRepo.FetchAll(ctx, registeredUser.Id)
+		if err != nil {
+			t.Fatalf("failed to fetch freezes: %v", err)
+		}
+		if user.FreezeTokens != wantTokens || len(freezes) != wantFreezes {
+			t.Errorf("tokens = %d, freezes = %d, want %d, %d", user.FreezeTokens, len(freezes), wantTokens, wantFreezes)
+		}
+	}
+
+	// 所持していない場合は使用を登録しない
+	if _, _, err = streakService.UseFreeze(ctx, registeredUser.Id, registeredHabit.Id, yesterday); !errors.Is(err, common.ErrNoFreezeTokens) {
+		t.Fatalf("got error %v, want ErrNoFreezeTokens", err)
+	}
+	assertState(0, 0)
+
+	if _, err = userRepo.IncrementFreezeTokens(ctx, registeredUser.Id, 2); err != nil {
+		t.Fatalf("failed to add freeze tokens: %v", err)
+	}
+	if _, _, err = streakService.UseFreeze(ctx, registeredUser.Id, registeredHabit.Id, yesterday); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertState(1, 1)
+
+	// 同じ日に使用済みの場合は、所持数も減らさない
+	if _, _, err = streakService.UseFreeze(ctx, registeredUser.Id, registeredHabit.Id, yesterday); !errors.Is(err, common.ErrAlreadyExists) {
+		t.Fatalf("got error %v, want ErrAlreadyExists", err)
+	}
+	assertState(1, 1)
+}
@@ -0,0 +1,44 @@
+package serviceImpl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"backend/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// テスト用のデータベース（TEST_DATABASE_URIが未設定の場合はスキップする）
+// NOTE: テストごとに別のデータベースを作成し、終了時に削除する
+func newTestDatabase(t *testing.T) (*mongo.Client, *mongo.Database) {
+	t.Helper()
+
+	uri := os.Getenv("TEST_DATABASE_URI")
+	if uri == "" {
+		t.Skip("TEST_DATABASE_URI is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	db := client.Database(fmt.Sprintf("test_%d", time.Now().UnixNano()))
+	if err = database.EnsureIndexes(ctx, db); err != nil {
+		t.Fatalf("failed to ensure indexes: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Drop(context.Background())
+		client.Disconnect(context.Background())
+	})
+	return client, db
+}
@@ -0,0 +1,333 @@
+package serviceImpl
+
+// serviceImpl規約
+// ・エラーはhandlerに返すのみ。handler側でログ出力する。
+// ・複数のrepositoryメソッドもしくはデータを変更するrepositoryメソッドを使用する場合はトランザクションを実行する
+// ・repositoryのメソッドに渡すcontext.Contextについて
+//   -> トランザクションが不要な場合はhandlerから受け取ったctxをそのまま渡す
+//   -> トランザクションを実行する場合はmongo.SessionContextを渡す（mongo.SessionContextはcontext.Contextインターフェースを満たしている）
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend/internal/domain/model/daily_track"
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/transfer"
+	"backend/internal/domain/model/user"
+	"backend/internal/domain/repository"
+	"backend/internal/infrastructure/importer"
+)
+
+// 全期間のdaily_trackを取得する際の範囲
+const (
+	transferFromDate = "0001-01-01"
+	transferToDate   = "9999-12-31"
+)
+
+type transferService struct {
+	client           *mongo.Client
+	userRepo         repository.UserRepository
+	habitRepo        repository.HabitRepository
+	dailyTrackRepo   repository.DailyTrackRepository
+	pointLedgerRepo  repository.PointLedgerRepository
+	streakFreezeRepo repository.StreakFreezeRepository
+}
+
+func NewTransferService(
+	client *mongo.Client,
+	userRepo repository.UserRepository,
+	habitRepo repository.HabitRepository,
+	dailyTrackRepo repository.DailyTrackRepository,
+	pointLedgerRepo repository.PointLedgerRepository,
+	streakFreezeRepo repository.StreakFreezeRepository,
+) *transferService {
+	return &transferService{
+		client:           client,
+		userRepo:         userRepo,
+		habitRepo:        habitRepo,
+		dailyTrackRepo:   dailyTrackRepo,
+		pointLedgerRepo:  pointLedgerRepo,
+		streakFreezeRepo: streakFreezeRepo,
+	}
+}
+
+// ユーザーの習慣・daily_track・ポイント台帳をexporterに書き出す
+// NOTE: daily_track・ポイント台帳はDBから1件ずつ読み込んで書き出す（全件をメモリに載せない）
+func (s *transferService) Export(ctx context.Context, userId string, exporter transfer.Exporter) error {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		targetUser, err := s.userRepo.Find(sessionContext, userId)
+		if err != nil {
+			return err
+		}
+		exportedUser := transfer.ExportedUser{
+			Username:     targetUser.Username,
+			Points:       targetUser.Points,
+			FreezeTokens: targetUser.FreezeTokens,
+			Timezone:     targetUser.Timezone,
+			DayStartHour: targetUser.DayStartHour,
+		}
+
+		// アーカイブ済みの習慣も含める
+		habits, err := s.habitRepo.FetchAll(sessionContext, userId)
+		if err != nil {
+			return err
+		}
+
+		if err := exporter.WriteHeader(time.Now(), exportedUser, habits); err != nil {
+			return err
+		}
+		if err := s.dailyTrackRepo.EachDailyTrack(sessionContext, userId, exporter.WriteDailyTrack); err != nil {
+			return err
+		}
+		if err := s.pointLedgerRepo.EachEntry(sessionContext, userId, exporter.WriteLedgerEntry); err != nil {
+			return err
+		}
+		return exporter.Close()
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// エクスポートしたドキュメントを取り込む
+// 習慣は新しいIDで登録し、daily_trackの習慣IDを付け替える
+// mergeの場合、同名の既存の習慣には既存のIDを使い、同じ日のdaily_trackは統合する
+// replaceの場合、既存の習慣・daily_track・ストリークフリーズを削除し、日付の設定をドキュメントの値に合わせる
+// NOTE: ポイント・ストリークフリーズの所持数はクライアントが書き換えられるため、ドキュメントの値は使わず現在の値を維持する
+// ポイント台帳も追記のみのため、エントリは取り込まない
+// NOTE: dryRunの場合は何も変更せず、取り込まれる内容のみを返す
+func (s *transferService) Import(ctx context.Context, userId string, document *transfer.Document, mode transfer.Mode, dryRun bool) (*transfer.ImportResult, error) {
+	if err := document.Validate(); err != nil {
+		return nil, err
+	}
+
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	// NOTE: replaceの場合は既存のデータを削除してから取り込むため、途中で失敗した場合は削除も含めて全て取り消す
+	var result *transfer.ImportResult
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		// NOTE: 一時的なエラーで再実行される場合があるため、結果はここで初期化する
+		result = &transfer.ImportResult{Mode: mode, DryRun: dryRun, CreatedHabitNames: make([]string, 0)}
+
+		if mode == transfer.ModeReplace && !dryRun {
+			if err := s.deleteAll(sessionContext, userId); err != nil {
+				return nil, err
+			}
+		}
+
+		// 習慣の登録（ドキュメント内のID -> 登録後のID）
+		habitIds, err := s.importHabits(sessionContext, userId, document.Habits, result)
+		if err != nil {
+			return nil, err
+		}
+
+		// daily_trackの登録・統合
+		if err := s.importDailyTracks(sessionContext, userId, document.DailyTracks, habitIds, result); err != nil {
+			return nil, err
+		}
+
+		if mode != transfer.ModeReplace {
+			return nil, nil
+		}
+
+		// 日付の設定をドキュメントの値に合わせる
+		return nil, s.restoreDaySettings(sessionContext, userId, &document.User, result)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// 他の習慣管理アプリのデータを変換して取り込む
+// NOTE: 日付の設定は外部のデータに含まれないため、replaceの場合も現在の値を維持する
+func (s *transferService) ImportExternal(ctx context.Context, userId string, source transfer.Source, data []byte, mode transfer.Mode, dryRun bool) (*transfer.ImportResult, error) {
+	targetUser, err := s.userRepo.Find(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 履歴の日時はユーザーのタイムゾーンで日付に変換する
+	document, err := importer.Parse(source, data, targetUser.Location())
+	if err != nil {
+		return nil, err
+	}
+
+	document.User = transfer.ExportedUser{
+		Username:     targetUser.Username,
+		Points:       targetUser.Points,
+		FreezeTokens: targetUser.FreezeTokens,
+		Timezone:     targetUser.Timezone,
+		DayStartHour: targetUser.DayStartHour,
+	}
+
+	return s.Import(ctx, userId, document, mode, dryRun)
+}
+
+// 既存の習慣・daily_track・ストリークフリーズを削除する
+func (s *transferService) deleteAll(ctx context.Context, userId string) error {
+	if err := s.habitRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.dailyTrackRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	return s.streakFreezeRepo.DeleteAll(ctx, userId)
+}
+
+// 習慣を表示順に登録し、ドキュメント内のIDから登録後のIDへの対応を返す
+func (s *transferService) importHabits(ctx context.Context, userId string, habits []*habit.Habit, result *transfer.ImportResult) (map[string]string, error) {
+	existingHabits, err := s.fetchExistingHabits(ctx, userId, result)
+	if err != nil {
+		return nil, err
+	}
+	existingIds := make(map[string]string)
+	for _, existingHabit := range existingHabits {
+		existingIds[existingHabit.Name] = existingHabit.Id
+	}
+
+	sortedHabits := make([]*habit.Habit, len(habits))
+	copy(sortedHabits, habits)
+	sort.SliceStable(sortedHabits, func(i, j int) bool {
+		return sortedHabits[i].SortOrder < sortedHabits[j].SortOrder
+	})
+
+	habitIds := make(map[string]string)
+	for _, importedHabit := range sortedHabits {
+		// 同名の習慣がある場合は既存の習慣に対応付ける
+		if existingId, ok := existingIds[importedHabit.Name]; ok {
+			habitIds[importedHabit.Id] = existingId
+			result.MatchedHabits++
+			continue
+		}
+
+		result.CreatedHabits++
+		result.CreatedHabitNames = append(result.CreatedHabitNames, importedHabit.Name)
+		if result.DryRun {
+			habitIds[importedHabit.Id] = importedHabit.Id
+			continue
+		}
+
+		newHabit := &habit.Habit{
+			UserId:   userId,
+			Name:     importedHabit.Name,
+			Schedule: importedHabit.Schedule,
+			Measure:  importedHabit.Measure,
+			Status:   habit.StatusActive,
+		}
+		newHabit, err = s.habitRepo.Register(ctx, newHabit)
+		if err != nil {
+			return nil, err
+		}
+
+		// 一時停止・アーカイブの状態を反映
+		if importedHabit.Status == habit.StatusPaused || importedHabit.Status == habit.StatusArchived {
+			newHabit.Status = importedHabit.Status
+			newHabit.PausedUntil = importedHabit.PausedUntil
+			newHabit.ArchivedAt = importedHabit.ArchivedAt
+			if err = s.habitRepo.Update(ctx, newHabit); err != nil {
+				return nil, err
+			}
+		}
+
+		habitIds[importedHabit.Id] = newHabit.Id
+	}
+
+	return habitIds, nil
+}
+
+// 取り込み先の既存の習慣を取得する（replaceの場合は削除済みのため空）
+func (s *transferService) fetchExistingHabits(ctx context.Context, userId string, result *transfer.ImportResult) ([]*habit.Habit, error) {
+	if result.Mode == transfer.ModeReplace {
+		return nil, nil
+	}
+	return s.habitRepo.FetchAll(ctx, userId)
+}
+
+// daily_trackの習慣IDを付け替え、存在しない日は登録、存在する日は統合する
+func (s *transferService) importDailyTracks(ctx context.Context, userId string, dailyTracks []*daily_track.DailyTrack, habitIds map[string]string, result *transfer.ImportResult) error {
+	// 取り込み先の既存のdaily_track（replaceの場合は削除済みのため空）
+	var existingTracks []*daily_track.DailyTrack
+	if result.Mode != transfer.ModeReplace {
+		var err error
+		existingTracks, err = s.dailyTrackRepo.FindDailyTracks(ctx, userId, transferFromDate, transferToDate)
+		if err != nil {
+			return err
+		}
+	}
+	existingByDate := make(map[string]*daily_track.DailyTrack)
+	for _, existingTrack := range existingTracks {
+		existingByDate[existingTrack.Date] = existingTrack
+	}
+
+	var newTracks []*daily_track.DailyTrack
+	for _, importedTrack := range dailyTracks {
+		track := &daily_track.DailyTrack{UserId: userId, Date: importedTrack.Date}
+		for _, habitStatus := range importedTrack.HabitStatuses {
+			track.HabitStatuses = append(track.HabitStatuses, &daily_track.HabitStatus{
+				HabitId:      habitIds[habitStatus.HabitId],
+				HabitName:    habitStatus.HabitName,
+				IsDone:       habitStatus.IsDone,
+				WeeklyTarget: habitStatus.WeeklyTarget,
+				Measure:      habitStatus.Measure,
+				Value:        habitStatus.Value,
+			})
+		}
+
+		existingTrack, ok := existingByDate[track.Date]
+		if !ok {
+			newTracks = append(newTracks, track)
+			continue
+		}
+
+		if !transfer.MergeHabitStatuses(existingTrack, track) {
+			continue
+		}
+		result.MergedDailyTracks++
+		if result.DryRun {
+			continue
+		}
+		if err := s.dailyTrackRepo.UpdateHabitStatuses(ctx, existingTrack); err != nil {
+			return err
+		}
+	}
+
+	result.CreatedDailyTracks = len(newTracks)
+	if result.DryRun {
+		return nil
+	}
+
+	return s.dailyTrackRepo.RegisterDailyTracks(ctx, newTracks)
+}
+
+// 日付の設定をドキュメントの値に合わせる（有効な場合のみ）
+func (s *transferService) restoreDaySettings(ctx context.Context, userId string, exportedUser *transfer.ExportedUser, result *transfer.ImportResult) error {
+	if result.DryRun || user.ValidateDaySettings(exportedUser.Timezone, exportedUser.DayStartHour) != nil {
+		return nil
+	}
+
+	return s.userRepo.UpdateDaySettings(ctx, userId, exportedUser.Timezone, exportedUser.DayStartHour)
+}
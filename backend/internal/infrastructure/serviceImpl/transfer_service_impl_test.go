@@ -0,0 +1,54 @@
+package serviceImpl
+
+import (
+	"context"
+	"testing"
+
+	"backend/internal/domain/model/habit"
+	"backend/internal/domain/model/transfer"
+	userModel "backend/internal/domain/model/user"
+	"backend/internal/infrastructure/repositoryImpl"
+)
+
+// replaceで取り込んでも、ポイント・ストリークフリーズの所持数はドキュメントの値にしない（MongoDBを使用する）
+func TestImportReplaceKeepsBalances(t *testing.T) {
+	client, db := newTestDatabase(t)
+	ctx := context.Background()
+
+	userRepo := repositoryImpl.NewUserRepository(db.Collection("user"))
+	pointLedgerRepo := repositoryImpl.NewPointLedgerRepository(db.Collection("points_ledger"))
+	transferService := NewTransferService(client, userRepo,
+		repositoryImpl.NewHabitRepository(db.Collection("habits")),
+		repositoryImpl.NewDailyTrackRepository(db.Collection("daily_track")), pointLedgerRepo,
+		repositoryImpl.NewStreakFreezeRepository(db.Collection("streak_freezes")))
+
+	registeredUser, err := userRepo.Register(ctx, &userModel.User{Username: "import-user", Password: "password", Timezone: "UTC"})
+	if err != nil {
+		t.Fatalf("failed to register user: %v", err)
+	}
+
+	document := &transfer.Document{
+		Version: transfer.CurrentVersion,
+		User:    transfer.ExportedUser{Username: "import-user", Points: 1000000, FreezeTokens: 100, Timezone: "Asia/Tokyo", DayStartHour: 4},
+		Habits:  []*habit.Habit{{Id: "h1", Name: "読書", Schedule: habit.DefaultSchedule()}},
+	}
+	if _, err = transferService.Import(ctx, registeredUser.Id, document, transfer.ModeReplace, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	importedUser, err := userRepo.Find(ctx, registeredUser.Id)
+	if err != nil {
+		t.Fatalf("failed to find user: %v", err)
+	}
+	if importedUser.Points != 0 || importedUser.FreezeTokens != 0 {
+		t.Errorf("points = %d, freeze tokens = %d, want 0, 0", importedUser.Points, importedUser.FreezeTokens)
+	}
+	if experience, err := pointLedgerRepo.SumEarned(ctx, registeredUser.Id); err != nil || experience != 0 {
+		t.Errorf("experience = %d (%v), want 0", experience, err)
+	}
+
+	// 日付の設定はドキュメントの値に合わせる
+	if importedUser.Timezone != "Asia/Tokyo" || importedUser.DayStartHour != 4 {
+		t.Errorf("day settings = %s %d, want Asia/Tokyo 4", importedUser.Timezone, importedUser.DayStartHour)
+	}
+}
@@ -9,6 +9,7 @@ package serviceImpl
 
 import (
 	"context"
+	"errors"
 	"os"
 	"time"
 
@@ -18,19 +19,44 @@ import (
 
 	"backend/internal/config"
 	"backend/internal/domain/common"
+	"backend/internal/domain/model/notification"
+	sessionModel "backend/internal/domain/model/session"
 	userModel "backend/internal/domain/model/user"
 	"backend/internal/domain/repository"
 )
 
+// userServiceが使用するリポジトリ
+// NOTE: アカウント削除でユーザーに紐づく全てのデータを削除するため、ほぼ全てのリポジトリに依存する
+type UserServiceDeps struct {
+	UserRepo             repository.UserRepository
+	HabitRepo            repository.HabitRepository
+	DailyTrackRepo       repository.DailyTrackRepository
+	PointLedgerRepo      repository.PointLedgerRepository
+	StreakFreezeRepo     repository.StreakFreezeRepository
+	SessionRepo          repository.SessionRepository
+	RewardRepo           repository.RewardRepository
+	RedemptionRepo       repository.RewardRedemptionRepository
+	AchievementRepo      repository.AchievementRepository
+	RankingRepo          repository.RankingRepository
+	FriendshipRepo       repository.FriendshipRepository
+	HabitShareRepo       repository.HabitShareRepository
+	ChallengeRepo        repository.ChallengeRepository
+	FeedItemRepo         repository.FeedItemRepository
+	CheerRepo            repository.CheerRepository
+	ReminderRepo         repository.ReminderRepository
+	ReminderDeliveryRepo repository.ReminderDeliveryRepository
+	PushSubscriptionRepo repository.PushSubscriptionRepository
+}
+
 type userService struct {
-	client   *mongo.Client
-	userRepo repository.UserRepository
+	client *mongo.Client
+	UserServiceDeps
 }
 
-func NewUserService(client *mongo.Client, userRepo repository.UserRepository) *userService {
+func NewUserService(client *mongo.Client, deps UserServiceDeps) *userService {
 	return &userService{
-		client:   client,
-		userRepo: userRepo,
+		client:          client,
+		UserServiceDeps: deps,
 	}
 }
 
@@ -46,7 +72,7 @@ func (s *userService) SignUp(ctx context.Context, userName string, password stri
 	var resultUser *userModel.User
 	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
 		// 同一usernameが登録済みかどうかのチェック
-		_, err := s.userRepo.FindByUserName(sessionContext, userName)
+		_, err := s.UserRepo.FindByUserName(sessionContext, userName)
 
 		if err != nil && err != common.ErrNotFound {
 			return err
@@ -57,7 +83,7 @@ func (s *userService) SignUp(ctx context.Context, userName string, password stri
 
 		// 登録
 		user := userModel.User{Username: userName, Password: password, Points: 0}
-		resultUser, err = s.userRepo.Register(sessionContext, &user)
+		resultUser, err = s.UserRepo.Register(sessionContext, &user)
 		if err != nil {
 			return err
 		}
@@ -74,41 +100,509 @@ func (s *userService) SignUp(ctx context.Context, userName string, password stri
 
 }
 
-func (s *userService) Login(ctx context.Context, userName string, password string) (*userModel.User, string, error) {
-	var jwtSecret = []byte(os.Getenv("JWT_SECRET_KEY"))
+func (s *userService) Login(ctx context.Context, userName string, password string) (*userModel.User, *sessionModel.TokenPair, error) {
 	var user *userModel.User
-	var tokenString string
 
 	// ユーザー取得
-	user, err := s.userRepo.FindByUserName(ctx, userName)
+	user, err := s.UserRepo.FindByUserName(ctx, userName)
 
 	if err != nil {
-		return nil, tokenString, err
+		return nil, nil, err
 	}
 
 	// パスワードチェック
 	// bcrypt.CompareHashAndPasswordが保存されているハッシュ値とユーザーが入力したパスワードが一致するかを検証
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		return nil, tokenString, common.ErrPasswordMismatch
+		return nil, nil, common.ErrPasswordMismatch
 	}
 	user.Password = ""
 
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var tokenPair *sessionModel.TokenPair
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		// レベルの算出
+		user.Level, err = userLevel(sessionContext, s.PointLedgerRepo, user.Id)
+		if err != nil {
+			return err
+		}
+
+		// ログインセッションの作成
+		refreshSecret, err := sessionModel.NewRefreshSecret()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		loginSession := &sessionModel.Session{
+			UserId:           user.Id,
+			RefreshTokenHash: sessionModel.HashRefreshSecret(refreshSecret),
+			ExpiresAt:        now.AddDate(0, 0, config.RefreshTokenExpirationDay),
+			CreatedAt:        now,
+		}
+		loginSession, err = s.SessionRepo.Register(sessionContext, loginSession)
+		if err != nil {
+			return err
+		}
+
+		// トークンの発行
+		tokenPair, err = issueTokenPair(user, loginSession.Id, refreshSecret, now)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, tokenPair, nil
+}
+
+// リフレッシュトークンを使用して新しいトークンの組を発行する（リフレッシュトークンは使い捨て）
+// 使用済みのリフレッシュトークンが再利用された場合は、漏洩とみなしてセッションを失効させる
+func (s *userService) RefreshToken(ctx context.Context, refreshToken string) (*sessionModel.TokenPair, error) {
+	sessionId, refreshSecret, err := sessionModel.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	tokenHash := sessionModel.HashRefreshSecret(refreshSecret)
+
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var tokenPair *sessionModel.TokenPair
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		loginSession, err := s.SessionRepo.Find(sessionContext, sessionId)
+		if errors.Is(err, common.ErrNotFound) {
+			return common.ErrInvalidToken
+		}
+		if err != nil {
+			return err
+		}
+
+		// 使用済みのリフレッシュトークンの再利用を検知
+		if loginSession.IsUsedToken(tokenHash) {
+			return s.revokeReusedSession(sessionContext, loginSession)
+		}
+		if loginSession.RefreshTokenHash != tokenHash {
+			return common.ErrInvalidToken
+		}
+		now := time.Now()
+		if !loginSession.IsActive(now) {
+			return common.ErrSessionRevoked
+		}
+
+		user, err := s.UserRepo.Find(sessionContext, loginSession.UserId)
+		if err != nil {
+			return err
+		}
+
+		// リフレッシュトークンのローテーション
+		newRefreshSecret, err := sessionModel.NewRefreshSecret()
+		if err != nil {
+			return err
+		}
+		expiresAt := now.AddDate(0, 0, config.RefreshTokenExpirationDay)
+		err = s.SessionRepo.Rotate(sessionContext, sessionId, tokenHash, sessionModel.HashRefreshSecret(newRefreshSecret), expiresAt)
+		if errors.Is(err, common.ErrNotFound) {
+			// 同じリフレッシュトークンで先にローテーションされていた場合も再利用とみなす
+			return s.revokeReusedSession(sessionContext, loginSession)
+		}
+		if err != nil {
+			return err
+		}
+
+		// トークンの発行
+		tokenPair, err = issueTokenPair(user, sessionId, newRefreshSecret, now)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tokenPair, nil
+}
+
+// ログアウト（ログイン中のセッションのみ失効させる）
+func (s *userService) Logout(ctx context.Context, userId string, sessionId string) error {
+	return s.SessionRepo.Revoke(ctx, userId, sessionId)
+}
+
+// 全端末からログアウト（ユーザーのすべてのセッションを失効させる）
+func (s *userService) LogoutAll(ctx context.Context, userId string) error {
+	return s.SessionRepo.RevokeAll(ctx, userId)
+}
+
+// アクセストークンのセッションが有効かどうかを検証する
+func (s *userService) ValidateSession(ctx context.Context, userId string, sessionId string) error {
+	loginSession, err := s.SessionRepo.Find(ctx, sessionId)
+	if errors.Is(err, common.ErrNotFound) {
+		return common.ErrInvalidToken
+	}
+	if err != nil {
+		return err
+	}
+
+	if loginSession.UserId != userId {
+		return common.ErrInvalidToken
+	}
+	if !loginSession.IsActive(time.Now()) {
+		return common.ErrSessionRevoked
+	}
+
+	return nil
+}
+
+// パスワード変更（現在のパスワードが必要）
+// 変更後は、ログイン中のセッション以外をすべて失効させる
+// パスワードの更新とセッションの失効は1つのトランザクションで行う
+func (s *userService) ChangePassword(ctx context.Context, userId string, sessionId string, currentPassword string, newPassword string) error {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		if err := s.verifyPassword(sessionContext, userId, currentPassword); err != nil {
+			return nil, err
+		}
+
+		// 更新
+		if err := s.UserRepo.UpdatePassword(sessionContext, userId, newPassword); err != nil {
+			return nil, err
+		}
+
+		// 他の端末のセッションを失効
+		return nil, s.SessionRepo.RevokeOthers(sessionContext, userId, sessionId)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ユーザーネーム変更（登録済みのユーザーネームは使用できない）
+func (s *userService) ChangeUsername(ctx context.Context, userId string, userName string) (*userModel.User, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var resultUser *userModel.User
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		// 同一usernameが登録済みかどうかのチェック
+		existingUser, err := s.UserRepo.FindByUserName(sessionContext, userName)
+
+		if err != nil && err != common.ErrNotFound {
+			return err
+		}
+		if err == nil && existingUser.Id != userId {
+			return common.ErrAlreadyExists
+		}
+
+		// 更新
+		if err := s.UserRepo.UpdateUsername(sessionContext, userId, userName); err != nil {
+			return err
+		}
+
+		resultUser, err = s.UserRepo.Find(sessionContext, userId)
+		if err != nil {
+			return err
+		}
+		resultUser.Password = ""
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resultUser, nil
+}
+
+// アカウント削除（パスワードが必要）
+// 習慣・daily_track・ストリークフリーズ・ポイント台帳もあわせて削除し、全てのセッションを失効させる
+func (s *userService) DeleteAccount(ctx context.Context, userId string, password string) error {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	// NOTE: 途中で失敗した場合に一部のデータだけが削除された状態にならないよう、全ての削除を1つのトランザクションで行う
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		return nil, s.deleteAccount(sessionContext, userId, password)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ユーザーと紐づく全てのデータを削除し、発行済みのトークンを無効化する
+func (s *userService) deleteAccount(ctx context.Context, userId string, password string) error {
+	if err := s.verifyPassword(ctx, userId, password); err != nil {
+		return err
+	}
+
+	// 発行済みのトークンを無効化
+	if err := s.SessionRepo.RevokeAll(ctx, userId); err != nil {
+		return err
+	}
+
+	// ユーザーに紐づくデータの削除
+	if err := s.HabitRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.DailyTrackRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.StreakFreezeRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.PointLedgerRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.RewardRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.RedemptionRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.AchievementRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.RankingRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.FriendshipRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.HabitShareRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.ChallengeRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.FeedItemRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.CheerRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.ReminderRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.ReminderDeliveryRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.PushSubscriptionRepo.DeleteAll(ctx, userId); err != nil {
+		return err
+	}
+
+	// ユーザーの削除
+	return s.UserRepo.Delete(ctx, userId)
+}
+
+// 登録されているパスワードと一致するかを検証する
+func (s *userService) verifyPassword(ctx context.Context, userId string, password string) error {
+	user, err := s.UserRepo.Find(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return common.ErrPasswordMismatch
+	}
+
+	return nil
+}
+
+// リフレッシュトークンの再利用を検知したセッションを失効させ、ErrRefreshTokenReusedを返す
+func (s *userService) revokeReusedSession(ctx context.Context, loginSession *sessionModel.Session) error {
+	if err := s.SessionRepo.Revoke(ctx, loginSession.UserId, loginSession.Id); err != nil {
+		return err
+	}
+	return common.ErrRefreshTokenReused
+}
+
+// アクセストークン（JWT）とリフレッシュトークンの組を発行する
+func issueTokenPair(user *userModel.User, sessionId string, refreshSecret string, now time.Time) (*sessionModel.TokenPair, error) {
+	var jwtSecret = []byte(os.Getenv("JWT_SECRET_KEY"))
+
 	// JWTトークンの生成
-	expirationTime := time.Now().Add(config.JwtExpirationHour * time.Hour)
+	expirationTime := now.Add(config.AccessTokenExpirationMinute * time.Minute)
 	claims := &userModel.Claims{
-		UserId:   user.Id,
-		Username: user.Username,
+		UserId:    user.Id,
+		Username:  user.Username,
+		SessionId: sessionId,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err = token.SignedString(jwtSecret)
+	tokenString, err := token.SignedString(jwtSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sessionModel.TokenPair{
+		AccessToken:  tokenString,
+		RefreshToken: sessionModel.FormatRefreshToken(sessionId, refreshSecret),
+		ExpiresAt:    expirationTime,
+	}, nil
+}
+
+// タイムゾーンと1日の始まりの時刻を更新し、更新後のユーザーを返す
+// リマインダーの送信日時も新しいタイムゾーンで計算し直す
+func (s *userService) UpdateDaySettings(ctx context.Context, userId string, timezone string, dayStartHour int) (*userModel.User, error) {
+	if err := userModel.ValidateDaySettings(timezone, dayStartHour); err != nil {
+		return nil, err
+	}
+
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var resultUser *userModel.User
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		if err := s.UserRepo.UpdateDaySettings(sessionContext, userId, timezone, dayStartHour); err != nil {
+			return err
+		}
+
+		resultUser, err = s.UserRepo.Find(sessionContext, userId)
+		if err != nil {
+			return err
+		}
+		resultUser.Password = ""
+
+		if err := rescheduleReminders(sessionContext, s.ReminderRepo, resultUser, time.Now()); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resultUser, nil
+}
+
+// ランキングの公開設定を更新（集計済みのスコアにも反映する）
+func (s *userService) UpdateLeaderboardVisibility(ctx context.Context, userId string, hidden bool) (*userModel.User, error) {
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var resultUser *userModel.User
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		if err := s.UserRepo.UpdateLeaderboardVisibility(sessionContext, userId, hidden); err != nil {
+			return nil, err
+		}
+		if err := s.RankingRepo.UpdateHidden(sessionContext, userId, hidden); err != nil {
+			return nil, err
+		}
+
+		resultUser, err = s.UserRepo.Find(sessionContext, userId)
+		if err != nil {
+			return nil, err
+		}
+		resultUser.Password = ""
+
+		return nil, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resultUser, nil
+}
+
+// 通知設定を更新（nilの場合は通知しない）
+func (s *userService) UpdateNotificationSettings(ctx context.Context, userId string, settings *notification.Settings) (*userModel.User, error) {
+	if settings != nil {
+		if err := settings.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// セッションの開始
+	session, err := s.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// トランザクションの実行
+	var resultUser *userModel.User
+	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
+		if err := s.UserRepo.UpdateNotificationSettings(sessionContext, userId, settings); err != nil {
+			return err
+		}
+
+		resultUser, err = s.UserRepo.Find(sessionContext, userId)
+		if err != nil {
+			return err
+		}
+		resultUser.Password = ""
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resultUser, nil
+}
+
+// ログイン中のユーザー情報（レベルを含む）
+func (s *userService) GetProfile(ctx context.Context, userId string) (*userModel.User, error) {
+	user, err := s.UserRepo.Find(ctx, userId)
 	if err != nil {
-		return nil, tokenString, err
+		return nil, err
+	}
+	user.Password = ""
+
+	user.Level, err = userLevel(ctx, s.PointLedgerRepo, userId)
+	if err != nil {
+		return nil, err
 	}
 
-	return user, tokenString, nil
+	return user, nil
 }
@@ -1,11 +1,15 @@
 package middleware
 
 import (
+	"errors"
+	"log"
 	"net/http"
 	"os"
 	"strings"
 
+	"backend/internal/domain/common"
 	"backend/internal/domain/model/user"
+	"backend/internal/domain/service"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
@@ -22,7 +26,8 @@ func InitJWTSecret() {
 	}
 }
 
-func AuthMiddleware() gin.HandlerFunc {
+// NOTE: 署名の検証に加え、トークンのセッションが失効していないかをDBで確認する
+func AuthMiddleware(userService service.UserService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -57,8 +62,23 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// 認証成功、ユーザーIDをコンテキストに保存
+		// セッションの確認（ログアウト済み・失効済みのトークンは拒否）
+		if err := userService.ValidateSession(c.Request.Context(), claims.UserId, claims.SessionId); err != nil {
+			if errors.Is(err, common.ErrInvalidToken) || errors.Is(err, common.ErrSessionRevoked) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+				c.Abort()
+				return
+			}
+
+			log.Printf("[ERROR] %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "エラーが発生しました。"})
+			c.Abort()
+			return
+		}
+
+		// 認証成功、ユーザーID・セッションIDをコンテキストに保存
 		c.Set("user_id", claims.UserId)
+		c.Set("session_id", claims.SessionId)
 		c.Next()
 	}
 }
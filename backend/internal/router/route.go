@@ -3,6 +3,7 @@ package router
 import (
 	"net/http"
 
+	"backend/internal/domain/service"
 	"backend/internal/handler"
 	"backend/internal/middleware"
 
@@ -11,9 +12,24 @@ import (
 
 // 必要な依存性をまとめた構造体
 type RouterConfig struct {
-	UserHandler       *handler.UserHandler
-	HabitHandler      *handler.HabitHandler
-	DailyTrackHandler *handler.DailyTrackHandler
+	UserHandler        *handler.UserHandler
+	HabitHandler       *handler.HabitHandler
+	DailyTrackHandler  *handler.DailyTrackHandler
+	PointHandler       *handler.PointHandler
+	StreakHandler      *handler.StreakHandler
+	StatsHandler       *handler.StatsHandler
+	TransferHandler    *handler.TransferHandler
+	RewardHandler      *handler.RewardHandler
+	AchievementHandler *handler.AchievementHandler
+	LeaderboardHandler *handler.LeaderboardHandler
+	FriendHandler      *handler.FriendHandler
+	ChallengeHandler   *handler.ChallengeHandler
+	FeedHandler        *handler.FeedHandler
+	ReminderHandler    *handler.ReminderHandler
+	PushHandler        *handler.PushHandler
+
+	// 認証ミドルウェアでのセッション確認に使用
+	UserService service.UserService
 }
 
 func NewRouter(config *RouterConfig) *gin.Engine {
@@ -34,17 +50,112 @@ func NewRouter(config *RouterConfig) *gin.Engine {
 	// ログイン
 	r.POST("/login", config.UserHandler.Login)
 
+	// トークンの再発行
+	r.POST("/token/refresh", config.UserHandler.RefreshToken)
+
 	protected := r.Group("/auth")
-	protected.Use(middleware.AuthMiddleware())
+	protected.Use(middleware.AuthMiddleware(config.UserService))
 	{
+		// ログアウト
+		protected.POST("/logout", config.UserHandler.Logout)
+		protected.POST("/logout/all", config.UserHandler.LogoutAll)
+
 		// 習慣トラック
+		protected.GET("/daily_track", config.DailyTrackHandler.GetDailyTracks)
 		protected.GET("/daily_track/:date", config.DailyTrackHandler.GetDailyTrack)
 		protected.POST("/daily_track/done", config.DailyTrackHandler.UpdateDoneDailyTrack)
+		protected.POST("/daily_track/undone", config.DailyTrackHandler.UndoDoneDailyTrack)
+		protected.POST("/daily_track/log", config.DailyTrackHandler.LogHabitAmount)
 
 		// 習慣の管理
 		protected.GET("/habit/list", config.HabitHandler.GetHabitList)
 		protected.POST("/habit/register", config.HabitHandler.RegisterHabit)
-		protected.DELETE("/habit/:id/delete", config.HabitHandler.DeleteHabit)
+		protected.PUT("/habit/order", config.HabitHandler.ReorderHabits)
+		protected.PUT("/habit/:id", config.HabitHandler.UpdateHabit)
+		protected.DELETE("/habit/:id/delete", config.HabitHandler.ArchiveHabit)
+		protected.POST("/habit/:id/archive", config.HabitHandler.ArchiveHabit)
+		protected.POST("/habit/:id/restore", config.HabitHandler.RestoreHabit)
+		protected.POST("/habit/:id/pause", config.HabitHandler.PauseHabit)
+		protected.POST("/habit/:id/resume", config.HabitHandler.ResumeHabit)
+		protected.DELETE("/habit/:id/purge", config.HabitHandler.PurgeHabit)
+
+		// リマインダー
+		protected.GET("/habit/:id/reminders", config.ReminderHandler.GetReminders)
+		protected.PUT("/habit/:id/reminders", config.ReminderHandler.UpdateReminders)
+
+		// Web Push（ブラウザ通知）
+		protected.GET("/push/vapid_public_key", config.PushHandler.GetPublicKey)
+		protected.POST("/push/subscriptions", config.PushHandler.Subscribe)
+		protected.DELETE("/push/subscriptions", config.PushHandler.Unsubscribe)
+
+		// 連続達成
+		protected.GET("/habit/:id/streak", config.StreakHandler.GetStreak)
+		protected.POST("/habit/:id/streak/freeze", config.StreakHandler.UseFreeze)
+		protected.POST("/streak/freeze/purchase", config.StreakHandler.PurchaseFreezeToken)
+
+		// 統計
+		protected.GET("/stats", config.StatsHandler.GetStats)
+
+		// ポイント
+		protected.GET("/points/history", config.PointHandler.GetPointHistory)
+
+		// 実績
+		protected.GET("/achievements", config.AchievementHandler.GetAchievements)
+
+		// ランキング（points / weekly / monthly / streak）
+		protected.GET("/leaderboards/:board", config.LeaderboardHandler.GetLeaderboard)
+
+		// フレンド
+		protected.GET("/friends", config.FriendHandler.GetFriends)
+		protected.POST("/friends/requests", config.FriendHandler.RequestFriend)
+		protected.POST("/friends/:id/accept", config.FriendHandler.AcceptFriend)
+		protected.DELETE("/friends/:id", config.FriendHandler.RemoveFriend)
+		protected.POST("/friends/:id/block", config.FriendHandler.BlockUser)
+		protected.DELETE("/friends/:id/block", config.FriendHandler.UnblockUser)
+		protected.PUT("/friends/:id/shares", config.FriendHandler.ShareHabits)
+		protected.GET("/friends/:id/daily_track/:date", config.FriendHandler.GetFriendDailyTrack)
+
+		// グループチャレンジ
+		protected.GET("/challenges", config.ChallengeHandler.GetChallenges)
+		protected.POST("/challenges", config.ChallengeHandler.CreateChallenge)
+		protected.DELETE("/challenges/:id", config.ChallengeHandler.DeleteChallenge)
+		protected.POST("/challenges/:id/invite", config.ChallengeHandler.InviteParticipant)
+		protected.POST("/challenges/:id/join", config.ChallengeHandler.JoinChallenge)
+		protected.POST("/challenges/:id/leave", config.ChallengeHandler.LeaveChallenge)
+		protected.GET("/challenges/:id/board", config.ChallengeHandler.GetChallengeBoard)
+
+		// フィード（フレンドの完了への応援）
+		protected.GET("/feed", config.FeedHandler.GetFeed)
+		protected.POST("/feed/:id/reactions", config.FeedHandler.AddReaction)
+		protected.DELETE("/feed/:id/reactions/:emoji", config.FeedHandler.RemoveReaction)
+		protected.POST("/feed/:id/comments", config.FeedHandler.AddComment)
+		protected.DELETE("/feed/comments/:id", config.FeedHandler.DeleteComment)
+
+		// ごほうび
+		protected.GET("/rewards", config.RewardHandler.GetRewards)
+		protected.POST("/rewards", config.RewardHandler.RegisterReward)
+		protected.GET("/rewards/redemptions", config.RewardHandler.GetRedemptionHistory)
+		protected.PUT("/rewards/:id", config.RewardHandler.UpdateReward)
+		protected.DELETE("/rewards/:id", config.RewardHandler.DeleteReward)
+		protected.POST("/rewards/:id/redeem", config.RewardHandler.RedeemReward)
+
+		// データのエクスポート・インポート
+		protected.GET("/export", config.TransferHandler.Export)
+		protected.POST("/import", config.TransferHandler.Import)
+		protected.POST("/import/external", config.TransferHandler.ImportExternal)
+
+		// ログイン中のユーザー情報（レベルを含む）
+		protected.GET("/me", config.UserHandler.GetProfile)
+
+		// ユーザー設定
+		protected.PUT("/user/settings", config.UserHandler.UpdateDaySettings)
+		protected.PUT("/user/notification", config.UserHandler.UpdateNotificationSettings)
+		protected.PUT("/user/privacy", config.UserHandler.UpdatePrivacySettings)
+
+		// アカウント管理
+		protected.PUT("/user/password", config.UserHandler.ChangePassword)
+		protected.PUT("/user/username", config.UserHandler.ChangeUsername)
+		protected.DELETE("/user", config.UserHandler.DeleteAccount)
 	}
 
 	return r
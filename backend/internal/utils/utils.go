@@ -14,3 +14,14 @@ func GetUserIdFromContext(c *gin.Context) string {
 	userId = loginedUserId.(string)
 	return userId
 }
+
+// Gin Context からセッションIDを取得する
+func GetSessionIdFromContext(c *gin.Context) string {
+	// NOTE: session_idの検証はミドルウェアに実装
+	sessionId, exists := c.Get("session_id")
+	if !exists {
+		return ""
+	}
+
+	return sessionId.(string)
+}